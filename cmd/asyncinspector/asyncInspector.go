@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	mc "github.com/multiversx/mx-chain-scenario-go/controller"
+	am "github.com/multiversx/mx-chain-vm-go/scenarioexec"
+	"github.com/multiversx/mx-chain-vm-go/vmhost/contexts"
+)
+
+// Lists the async contexts persisted in the contract storages, either after running a scenario,
+// or from a state dump, and exits with an error code if any of them is orphaned.
+//
+// The state dump is a JSON object of the form {"<hex address>": {"<hex key>": "<hex value>"}}.
+func main() {
+	dumpPath := flag.String("dump", "", "inspect the storage in this state dump instead of running a scenario")
+	flag.Parse()
+
+	var persistedContexts []*contexts.PersistedAsyncContext
+	var err error
+	switch {
+	case len(*dumpPath) > 0:
+		persistedContexts, err = inspectStateDump(*dumpPath)
+	case flag.NArg() == 1:
+		persistedContexts, err = inspectScenario(flag.Arg(0))
+	default:
+		err = fmt.Errorf("expected either -dump <state dump> or the path to a scenario")
+	}
+	if err != nil {
+		fmt.Printf("ERROR: %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	numOrphaned := 0
+	for _, persisted := range persistedContexts {
+		fmt.Print(persisted.String())
+		if persisted.IsOrphaned() {
+			numOrphaned++
+		}
+	}
+	fmt.Printf("%d persisted async contexts, %d orphaned\n", len(persistedContexts), numOrphaned)
+
+	if numOrphaned > 0 {
+		os.Exit(1)
+	}
+}
+
+func inspectScenario(scenarioPath string) ([]*contexts.PersistedAsyncContext, error) {
+	executor, err := am.NewVMTestExecutor()
+	if err != nil {
+		return nil, err
+	}
+
+	runner := mc.NewScenarioController(executor, mc.NewDefaultFileResolver())
+	err = runner.RunSingleJSONScenario(scenarioPath, mc.DefaultRunScenarioOptions())
+	if err != nil {
+		// the state left by a failed scenario is often the interesting one
+		fmt.Printf("scenario failed: %s\n", err.Error())
+	}
+
+	return executor.InspectAsyncContexts(), nil
+}
+
+func inspectStateDump(dumpPath string) ([]*contexts.PersistedAsyncContext, error) {
+	data, err := os.ReadFile(dumpPath)
+	if err != nil {
+		return nil, err
+	}
+
+	hexStorages := make(map[string]map[string]string)
+	err = json.Unmarshal(data, &hexStorages)
+	if err != nil {
+		return nil, fmt.Errorf("invalid state dump %s: %w", dumpPath, err)
+	}
+
+	storages := make(map[string]map[string][]byte, len(hexStorages))
+	for hexAddress, hexStorage := range hexStorages {
+		address, errDecode := hex.DecodeString(hexAddress)
+		if errDecode != nil {
+			return nil, fmt.Errorf("invalid address %s in state dump: %w", hexAddress, errDecode)
+		}
+
+		storage := make(map[string][]byte, len(hexStorage))
+		for hexKey, hexValue := range hexStorage {
+			key, errKey := hex.DecodeString(hexKey)
+			value, errValue := hex.DecodeString(hexValue)
+			if errKey != nil || errValue != nil {
+				return nil, fmt.Errorf("invalid storage entry %s of address %s in state dump", hexKey, hexAddress)
+			}
+			storage[string(key)] = value
+		}
+		storages[string(address)] = storage
+	}
+
+	return contexts.InspectAsyncContexts(storages), nil
+}
@@ -3,11 +3,13 @@ package scenariostestcli
 import (
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
 	mc "github.com/multiversx/mx-chain-scenario-go/controller"
+	worldmock "github.com/multiversx/mx-chain-vm-go/mock/world"
 	am "github.com/multiversx/mx-chain-vm-go/scenarioexec"
 	"github.com/multiversx/mx-chain-vm-go/wasmer"
 	"github.com/multiversx/mx-chain-vm-go/wasmer2"
@@ -26,17 +28,96 @@ func resolveArgument(exeDir string, arg string) (string, bool, error) {
 	return arg, fi.IsDir(), nil
 }
 
-func parseOptionFlags() *mc.RunScenarioOptions {
+type cliOptions struct {
+	runOptions  *mc.RunScenarioOptions
+	gasSweep    *am.GasSweepOptions
+	estimateGas bool
+
+	gasSnapshotPath      string
+	updateGasSnapshot    bool
+	gasSnapshotTolerance float64
+
+	updateExpectations bool
+
+	callGraphDir    string
+	debugPrint      bool
+	opcodeTracePath string
+	trieDepth       bool
+	systemSCs       bool
+
+	checkESDTGlobalSettings bool
+
+	flagMatrix bool
+	releases   []string
+}
+
+func parseOptionFlags() *cliOptions {
 	forceTraceGas := flag.Bool("force-trace-gas", false, "overrides the traceGas option in the scenarios")
 	useWasmer1 := flag.Bool("wasmer1", false, "use the wasmer1 executor")
 	useWasmer2 := flag.Bool("wasmer2", false, "use the wasmer2 executor")
+	gasSweep := flag.Bool("gas-sweep", false, "re-run each scCall and scDeploy with all gas limits up to the one in the scenario and report behaviour changes")
+	gasSweepMin := flag.Uint64("gas-sweep-min", 0, "the first gas limit tried by the gas sweep")
+	gasSweepStep := flag.Uint64("gas-sweep-step", 1, "the distance between two gas limits tried by the gas sweep")
+	gasSweepBisect := flag.Bool("gas-sweep-bisect", false, "only sample the gas limits needed to locate behaviour changes")
+	estimateGas := flag.Bool("estimate-gas", false, "print the minimal gas limit of each scCall and scDeploy next to the one in the scenario")
+	gasSnapshotPath := flag.String("gas-snapshot", "", "check the gas used by each scCall and scDeploy against this gas snapshot file")
+	updateGasSnapshot := flag.Bool("update-gas-snapshot", false, "rewrite the gas snapshot file instead of checking it")
+	gasSnapshotTolerance := flag.Float64("gas-snapshot-tolerance", 0, "accepted gas change, as a percentage of the gas in the snapshot")
+	updateExpectations := flag.Bool("update-expectations", false, "rewrite the expect blocks and checkState steps of the scenarios with the actual results")
+	callGraphDir := flag.String("call-graph", "", "write the call graph of each transaction to this directory, as DOT and JSON")
+	debugPrint := flag.Bool("debug-print", false, "allow the contracts to use the debug print hooks, which print to the standard output; runs on wasmer1, the only executor providing them")
+	opcodeTracePath := flag.String("opcode-trace", "", "write the gas used by each contract call and the opcodes of each contract to this file, as JSON lines; runs on wasmer1, the only executor providing them")
+	trieDepth := flag.Bool("trie-depth", false, "charge the storage loads by the depth of the keys in a simulated data trie, as on a real node")
+	systemSCs := flag.Bool("system-scs", false, "run the calls to the ESDT, staking, delegation and governance system SCs on their Go stand-ins")
+	checkESDTGlobalSettings := flag.Bool("check-esdt-global-settings", false, "compare the global settings of the tokens, kept in the storage of the system account, in the checkState steps")
+	flagMatrix := flag.Bool("flag-matrix", false, "run each scenario under all the combinations of the epoch flags it declares and report behaviour changes")
+	releases := flag.String("releases", "", "run each scenario under the epoch flags of these comma-separated protocol releases, or \"all\", and report behaviour changes")
+	enableEpochsPath := flag.String("enable-epochs", "", "take the protocol releases of -releases from the activation epochs in this enableEpochs.toml of a node, one release per epoch")
 	flag.Parse()
 
-	return &mc.RunScenarioOptions{
-		ForceTraceGas: *forceTraceGas,
-		UseWasmer1:    *useWasmer1,
-		UseWasmer2:    *useWasmer2,
+	options := &cliOptions{
+		runOptions: &mc.RunScenarioOptions{
+			ForceTraceGas: *forceTraceGas,
+			UseWasmer1:    *useWasmer1,
+			UseWasmer2:    *useWasmer2,
+		},
+		estimateGas:             *estimateGas,
+		gasSnapshotPath:         *gasSnapshotPath,
+		updateGasSnapshot:       *updateGasSnapshot,
+		gasSnapshotTolerance:    *gasSnapshotTolerance,
+		updateExpectations:      *updateExpectations,
+		callGraphDir:            *callGraphDir,
+		debugPrint:              *debugPrint,
+		opcodeTracePath:         *opcodeTracePath,
+		trieDepth:               *trieDepth,
+		systemSCs:               *systemSCs,
+		checkESDTGlobalSettings: *checkESDTGlobalSettings,
+		flagMatrix:              *flagMatrix,
+	}
+	if len(*enableEpochsPath) > 0 {
+		protocolReleases, err := worldmock.LoadProtocolReleases(*enableEpochsPath)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		worldmock.ProtocolReleases = protocolReleases
 	}
+	if *releases == "all" {
+		for _, release := range worldmock.ProtocolReleases {
+			options.releases = append(options.releases, release.Name)
+		}
+	} else if len(*releases) > 0 {
+		options.releases = strings.Split(*releases, ",")
+	}
+	if *gasSweep {
+		options.gasSweep = &am.GasSweepOptions{
+			MinGasLimit: *gasSweepMin,
+			Step:        *gasSweepStep,
+			Bisect:      *gasSweepBisect,
+		}
+	}
+
+	return options
 }
 
 // ScenariosTestCLI provides the functionality for any scenarios test executor.
@@ -62,44 +143,63 @@ func ScenariosTestCLI() {
 	}
 
 	// init
-	executor, err := am.NewVMTestExecutor()
+	var opcodeTraceWriter io.Writer
+	if len(options.opcodeTracePath) > 0 {
+		opcodeTraceFile, errCreate := os.Create(options.opcodeTracePath)
+		if errCreate != nil {
+			fmt.Println(errCreate)
+			os.Exit(1)
+		}
+		defer func() {
+			_ = opcodeTraceFile.Close()
+		}()
+		opcodeTraceWriter = opcodeTraceFile
+	}
+	executor, err := newExecutor(options, opcodeTraceWriter)
 	if err != nil {
-		panic("Could not instantiate VM VM")
+		fmt.Printf("Could not instantiate VM: %s\n", err.Error())
+		os.Exit(1)
 	}
-	if options.UseWasmer1 {
-		executor.OverrideVMExecutor = wasmer.ExecutorFactory()
-	}
-	if options.UseWasmer2 {
-		executor.OverrideVMExecutor = wasmer2.ExecutorFactory()
+	if len(options.gasSnapshotPath) > 0 {
+		executor.GasSnapshot = am.NewGasSnapshot()
+		executor.GasSnapshot.BaseDir = filepath.Dir(options.gasSnapshotPath)
 	}
 
 	// execute
 	switch {
+	case options.flagMatrix || len(options.releases) > 0:
+		err = runFlagMatrix(options, opcodeTraceWriter, jsonFilePath, isDir)
+	case options.updateExpectations:
+		err = updateExpectations(executor, jsonFilePath, isDir)
 	case isDir:
 		runner := mc.NewScenarioController(
 			executor,
-			mc.NewDefaultFileResolver(),
+			am.NewScenarioFileResolver(),
 		)
 		err = runner.RunAllJSONScenariosInDirectory(
 			jsonFilePath,
 			"",
 			".scen.json",
 			[]string{},
-			options)
+			options.runOptions)
 	case strings.HasSuffix(jsonFilePath, ".scen.json"):
 		runner := mc.NewScenarioController(
 			executor,
-			mc.NewDefaultFileResolver(),
+			am.NewScenarioFileResolver(),
 		)
-		err = runner.RunSingleJSONScenario(jsonFilePath, options)
+		err = runner.RunSingleJSONScenario(jsonFilePath, options.runOptions)
 	default:
 		runner := mc.NewTestRunner(
 			executor,
-			mc.NewDefaultFileResolver(),
+			am.NewScenarioFileResolver(),
 		)
 		err = runner.RunSingleJSONTest(jsonFilePath)
 	}
 
+	if err == nil && executor.GasSnapshot != nil {
+		err = processGasSnapshot(options, executor.GasSnapshot)
+	}
+
 	// print result
 	if err == nil {
 		fmt.Println("SUCCESS")
@@ -108,3 +208,125 @@ func ScenariosTestCLI() {
 		os.Exit(1)
 	}
 }
+
+func newExecutor(options *cliOptions, opcodeTraceWriter io.Writer) (*am.VMTestExecutor, error) {
+	executor, err := am.NewVMTestExecutor()
+	if err != nil {
+		return nil, err
+	}
+	if options.runOptions.UseWasmer1 {
+		executor.OverrideVMExecutor = wasmer.ExecutorFactory()
+	}
+	if options.runOptions.UseWasmer2 {
+		executor.OverrideVMExecutor = wasmer2.ExecutorFactory()
+	}
+	executor.GasSweep = options.gasSweep
+	executor.GasReportWriter = os.Stdout
+	executor.EstimateGas = options.estimateGas
+	executor.CallGraphDir = options.callGraphDir
+	executor.World.SimulateTrieDepth = options.trieDepth
+	executor.CheckESDTGlobalSettings = options.checkESDTGlobalSettings
+	if options.systemSCs {
+		executor.EnableSystemSCs()
+	}
+	if options.debugPrint {
+		if options.runOptions.UseWasmer2 {
+			return nil, wasmer2.ErrDebugHooksNotSupported
+		}
+		executor.OverrideVMExecutor = wasmer.ExecutorFactory()
+		executor.DebugPrintWriter = os.Stdout
+	}
+	if opcodeTraceWriter != nil {
+		if options.runOptions.UseWasmer2 {
+			return nil, wasmer2.ErrOpcodeTraceNotSupported
+		}
+		executor.OverrideVMExecutor = wasmer.ExecutorFactory()
+		executor.OpcodeTraceWriter = opcodeTraceWriter
+	}
+
+	return executor, nil
+}
+
+func processGasSnapshot(options *cliOptions, actual *am.GasSnapshot) error {
+	snapshot, err := am.LoadGasSnapshot(options.gasSnapshotPath)
+	if err != nil {
+		return err
+	}
+
+	if options.updateGasSnapshot {
+		snapshot.Merge(actual)
+		return snapshot.Save(options.gasSnapshotPath)
+	}
+
+	diffs := snapshot.Compare(actual, options.gasSnapshotTolerance)
+	return am.GasSnapshotDiffsError(diffs)
+}
+
+func updateExpectations(executor *am.VMTestExecutor, path string, isDir bool) error {
+	if !isDir {
+		return executor.UpdateScenarioExpectations(path)
+	}
+
+	return filepath.Walk(path, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil || !strings.HasSuffix(filePath, ".scen.json") {
+			return err
+		}
+
+		fmt.Printf("Update: %s\n", filePath)
+		return executor.UpdateScenarioExpectations(filePath)
+	})
+}
+
+func runFlagMatrix(options *cliOptions, opcodeTraceWriter io.Writer, path string, isDir bool) error {
+	scenarioPaths := []string{path}
+	if isDir {
+		scenarioPaths = make([]string, 0)
+		err := filepath.Walk(path, func(filePath string, info os.FileInfo, err error) error {
+			if err == nil && strings.HasSuffix(filePath, ".scen.json") {
+				scenarioPaths = append(scenarioPaths, filePath)
+			}
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	newMatrixExecutor := func() (*am.VMTestExecutor, error) {
+		return newExecutor(options, opcodeTraceWriter)
+	}
+
+	allDiffs := make([]*am.FlagMatrixDiff, 0)
+	for _, scenarioPath := range scenarioPaths {
+		flagSets, err := scenarioFlagSets(options, scenarioPath)
+		if err != nil {
+			return err
+		}
+		if len(flagSets) == 0 {
+			fmt.Printf("%s: no declared flags, skipped\n", scenarioPath)
+			continue
+		}
+
+		report, err := am.RunFlagMatrix(scenarioPath, flagSets, newMatrixExecutor, options.runOptions)
+		if err != nil {
+			return err
+		}
+		fmt.Print(report.String())
+		allDiffs = append(allDiffs, report.Compare()...)
+	}
+
+	return am.FlagMatrixDiffsError(allDiffs)
+}
+
+func scenarioFlagSets(options *cliOptions, scenarioPath string) ([]*am.FlagSet, error) {
+	if len(options.releases) > 0 {
+		return am.ReleaseFlagSets(options.releases)
+	}
+
+	declared, err := am.DeclaredFlags(scenarioPath)
+	if err != nil || len(declared) == 0 {
+		return nil, err
+	}
+
+	return am.MatrixFlagSets(declared)
+}
@@ -0,0 +1,19 @@
+package executor
+
+// DebugVMHooks contains the debug functions which contracts can call during tests. They are not part
+// of the generated VMHooks: the wasmer executor adds them to its imports, and the hosts only accept contracts
+// importing them, when the debug hooks are enabled. The imports table of wasmer2 is fixed and does not contain them.
+type DebugVMHooks interface {
+	DebugPrintManagedBuffer(mBufferHandle int32)
+	DebugPrintBigInt(bigIntHandle int32)
+	DebugDumpManagedVector(managedVecHandle int32)
+	DebugPrintGasLeft()
+}
+
+// DebugVMHookNames holds the import names of the DebugVMHooks functions
+var DebugVMHookNames = []string{
+	"debugPrintManagedBuffer",
+	"debugPrintBigInt",
+	"debugDumpManagedVector",
+	"debugPrintGasLeft",
+}
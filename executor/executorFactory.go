@@ -8,6 +8,12 @@ type ExecutorFactoryArgs struct {
 	OpcodeCosts              *WASMOpcodeCost
 	RkyvSerializationEnabled bool
 	WasmerSIGSEGVPassthrough bool
+
+	// DebugHooksEnabled makes the executor provide the DebugVMHooks, next to the VMHooks
+	DebugHooksEnabled bool
+
+	// OpcodeTraceEnabled announces that the instances will be compiled with the OpcodeTrace option
+	OpcodeTraceEnabled bool
 }
 
 // ExecutorAbstractFactory defines an object to be passed to the VM to configure the instantiation of the Executor.
@@ -0,0 +1,41 @@
+package executor
+
+import "reflect"
+
+// OpcodeTraceEntry is an opcode of a contract function, along with the gas points the WASMOpcodeCost table charges for it.
+type OpcodeTraceEntry struct {
+	FunctionIndex uint32 `json:"functionIndex"`
+	Opcode        string `json:"opcode"`
+	GasPoints     uint64 `json:"gasPoints"`
+}
+
+// OpcodeTracingInstance is implemented by the instances created with the OpcodeTrace compilation option.
+type OpcodeTracingInstance interface {
+	// CompiledOpcodes returns the opcodes of the contract functions, in the order they were compiled.
+	CompiledOpcodes() []OpcodeTraceEntry
+}
+
+// OpcodeTraceRecord holds the gas points consumed by an instance during a single function call.
+// The opcodes of a contract code are only held by the first record of that code.
+type OpcodeTraceRecord struct {
+	InstanceID string             `json:"instanceID"`
+	Address    string             `json:"address"`
+	CodeHash   string             `json:"codeHash"`
+	Function   string             `json:"function"`
+	GasPoints  uint64             `json:"gasPoints"`
+	Opcodes    []OpcodeTraceEntry `json:"opcodes,omitempty"`
+}
+
+// OpcodeGasPoints returns the gas points charged for the opcode with the given name, which is the name of its field
+// in WASMOpcodeCost, or 0 if the opcode is unknown.
+func OpcodeGasPoints(opcodeCosts *WASMOpcodeCost, opcode string) uint64 {
+	if opcodeCosts == nil {
+		return 0
+	}
+
+	cost := reflect.ValueOf(opcodeCosts).Elem().FieldByName(opcode)
+	if !cost.IsValid() {
+		return 0
+	}
+	return cost.Uint()
+}
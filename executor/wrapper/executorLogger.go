@@ -2,6 +2,7 @@ package executorwrapper
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
 	logger "github.com/multiversx/mx-chain-logger-go"
@@ -94,3 +95,30 @@ func (*NoLogger) LogVMHookCallBefore(_ string) {}
 
 // LogVMHookCallAfter does nothing.
 func (*NoLogger) LogVMHookCallAfter(_ string) {}
+
+// ExecutorLogWriter is an io.Writer recording each line written to it as an executor event, for instance
+// the messages of the debug hooks, so that they appear in the executor logs between the VM hook calls.
+type ExecutorLogWriter struct {
+	logger ExecutorLogger
+	writer io.Writer
+}
+
+// NewExecutorLogWriter creates a new ExecutorLogWriter, which also forwards the written data to the given writer, if any.
+func NewExecutorLogWriter(logger ExecutorLogger, writer io.Writer) *ExecutorLogWriter {
+	return &ExecutorLogWriter{
+		logger: logger,
+		writer: writer,
+	}
+}
+
+// Write records the written lines as executor events.
+func (elw *ExecutorLogWriter) Write(data []byte) (int, error) {
+	for _, line := range strings.Split(strings.TrimSuffix(string(data), "\n"), "\n") {
+		elw.logger.LogExecutorEvent(line)
+	}
+	if elw.writer == nil {
+		return len(data), nil
+	}
+
+	return elw.writer.Write(data)
+}
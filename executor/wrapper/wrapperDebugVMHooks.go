@@ -0,0 +1,56 @@
+package executorwrapper
+
+import (
+	"fmt"
+
+	"github.com/multiversx/mx-chain-vm-go/executor"
+)
+
+var _ executor.DebugVMHooks = (*WrapperVMHooks)(nil)
+
+// The DebugVMHooks are not generated with the VMHooks, so their wrappers are written by hand.
+// The calls are only forwarded if the wrapped VMHooks provide the debug hooks.
+
+// DebugPrintManagedBuffer VM hook wrapper
+func (w *WrapperVMHooks) DebugPrintManagedBuffer(mBufferHandle int32) {
+	callInfo := fmt.Sprintf("DebugPrintManagedBuffer(%d)", mBufferHandle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	debugVMHooks, ok := w.wrappedVMHooks.(executor.DebugVMHooks)
+	if ok {
+		debugVMHooks.DebugPrintManagedBuffer(mBufferHandle)
+	}
+	w.logger.LogVMHookCallAfter(callInfo)
+}
+
+// DebugPrintBigInt VM hook wrapper
+func (w *WrapperVMHooks) DebugPrintBigInt(bigIntHandle int32) {
+	callInfo := fmt.Sprintf("DebugPrintBigInt(%d)", bigIntHandle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	debugVMHooks, ok := w.wrappedVMHooks.(executor.DebugVMHooks)
+	if ok {
+		debugVMHooks.DebugPrintBigInt(bigIntHandle)
+	}
+	w.logger.LogVMHookCallAfter(callInfo)
+}
+
+// DebugDumpManagedVector VM hook wrapper
+func (w *WrapperVMHooks) DebugDumpManagedVector(managedVecHandle int32) {
+	callInfo := fmt.Sprintf("DebugDumpManagedVector(%d)", managedVecHandle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	debugVMHooks, ok := w.wrappedVMHooks.(executor.DebugVMHooks)
+	if ok {
+		debugVMHooks.DebugDumpManagedVector(managedVecHandle)
+	}
+	w.logger.LogVMHookCallAfter(callInfo)
+}
+
+// DebugPrintGasLeft VM hook wrapper
+func (w *WrapperVMHooks) DebugPrintGasLeft() {
+	callInfo := "DebugPrintGasLeft()"
+	w.logger.LogVMHookCallBefore(callInfo)
+	debugVMHooks, ok := w.wrappedVMHooks.(executor.DebugVMHooks)
+	if ok {
+		debugVMHooks.DebugPrintGasLeft()
+	}
+	w.logger.LogVMHookCallAfter(callInfo)
+}
@@ -38,6 +38,8 @@ func (factory *WrapperExecutorFactory) CreateExecutor(args executor.ExecutorFact
 		OpcodeCosts:              args.OpcodeCosts,
 		RkyvSerializationEnabled: args.RkyvSerializationEnabled,
 		WasmerSIGSEGVPassthrough: args.WasmerSIGSEGVPassthrough,
+		DebugHooksEnabled:        args.DebugHooksEnabled,
+		OpcodeTraceEnabled:       args.OpcodeTraceEnabled,
 	})
 	if err != nil {
 		return nil, err
@@ -158,3 +158,12 @@ func (inst *WrapperInstance) GetVMHooksPtr() uintptr {
 func (inst *WrapperInstance) ID() string {
 	return inst.wrappedInstance.ID()
 }
+
+// CompiledOpcodes wraps the call to the underlying instance, if it was compiled with opcode tracing.
+func (inst *WrapperInstance) CompiledOpcodes() []executor.OpcodeTraceEntry {
+	tracingInstance, ok := inst.wrappedInstance.(executor.OpcodeTracingInstance)
+	if !ok {
+		return nil
+	}
+	return tracingInstance.CompiledOpcodes()
+}
@@ -0,0 +1,51 @@
+package vmjsonintegrationtest
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	am "github.com/multiversx/mx-chain-vm-go/scenarioexec"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasSweep_Adder(t *testing.T) {
+	report := &bytes.Buffer{}
+	ScenariosTest(t).
+		Folder("adder/scenarios").
+		File("adder.scen.json").
+		WithExecutorSetup(func(executor *am.VMTestExecutor) {
+			executor.GasSweep = &am.GasSweepOptions{
+				MinGasLimit: 0,
+				Bisect:      true,
+			}
+			executor.GasReportWriter = report
+		}).
+		Run().
+		CheckNoError()
+
+	// the checkState steps pass, so the sweeps left the world untouched
+	reportLines := strings.Split(report.String(), "\n")
+	require.True(t, strings.HasPrefix(reportLines[0], "gas sweep of tx 1: "))
+	require.True(t, strings.HasPrefix(reportLines[1], "  gas 0: out of gas"))
+	require.Contains(t, report.String(), "gas sweep of tx 3: ")
+	require.NotContains(t, report.String(), " 0 behaviour changes")
+	require.Equal(t, 2, strings.Count(report.String(), ", 0 invariant violations"))
+}
+
+func TestGasEstimate_Adder(t *testing.T) {
+	report := &bytes.Buffer{}
+	ScenariosTest(t).
+		Folder("adder/scenarios").
+		File("adder.scen.json").
+		WithExecutorSetup(func(executor *am.VMTestExecutor) {
+			executor.EstimateGas = true
+			executor.GasReportWriter = report
+		}).
+		Run().
+		CheckNoError()
+
+	require.Contains(t, report.String(), "tx 1: gasLimit 5000000, estimated ")
+	require.Contains(t, report.String(), "tx 3: gasLimit 5000000, estimated ")
+	require.NotContains(t, report.String(), "estimate not available")
+}
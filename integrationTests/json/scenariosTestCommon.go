@@ -42,6 +42,7 @@ type ScenariosTestBuilder struct {
 	executorLogger      executorwrapper.ExecutorLogger
 	executorFactory     executor.ExecutorAbstractFactory
 	enableEpochsHandler vmi.EnableEpochsHandler
+	executorSetup       func(executor *am.VMTestExecutor)
 	currentError        error
 }
 
@@ -99,6 +100,12 @@ func (mtb *ScenariosTestBuilder) WithEnableEpochsHandler(enableEpochsHandler vmi
 	return mtb
 }
 
+// WithExecutorSetup sets a function which configures the scenario executor before running the scenarios
+func (mtb *ScenariosTestBuilder) WithExecutorSetup(executorSetup func(executor *am.VMTestExecutor)) *ScenariosTestBuilder {
+	mtb.executorSetup = executorSetup
+	return mtb
+}
+
 // Run will start the testing process
 func (mtb *ScenariosTestBuilder) Run() *ScenariosTestBuilder {
 	executor, err := am.NewVMTestExecutor()
@@ -115,6 +122,9 @@ func (mtb *ScenariosTestBuilder) Run() *ScenariosTestBuilder {
 			mtb.executorLogger,
 			mtb.executorFactory)
 	}
+	if mtb.executorSetup != nil {
+		mtb.executorSetup(executor)
+	}
 
 	runner := mc.NewScenarioController(
 		executor,
@@ -21,6 +21,8 @@ type RuntimeContextMock struct {
 	ReadOnlyFlag             bool
 	VerifyCode               bool
 	CurrentBreakpointValue   vmhost.BreakpointValue
+	DebugHooks               bool
+	DebugMessages            []string
 	PointsUsed               uint64
 	InstanceCtxID            int
 	MemLoadResult            []byte
@@ -337,11 +339,30 @@ func (r *RuntimeContextMock) IsFunctionImported(_ string) bool {
 func (r *RuntimeContextMock) AddError(_ error, _ ...string) {
 }
 
+// AddTypedError mocked method
+func (r *RuntimeContextMock) AddTypedError(_ vmhost.ErrorKind, _ error, _ ...string) {
+}
+
 // GetAllErrors mocked method
 func (r *RuntimeContextMock) GetAllErrors() error {
 	return nil
 }
 
+// GetErrorRecords mocked method
+func (r *RuntimeContextMock) GetErrorRecords() []*vmhost.ErrorRecord {
+	return nil
+}
+
+// DebugHooksEnabled mocked method
+func (r *RuntimeContextMock) DebugHooksEnabled() bool {
+	return r.DebugHooks
+}
+
+// DebugPrint mocked method
+func (r *RuntimeContextMock) DebugPrint(message string) {
+	r.DebugMessages = append(r.DebugMessages, message)
+}
+
 // EndExecution -
 func (r *RuntimeContextMock) EndExecution() {
 }
@@ -106,8 +106,16 @@ type RuntimeContextWrapper struct {
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	AddErrorFunc func(err error, otherInfo ...string)
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	AddTypedErrorFunc func(kind vmhost.ErrorKind, err error, otherInfo ...string)
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	GetAllErrorsFunc func() error
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	GetErrorRecordsFunc func() []*vmhost.ErrorRecord
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	DebugHooksEnabledFunc func() bool
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	DebugPrintFunc func(message string)
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	InitStateFunc func()
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	PushStateFunc func()
@@ -288,10 +296,26 @@ func NewRuntimeContextWrapper(inputRuntimeContext *vmhost.RuntimeContext) *Runti
 		runtimeWrapper.runtimeContext.AddError(err, otherInfo...)
 	}
 
+	runtimeWrapper.AddTypedErrorFunc = func(kind vmhost.ErrorKind, err error, otherInfo ...string) {
+		runtimeWrapper.runtimeContext.AddTypedError(kind, err, otherInfo...)
+	}
+
 	runtimeWrapper.GetAllErrorsFunc = func() error {
 		return runtimeWrapper.runtimeContext.GetAllErrors()
 	}
 
+	runtimeWrapper.GetErrorRecordsFunc = func() []*vmhost.ErrorRecord {
+		return runtimeWrapper.runtimeContext.GetErrorRecords()
+	}
+
+	runtimeWrapper.DebugHooksEnabledFunc = func() bool {
+		return runtimeWrapper.runtimeContext.DebugHooksEnabled()
+	}
+
+	runtimeWrapper.DebugPrintFunc = func(message string) {
+		runtimeWrapper.runtimeContext.DebugPrint(message)
+	}
+
 	runtimeWrapper.InitStateFunc = func() {
 		runtimeWrapper.runtimeContext.InitState()
 	}
@@ -547,11 +571,31 @@ func (contextWrapper *RuntimeContextWrapper) AddError(err error, otherInfo ...st
 	contextWrapper.AddErrorFunc(err, otherInfo...)
 }
 
+// AddTypedError calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) AddTypedError(kind vmhost.ErrorKind, err error, otherInfo ...string) {
+	contextWrapper.AddTypedErrorFunc(kind, err, otherInfo...)
+}
+
 // GetAllErrors calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
 func (contextWrapper *RuntimeContextWrapper) GetAllErrors() error {
 	return contextWrapper.GetAllErrorsFunc()
 }
 
+// GetErrorRecords calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) GetErrorRecords() []*vmhost.ErrorRecord {
+	return contextWrapper.GetErrorRecordsFunc()
+}
+
+// DebugHooksEnabled calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) DebugHooksEnabled() bool {
+	return contextWrapper.DebugHooksEnabledFunc()
+}
+
+// DebugPrint calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) DebugPrint(message string) {
+	contextWrapper.DebugPrintFunc(message)
+}
+
 // InitState calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
 func (contextWrapper *RuntimeContextWrapper) InitState() {
 	contextWrapper.InitStateFunc()
@@ -1,6 +1,7 @@
 package mock
 
 import (
+	"context"
 	"github.com/multiversx/mx-chain-core-go/data/vm"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 	"github.com/multiversx/mx-chain-vm-go/config"
@@ -25,6 +26,7 @@ type VMHostMock struct {
 	StorageContext           vmhost.StorageContext
 	EnableEpochsHandlerField vmcommon.EnableEpochsHandler
 	ManagedTypesContext      vmhost.ManagedTypesContext
+	CallGraphRecorderField   *vmhost.CallGraphRecorder
 
 	IsBuiltinFunc bool
 
@@ -167,6 +169,36 @@ func (host *VMHostMock) RunSmartContractCreate(_ *vmcommon.ContractCreateInput)
 	return nil, nil
 }
 
+// RunSmartContractCallWithContext mocked method
+func (host *VMHostMock) RunSmartContractCallWithContext(_ context.Context, _ *vmcommon.ContractCallInput) (vmOutput *vmcommon.VMOutput, err error) {
+	return nil, nil
+}
+
+// RunSmartContractCreateWithContext mocked method
+func (host *VMHostMock) RunSmartContractCreateWithContext(_ context.Context, _ *vmcommon.ContractCreateInput) (vmOutput *vmcommon.VMOutput, err error) {
+	return nil, nil
+}
+
+// RunSmartContractCreateWithInfo mocked method
+func (host *VMHostMock) RunSmartContractCreateWithInfo(_ context.Context, _ *vmcommon.ContractCreateInput) (*vmcommon.VMOutput, *vmhost.ExecutionInfo, error) {
+	return nil, nil, nil
+}
+
+// RunSmartContractCallWithInfo mocked method
+func (host *VMHostMock) RunSmartContractCallWithInfo(_ context.Context, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, *vmhost.ExecutionInfo, error) {
+	return nil, nil, nil
+}
+
+// EstimateGasForCall mocked method
+func (host *VMHostMock) EstimateGasForCall(_ *vmcommon.ContractCallInput) (*vmhost.GasEstimate, error) {
+	return nil, nil
+}
+
+// EstimateGasForCreate mocked method
+func (host *VMHostMock) EstimateGasForCreate(_ *vmcommon.ContractCreateInput) (*vmhost.GasEstimate, error) {
+	return nil, nil
+}
+
 // GasScheduleChange mocked method
 func (host *VMHostMock) GasScheduleChange(_ config.GasScheduleMap) {
 }
@@ -243,6 +275,11 @@ func (host *VMHostMock) GetNextVMOutput() *vmcommon.VMOutput {
 func (host *VMHostMock) CompleteLogEntriesWithCallType(vmOutput *vmcommon.VMOutput, callType string) {
 }
 
+// CallGraphRecorder mocked method
+func (host *VMHostMock) CallGraphRecorder() *vmhost.CallGraphRecorder {
+	return host.CallGraphRecorderField
+}
+
 // Close -
 func (host *VMHostMock) Close() error {
 	return nil
@@ -1,6 +1,8 @@
 package mock
 
 import (
+	"context"
+
 	"github.com/multiversx/mx-chain-core-go/data/vm"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 	"github.com/multiversx/mx-chain-vm-go/config"
@@ -37,12 +39,19 @@ type VMHostStub struct {
 	IsBuiltinFunctionCallCalled func(data []byte) bool
 	AreInSameShardCalled        func(left []byte, right []byte) bool
 
-	RunSmartContractCallCalled           func(input *vmcommon.ContractCallInput) (vmOutput *vmcommon.VMOutput, err error)
-	RunSmartContractCreateCalled         func(input *vmcommon.ContractCreateInput) (vmOutput *vmcommon.VMOutput, err error)
-	GetGasScheduleMapCalled              func() config.GasScheduleMap
-	GasScheduleChangeCalled              func(newGasSchedule config.GasScheduleMap)
-	IsInterfaceNilCalled                 func() bool
-	CompleteLogEntriesWithCallTypeCalled func(vmOutput *vmcommon.VMOutput, callType string)
+	RunSmartContractCallCalled              func(input *vmcommon.ContractCallInput) (vmOutput *vmcommon.VMOutput, err error)
+	RunSmartContractCreateCalled            func(input *vmcommon.ContractCreateInput) (vmOutput *vmcommon.VMOutput, err error)
+	RunSmartContractCallWithContextCalled   func(ctx context.Context, input *vmcommon.ContractCallInput) (vmOutput *vmcommon.VMOutput, err error)
+	RunSmartContractCreateWithContextCalled func(ctx context.Context, input *vmcommon.ContractCreateInput) (vmOutput *vmcommon.VMOutput, err error)
+	RunSmartContractCreateWithInfoCalled    func(ctx context.Context, input *vmcommon.ContractCreateInput) (*vmcommon.VMOutput, *vmhost.ExecutionInfo, error)
+	RunSmartContractCallWithInfoCalled      func(ctx context.Context, input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, *vmhost.ExecutionInfo, error)
+	EstimateGasForCallCalled                func(input *vmcommon.ContractCallInput) (*vmhost.GasEstimate, error)
+	EstimateGasForCreateCalled              func(input *vmcommon.ContractCreateInput) (*vmhost.GasEstimate, error)
+	GetGasScheduleMapCalled                 func() config.GasScheduleMap
+	GasScheduleChangeCalled                 func(newGasSchedule config.GasScheduleMap)
+	IsInterfaceNilCalled                    func() bool
+	CompleteLogEntriesWithCallTypeCalled    func(vmOutput *vmcommon.VMOutput, callType string)
+	CallGraphRecorderCalled                 func() *vmhost.CallGraphRecorder
 
 	SetRuntimeContextCalled func(runtime vmhost.RuntimeContext)
 
@@ -255,6 +264,54 @@ func (vhs *VMHostStub) RunSmartContractCreate(input *vmcommon.ContractCreateInpu
 	return nil, nil
 }
 
+// RunSmartContractCallWithContext mocked method
+func (vhs *VMHostStub) RunSmartContractCallWithContext(ctx context.Context, input *vmcommon.ContractCallInput) (vmOutput *vmcommon.VMOutput, err error) {
+	if vhs.RunSmartContractCallWithContextCalled != nil {
+		return vhs.RunSmartContractCallWithContextCalled(ctx, input)
+	}
+	return nil, nil
+}
+
+// RunSmartContractCreateWithContext mocked method
+func (vhs *VMHostStub) RunSmartContractCreateWithContext(ctx context.Context, input *vmcommon.ContractCreateInput) (vmOutput *vmcommon.VMOutput, err error) {
+	if vhs.RunSmartContractCreateWithContextCalled != nil {
+		return vhs.RunSmartContractCreateWithContextCalled(ctx, input)
+	}
+	return nil, nil
+}
+
+// RunSmartContractCreateWithInfo mocked method
+func (vhs *VMHostStub) RunSmartContractCreateWithInfo(ctx context.Context, input *vmcommon.ContractCreateInput) (*vmcommon.VMOutput, *vmhost.ExecutionInfo, error) {
+	if vhs.RunSmartContractCreateWithInfoCalled != nil {
+		return vhs.RunSmartContractCreateWithInfoCalled(ctx, input)
+	}
+	return nil, nil, nil
+}
+
+// RunSmartContractCallWithInfo mocked method
+func (vhs *VMHostStub) RunSmartContractCallWithInfo(ctx context.Context, input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, *vmhost.ExecutionInfo, error) {
+	if vhs.RunSmartContractCallWithInfoCalled != nil {
+		return vhs.RunSmartContractCallWithInfoCalled(ctx, input)
+	}
+	return nil, nil, nil
+}
+
+// EstimateGasForCall mocked method
+func (vhs *VMHostStub) EstimateGasForCall(input *vmcommon.ContractCallInput) (*vmhost.GasEstimate, error) {
+	if vhs.EstimateGasForCallCalled != nil {
+		return vhs.EstimateGasForCallCalled(input)
+	}
+	return nil, nil
+}
+
+// EstimateGasForCreate mocked method
+func (vhs *VMHostStub) EstimateGasForCreate(input *vmcommon.ContractCreateInput) (*vmhost.GasEstimate, error) {
+	if vhs.EstimateGasForCreateCalled != nil {
+		return vhs.EstimateGasForCreateCalled(input)
+	}
+	return nil, nil
+}
+
 // GasScheduleChange mocked method
 func (vhs *VMHostStub) GasScheduleChange(newGasSchedule config.GasScheduleMap) {
 	if vhs.GasScheduleChangeCalled != nil {
@@ -307,6 +364,14 @@ func (vhs *VMHostStub) CompleteLogEntriesWithCallType(vmOutput *vmcommon.VMOutpu
 	}
 }
 
+// CallGraphRecorder mocked method
+func (vhs *VMHostStub) CallGraphRecorder() *vmhost.CallGraphRecorder {
+	if vhs.CallGraphRecorderCalled != nil {
+		return vhs.CallGraphRecorderCalled()
+	}
+	return nil
+}
+
 // Close -
 func (vhs *VMHostStub) Close() error {
 	return nil
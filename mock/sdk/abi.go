@@ -0,0 +1,133 @@
+package mocksdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// initEndpointName is the name of the endpoint exported as the constructor of the contract
+const initEndpointName = "init"
+
+// ABI is the description of a contract, in the format of the ABI files of the Rust framework
+type ABI struct {
+	Name        string         `json:"name"`
+	Constructor *ABIEndpoint   `json:"constructor,omitempty"`
+	Endpoints   []*ABIEndpoint `json:"endpoints"`
+	Events      []*ABIEvent    `json:"events"`
+}
+
+// ABIEndpoint describes an endpoint
+type ABIEndpoint struct {
+	Name       string       `json:"name,omitempty"`
+	Mutability string       `json:"mutability,omitempty"`
+	Inputs     []*ABIInput  `json:"inputs"`
+	Outputs    []*ABIOutput `json:"outputs"`
+}
+
+// ABIInput describes an endpoint argument
+type ABIInput struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	MultiArg bool   `json:"multi_arg,omitempty"`
+}
+
+// ABIOutput describes an endpoint result
+type ABIOutput struct {
+	Type        string `json:"type"`
+	MultiResult bool   `json:"multi_result,omitempty"`
+}
+
+// ABIEvent describes an event
+type ABIEvent struct {
+	Identifier string           `json:"identifier"`
+	Inputs     []*ABIEventInput `json:"inputs"`
+}
+
+// ABIEventInput describes an event field
+type ABIEventInput struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Indexed bool   `json:"indexed,omitempty"`
+}
+
+// ABI exports the description of the endpoints and of the events of the contract; the callbacks are not exported
+func (contract *Contract) ABI() *ABI {
+	abi := &ABI{
+		Name:      contract.name,
+		Endpoints: make([]*ABIEndpoint, 0, len(contract.endpoints)),
+		Events:    make([]*ABIEvent, 0, len(contract.events)),
+	}
+
+	for _, ep := range contract.endpoints {
+		abiEndpoint := ep.abiEndpoint()
+		if ep.name == initEndpointName {
+			abiEndpoint.Name = ""
+			abiEndpoint.Mutability = ""
+			abi.Constructor = abiEndpoint
+			continue
+		}
+		abi.Endpoints = append(abi.Endpoints, abiEndpoint)
+	}
+
+	for _, event := range contract.events {
+		abiEvent := &ABIEvent{
+			Identifier: event.identifier,
+			Inputs:     make([]*ABIEventInput, 0, len(event.fields)),
+		}
+		for _, field := range event.fields {
+			name, _ := typeName(field.fieldType)
+			abiEvent.Inputs = append(abiEvent.Inputs, &ABIEventInput{
+				Name:    field.name,
+				Type:    name,
+				Indexed: field.indexed,
+			})
+		}
+		abi.Events = append(abi.Events, abiEvent)
+	}
+
+	return abi
+}
+
+// JSON returns the ABI as indented JSON
+func (abi *ABI) JSON() ([]byte, error) {
+	return json.MarshalIndent(abi, "", "    ")
+}
+
+func (ep *endpoint) abiEndpoint() *ABIEndpoint {
+	abiEndpoint := &ABIEndpoint{
+		Name:       ep.name,
+		Mutability: ep.mutability,
+		Inputs:     make([]*ABIInput, 0, len(ep.inputs)),
+		Outputs:    make([]*ABIOutput, 0, len(ep.outputs)),
+	}
+
+	for i, input := range ep.inputs {
+		isVariadic := ep.variadic && i == len(ep.inputs)-1
+		abiEndpoint.Inputs = append(abiEndpoint.Inputs, &ABIInput{
+			Name:     ep.inputNames[i],
+			Type:     abiTypeName(input, isVariadic),
+			MultiArg: isVariadic,
+		})
+	}
+
+	for _, output := range ep.outputs {
+		isMultiValue := isMultiValueType(output)
+		abiEndpoint.Outputs = append(abiEndpoint.Outputs, &ABIOutput{
+			Type:        abiTypeName(output, isMultiValue),
+			MultiResult: isMultiValue,
+		})
+	}
+
+	return abiEndpoint
+}
+
+func abiTypeName(t reflect.Type, isVariadic bool) string {
+	if isVariadic {
+		name, _ := typeName(t.Elem())
+		return fmt.Sprintf("variadic<%s>", name)
+	}
+
+	name, _ := typeName(t)
+	return name
+}
@@ -0,0 +1,82 @@
+package mocksdk
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type transferEvent struct {
+	From   Address `event:",indexed"`
+	To     Address `event:"destination,indexed"`
+	Amount *big.Int
+}
+
+func TestContract_ABI(t *testing.T) {
+	t.Parallel()
+
+	contract := NewContract("token")
+	NewEvent[transferEvent](contract, "transfer")
+	contract.
+		Endpoint("init", func(ctx *Context, supply *big.Int) {}, "supply").
+		Endpoint("send", func(ctx *Context, to Address, amounts ...uint64) error { return nil }, "to", "amounts").
+		View("balances", func(ctx *Context, owner Address) ([]*big.Int, bool) { return nil, false }).
+		Callback("sendCallback", func(ctx *Context, result *AsyncResult, tag string) {})
+
+	abi := contract.ABI()
+	require.Equal(t, &ABI{
+		Name: "token",
+		Constructor: &ABIEndpoint{
+			Inputs:  []*ABIInput{{Name: "supply", Type: "BigUint"}},
+			Outputs: []*ABIOutput{},
+		},
+		Endpoints: []*ABIEndpoint{
+			{
+				Name:       "send",
+				Mutability: "mutable",
+				Inputs: []*ABIInput{
+					{Name: "to", Type: "Address"},
+					{Name: "amounts", Type: "variadic<u64>", MultiArg: true},
+				},
+				Outputs: []*ABIOutput{},
+			},
+			{
+				Name:       "balances",
+				Mutability: "readonly",
+				Inputs:     []*ABIInput{{Name: "arg0", Type: "Address"}},
+				Outputs: []*ABIOutput{
+					{Type: "variadic<BigUint>", MultiResult: true},
+					{Type: "bool"},
+				},
+			},
+		},
+		Events: []*ABIEvent{
+			{
+				Identifier: "transfer",
+				Inputs: []*ABIEventInput{
+					{Name: "from", Type: "Address", Indexed: true},
+					{Name: "destination", Type: "Address", Indexed: true},
+					{Name: "amount", Type: "BigUint"},
+				},
+			},
+		},
+	}, abi)
+
+	_, err := abi.JSON()
+	require.Nil(t, err)
+}
+
+func TestContract_InvalidHandlers(t *testing.T) {
+	t.Parallel()
+
+	contract := NewContract("invalid")
+	require.Panics(t, func() { contract.Endpoint("noContext", func(value uint64) {}) })
+	require.Panics(t, func() { contract.Endpoint("badInput", func(ctx *Context, value float64) {}) })
+	require.Panics(t, func() { contract.Endpoint("badOutput", func(ctx *Context) map[string]int { return nil }) })
+	require.Panics(t, func() { contract.Endpoint("errorFirst", func(ctx *Context) (error, uint64) { return nil, 0 }) })
+	require.Panics(t, func() { contract.Callback("noResult", func(ctx *Context, tag string) {}) })
+	require.Panics(t, func() { contract.Callback("withResults", func(ctx *Context, result *AsyncResult) uint64 { return 0 }) })
+	require.Panics(t, func() { NewStorageField[float64]("float") })
+	require.Panics(t, func() { NewEvent[uint64](contract, "notStruct") })
+}
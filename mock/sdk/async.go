@@ -0,0 +1,93 @@
+package mocksdk
+
+import (
+	"math/big"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/txDataBuilder"
+	"github.com/multiversx/mx-chain-vm-go/vmhost/vmhooks"
+)
+
+// AsyncCall is an async call being prepared by an endpoint
+type AsyncCall struct {
+	ctx            *Context
+	destination    []byte
+	function       string
+	arguments      [][]byte
+	value          *big.Int
+	gasLimit       uint64
+	extraGasLocked uint64
+	callback       string
+	closureArgs    []interface{}
+}
+
+// AsyncResult is the outcome of an async call, received by the callbacks
+type AsyncResult struct {
+	ReturnCode vmcommon.ReturnCode
+	Data       [][]byte
+	Message    string
+}
+
+// IsOk returns true if the async call was successful
+func (result *AsyncResult) IsOk() bool {
+	return result.ReturnCode == vmcommon.Ok
+}
+
+func newAsyncResult(arguments [][]byte) *AsyncResult {
+	result := &AsyncResult{
+		ReturnCode: vmcommon.ReturnCode(big.NewInt(0).SetBytes(arguments[0]).Uint64()),
+	}
+	if result.IsOk() {
+		result.Data = arguments[1:]
+	} else if len(arguments) > 1 {
+		result.Message = string(arguments[1])
+	}
+	return result
+}
+
+// WithValue sets the EGLD value sent with the call
+func (call *AsyncCall) WithValue(value *big.Int) *AsyncCall {
+	call.value = value
+	return call
+}
+
+// WithGas sets the gas limit of the call and the extra gas locked for the callback
+func (call *AsyncCall) WithGas(gasLimit uint64, extraGasLocked uint64) *AsyncCall {
+	call.gasLimit = gasLimit
+	call.extraGasLocked = extraGasLocked
+	return call
+}
+
+// WithCallback sets the callback called both on success and on error, together with the closure
+// arguments which are passed to the callback after the async result
+func (call *AsyncCall) WithCallback(callback string, closureArgs ...interface{}) *AsyncCall {
+	call.callback = callback
+	call.closureArgs = closureArgs
+	return call
+}
+
+// Register registers the async call in the async context of the contract
+func (call *AsyncCall) Register() {
+	ctx := call.ctx
+	closure, err := encodeClosure(call.closureArgs)
+	if err != nil {
+		ctx.Fail(err)
+	}
+
+	data := txDataBuilder.NewBuilder()
+	data.Func(call.function)
+	for _, argument := range call.arguments {
+		data.Bytes(argument)
+	}
+
+	vmhooks.CreateAsyncCallWithTypedArgs(ctx.host,
+		call.destination,
+		call.value.Bytes(),
+		data.ToBytes(),
+		[]byte(call.callback),
+		[]byte(call.callback),
+		int64(call.gasLimit),
+		int64(call.extraGasLocked),
+		closure)
+	ctx.stopIfFailed()
+}
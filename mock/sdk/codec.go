@@ -0,0 +1,275 @@
+package mocksdk
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"reflect"
+)
+
+// AddressLength is the length of the addresses accepted as Address arguments
+const AddressLength = 32
+
+// Address is a 32 bytes account address, encoded as it is
+type Address []byte
+
+// lengthPrefixSize is the size of the length prefix of the dynamically sized nested values
+const lengthPrefixSize = 4
+
+var (
+	bigIntType  = reflect.TypeOf((*big.Int)(nil))
+	bytesType   = reflect.TypeOf([]byte(nil))
+	addressType = reflect.TypeOf(Address(nil))
+	stringType  = reflect.TypeOf("")
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// typeName returns the ABI name of a supported Go type, following the names of the Rust framework
+func typeName(t reflect.Type) (string, bool) {
+	switch t {
+	case bigIntType:
+		return "BigUint", true
+	case bytesType:
+		return "bytes", true
+	case addressType:
+		return "Address", true
+	case stringType:
+		return "utf-8 string", true
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return "bool", true
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fmt.Sprintf("u%d", t.Bits()), true
+	case reflect.Uint:
+		return "u64", true
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return fmt.Sprintf("i%d", t.Bits()), true
+	case reflect.Int:
+		return "i64", true
+	default:
+		return "", false
+	}
+}
+
+func isSupportedType(t reflect.Type) bool {
+	_, ok := typeName(t)
+	return ok
+}
+
+// isMultiValueType returns true for the slices which are encoded as one value per element
+func isMultiValueType(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t != bytesType && t != addressType && isSupportedType(t.Elem())
+}
+
+// fixedSize returns the size of the nested encoding of the numeric types, or 0 for the other types
+func fixedSize(t reflect.Type) int {
+	switch t.Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return t.Bits() / 8
+	case reflect.Uint, reflect.Int:
+		return 8
+	default:
+		return 0
+	}
+}
+
+// topEncode encodes a value as a top level argument or result: numbers are minimal big endian,
+// booleans are 0x01 or empty and byte-like values are kept as they are
+func topEncode(value reflect.Value) ([]byte, error) {
+	switch value.Type() {
+	case bigIntType:
+		if value.IsNil() {
+			return []byte{}, nil
+		}
+		bigValue := value.Interface().(*big.Int)
+		if bigValue.Sign() < 0 {
+			return nil, ErrNegativeBigUint
+		}
+		return bigValue.Bytes(), nil
+	case bytesType, addressType:
+		return append([]byte{}, value.Bytes()...), nil
+	case stringType:
+		return []byte(value.String()), nil
+	}
+
+	switch value.Kind() {
+	case reflect.Bool:
+		if value.Bool() {
+			return []byte{1}, nil
+		}
+		return []byte{}, nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
+		return trimUnsigned(value.Uint()), nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
+		return trimSigned(value.Int()), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, value.Type())
+	}
+}
+
+func trimUnsigned(value uint64) []byte {
+	encoded := make([]byte, 8)
+	binary.BigEndian.PutUint64(encoded, value)
+	for len(encoded) > 0 && encoded[0] == 0 {
+		encoded = encoded[1:]
+	}
+	return encoded
+}
+
+func trimSigned(value int64) []byte {
+	if value == 0 {
+		return []byte{}
+	}
+
+	encoded := make([]byte, 8)
+	binary.BigEndian.PutUint64(encoded, uint64(value))
+	for len(encoded) > 1 {
+		redundantZero := encoded[0] == 0 && encoded[1]&0x80 == 0
+		redundantOnes := encoded[0] == 0xff && encoded[1]&0x80 != 0
+		if !redundantZero && !redundantOnes {
+			break
+		}
+		encoded = encoded[1:]
+	}
+	return encoded
+}
+
+// topDecode decodes a top level argument or result into a value of the given type
+func topDecode(data []byte, t reflect.Type) (reflect.Value, error) {
+	switch t {
+	case bigIntType:
+		return reflect.ValueOf(big.NewInt(0).SetBytes(data)), nil
+	case bytesType:
+		return reflect.ValueOf(append([]byte{}, data...)), nil
+	case addressType:
+		if len(data) != AddressLength {
+			return reflect.Value{}, ErrInvalidAddressLength
+		}
+		return reflect.ValueOf(Address(append([]byte{}, data...))), nil
+	}
+
+	value := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.String:
+		value.SetString(string(data))
+	case reflect.Bool:
+		switch {
+		case len(data) == 0:
+			value.SetBool(false)
+		case len(data) == 1 && data[0] == 1:
+			value.SetBool(true)
+		default:
+			return reflect.Value{}, ErrInvalidValue
+		}
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
+		if len(data) > fixedSize(t) {
+			return reflect.Value{}, ErrInputTooLong
+		}
+		var decoded uint64
+		for _, b := range data {
+			decoded = decoded<<8 | uint64(b)
+		}
+		value.SetUint(decoded)
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
+		if len(data) > fixedSize(t) {
+			return reflect.Value{}, ErrInputTooLong
+		}
+		var decoded int64
+		if len(data) > 0 && data[0]&0x80 != 0 {
+			decoded = -1
+		}
+		for _, b := range data {
+			decoded = decoded<<8 | int64(b)
+		}
+		value.SetInt(decoded)
+	default:
+		return reflect.Value{}, fmt.Errorf("%w: %s", ErrUnsupportedType, t)
+	}
+
+	return value, nil
+}
+
+// nestedEncode encodes a value as part of a larger value, such as a storage key: numbers have
+// their full size, addresses are kept as they are and the other values are length prefixed
+func nestedEncode(value reflect.Value) ([]byte, error) {
+	encoded, err := topEncode(value)
+	if err != nil {
+		return nil, err
+	}
+
+	t := value.Type()
+	size := fixedSize(t)
+	switch {
+	case t == addressType:
+		return encoded, nil
+	case t.Kind() == reflect.Bool:
+		return append(make([]byte, 1-len(encoded)), encoded...), nil
+	case size > 0:
+		padding := make([]byte, size-len(encoded))
+		if t.Kind() >= reflect.Int && t.Kind() <= reflect.Int64 && value.Int() < 0 {
+			for i := range padding {
+				padding[i] = 0xff
+			}
+		}
+		return append(padding, encoded...), nil
+	default:
+		return appendLengthPrefixed(nil, encoded), nil
+	}
+}
+
+func appendLengthPrefixed(data []byte, value []byte) []byte {
+	prefix := make([]byte, lengthPrefixSize)
+	binary.BigEndian.PutUint32(prefix, uint32(len(value)))
+	data = append(data, prefix...)
+	return append(data, value...)
+}
+
+// encodeValues top encodes values of any supported type, passing the raw byte slices through
+func encodeValues(values []interface{}) ([][]byte, error) {
+	encoded := make([][]byte, 0, len(values))
+	for i, value := range values {
+		if value == nil {
+			return nil, fmt.Errorf("%w: nil value at position %d", ErrUnsupportedType, i)
+		}
+		data, err := topEncode(reflect.ValueOf(value))
+		if err != nil {
+			return nil, err
+		}
+		encoded = append(encoded, data)
+	}
+	return encoded, nil
+}
+
+// encodeClosure serializes the callback closure arguments as length prefixed top encoded values
+func encodeClosure(values []interface{}) ([]byte, error) {
+	encoded, err := encodeValues(values)
+	if err != nil {
+		return nil, err
+	}
+
+	closure := make([]byte, 0)
+	for _, value := range encoded {
+		closure = appendLengthPrefixed(closure, value)
+	}
+	return closure, nil
+}
+
+// decodeClosure splits a callback closure serialized by encodeClosure
+func decodeClosure(closure []byte) ([][]byte, error) {
+	values := make([][]byte, 0)
+	for len(closure) > 0 {
+		if len(closure) < lengthPrefixSize {
+			return nil, ErrInvalidCallbackClosure
+		}
+		length := binary.BigEndian.Uint32(closure[:lengthPrefixSize])
+		closure = closure[lengthPrefixSize:]
+		if uint64(len(closure)) < uint64(length) {
+			return nil, ErrInvalidCallbackClosure
+		}
+		values = append(values, closure[:length])
+		closure = closure[length:]
+	}
+	return values, nil
+}
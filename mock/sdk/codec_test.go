@@ -0,0 +1,97 @@
+package mocksdk
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodec_TopEncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	address := Address(make([]byte, AddressLength))
+	testCases := []struct {
+		value   interface{}
+		encoded []byte
+	}{
+		{big.NewInt(0), []byte{}},
+		{big.NewInt(256), []byte{1, 0}},
+		{[]byte("data"), []byte("data")},
+		{"text", []byte("text")},
+		{address, address},
+		{true, []byte{1}},
+		{false, []byte{}},
+		{uint8(0), []byte{}},
+		{uint16(0x1234), []byte{0x12, 0x34}},
+		{uint64(1), []byte{1}},
+		{int64(0), []byte{}},
+		{int64(127), []byte{0x7f}},
+		{int64(128), []byte{0x00, 0x80}},
+		{int64(-1), []byte{0xff}},
+		{int32(-129), []byte{0xff, 0x7f}},
+	}
+
+	for _, testCase := range testCases {
+		encoded, err := topEncode(reflect.ValueOf(testCase.value))
+		require.Nil(t, err)
+		require.Equal(t, testCase.encoded, encoded, "encoding %v", testCase.value)
+
+		decoded, err := topDecode(encoded, reflect.TypeOf(testCase.value))
+		require.Nil(t, err)
+		require.Equal(t, testCase.value, decoded.Interface(), "decoding %v", testCase.value)
+	}
+}
+
+func TestCodec_DecodeErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := topDecode([]byte{1, 0}, reflect.TypeOf(uint8(0)))
+	require.Equal(t, ErrInputTooLong, err)
+	_, err = topDecode([]byte{2}, reflect.TypeOf(false))
+	require.Equal(t, ErrInvalidValue, err)
+	_, err = topDecode([]byte("short"), addressType)
+	require.Equal(t, ErrInvalidAddressLength, err)
+	_, err = topEncode(reflect.ValueOf(big.NewInt(-1)))
+	require.Equal(t, ErrNegativeBigUint, err)
+	_, err = topEncode(reflect.ValueOf(1.5))
+	require.ErrorIs(t, err, ErrUnsupportedType)
+}
+
+func TestCodec_NestedEncode(t *testing.T) {
+	t.Parallel()
+
+	encoded, err := nestedEncode(reflect.ValueOf(uint32(1)))
+	require.Nil(t, err)
+	require.Equal(t, []byte{0, 0, 0, 1}, encoded)
+
+	encoded, err = nestedEncode(reflect.ValueOf(int16(-2)))
+	require.Nil(t, err)
+	require.Equal(t, []byte{0xff, 0xfe}, encoded)
+
+	encoded, err = nestedEncode(reflect.ValueOf(false))
+	require.Nil(t, err)
+	require.Equal(t, []byte{0}, encoded)
+
+	encoded, err = nestedEncode(reflect.ValueOf("ab"))
+	require.Nil(t, err)
+	require.Equal(t, []byte{0, 0, 0, 2, 'a', 'b'}, encoded)
+
+	field := NewStorageField[*big.Int]("balance")
+	require.Equal(t, append([]byte("balance"), 0, 0, 0, 0, 0, 0, 0, 5), field.Key(uint64(5)))
+}
+
+func TestCodec_Closure(t *testing.T) {
+	t.Parallel()
+
+	closure, err := encodeClosure([]interface{}{"tag", uint64(0), big.NewInt(7)})
+	require.Nil(t, err)
+
+	values, err := decodeClosure(closure)
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{[]byte("tag"), {}, {7}}, values)
+
+	_, err = decodeClosure(closure[:len(closure)-1])
+	require.Equal(t, ErrInvalidCallbackClosure, err)
+}
@@ -0,0 +1,172 @@
+package mocksdk
+
+import (
+	"math/big"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-go/vmhost/vmhooks"
+)
+
+// executionStopped is the panic value used to leave an endpoint once the execution was stopped
+type executionStopped struct{}
+
+// Context gives the endpoints access to the call and to the host; the methods which fail the
+// execution also leave the endpoint, like a failing VM hook would stop a WASM contract.
+type Context struct {
+	host vmhost.VMHost
+}
+
+func newContext(host vmhost.VMHost) *Context {
+	return &Context{
+		host: host,
+	}
+}
+
+// run calls the given function, recovering when the execution was stopped by the context
+func (ctx *Context) run(function func()) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		if _, ok := r.(executionStopped); !ok {
+			panic(r)
+		}
+	}()
+
+	function()
+}
+
+func (ctx *Context) stop() {
+	panic(executionStopped{})
+}
+
+// stopIfFailed leaves the endpoint if a host call has set a breakpoint
+func (ctx *Context) stopIfFailed() {
+	if ctx.host.Runtime().GetRuntimeBreakpointValue() != vmhost.BreakpointNone {
+		ctx.stop()
+	}
+}
+
+// Host returns the VM host, for the operations which are not covered by the context
+func (ctx *Context) Host() vmhost.VMHost {
+	return ctx.host
+}
+
+// Input returns the input of the current call
+func (ctx *Context) Input() *vmcommon.ContractCallInput {
+	return ctx.host.Runtime().GetVMInput()
+}
+
+// Caller returns the address of the caller
+func (ctx *Context) Caller() Address {
+	return ctx.Input().CallerAddr
+}
+
+// OriginalCaller returns the address of the original caller of the transaction
+func (ctx *Context) OriginalCaller() Address {
+	return ctx.host.Runtime().GetOriginalCallerAddress()
+}
+
+// SelfAddress returns the address of the contract
+func (ctx *Context) SelfAddress() Address {
+	return ctx.host.Runtime().GetContextAddress()
+}
+
+// CallValue returns the EGLD value of the call
+func (ctx *Context) CallValue() *big.Int {
+	callValue := ctx.Input().CallValue
+	if callValue == nil {
+		return big.NewInt(0)
+	}
+	return big.NewInt(0).Set(callValue)
+}
+
+// ESDTTransfers returns the ESDT transfers of the call
+func (ctx *Context) ESDTTransfers() []*vmcommon.ESDTTransfer {
+	return ctx.Input().ESDTTransfers
+}
+
+// UseGas consumes gas, stopping the execution when there is not enough gas left
+func (ctx *Context) UseGas(gas uint64) {
+	err := ctx.host.Metering().UseGasBounded(gas)
+	if err != nil {
+		ctx.host.Runtime().SetRuntimeBreakpointValue(vmhost.BreakpointOutOfGas)
+		ctx.stop()
+	}
+}
+
+// SignalError stops the execution with a user error
+func (ctx *Context) SignalError(message string) {
+	ctx.host.Runtime().SignalUserError(message)
+	ctx.stop()
+}
+
+// Require stops the execution with a user error if the condition does not hold
+func (ctx *Context) Require(condition bool, message string) {
+	if !condition {
+		ctx.SignalError(message)
+	}
+}
+
+// Fail stops the execution with an execution failure
+func (ctx *Context) Fail(err error) {
+	ctx.host.Runtime().FailExecution(err)
+	ctx.stop()
+}
+
+// Finish encodes the values as results of the call
+func (ctx *Context) Finish(values ...interface{}) {
+	for _, value := range ctx.encode(values) {
+		ctx.host.Output().Finish(value)
+	}
+}
+
+// Transfer sends EGLD from the contract to the destination
+func (ctx *Context) Transfer(destination []byte, value *big.Int) {
+	vmhooks.TransferValueExecuteWithTypedArgs(ctx.host, destination, value, 0, nil, nil)
+	ctx.stopIfFailed()
+}
+
+// ExecuteOnDestContext calls a contract synchronously and returns its results
+func (ctx *Context) ExecuteOnDestContext(destination []byte, function string, value *big.Int, gasLimit uint64, args ...interface{}) [][]byte {
+	arguments := ctx.encode(args)
+	if value == nil {
+		value = big.NewInt(0)
+	}
+
+	output := ctx.host.Output()
+	resultsBefore := len(output.ReturnData())
+	result := vmhooks.ExecuteOnDestContextWithTypedArgs(ctx.host, int64(gasLimit), value, []byte(function), destination, arguments)
+	ctx.stopIfFailed()
+	if result != 0 {
+		return nil
+	}
+
+	returnData := output.ReturnData()
+	if len(returnData) < resultsBefore {
+		return nil
+	}
+	return returnData[resultsBefore:]
+}
+
+// AsyncCall prepares an async call to the destination, which is registered by AsyncCall.Register
+func (ctx *Context) AsyncCall(destination []byte, function string, args ...interface{}) *AsyncCall {
+	return &AsyncCall{
+		ctx:         ctx,
+		destination: destination,
+		function:    function,
+		arguments:   ctx.encode(args),
+		value:       big.NewInt(0),
+	}
+}
+
+// encode top encodes values, stopping the execution if one of them cannot be encoded
+func (ctx *Context) encode(values []interface{}) [][]byte {
+	encoded, err := encodeValues(values)
+	if err != nil {
+		ctx.Fail(err)
+	}
+	return encoded
+}
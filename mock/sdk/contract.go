@@ -0,0 +1,316 @@
+// Package mocksdk is a small framework for writing mock contracts in Go, on top of the mock
+// Wasmer instances. A contract declares typed endpoints, callbacks, storage fields and events;
+// the SDK decodes the arguments, encodes the results, builds the async calls and exports an ABI.
+//
+// The endpoints are functions receiving a *Context followed by their arguments and returning
+// their results, optionally followed by an error which is signalled as a user error:
+//
+//	adder := mocksdk.NewContract("adder")
+//	sum := mocksdk.NewStorageField[*big.Int]("sum")
+//	adder.Endpoint("add", func(ctx *mocksdk.Context, value *big.Int) {
+//		sum.Set(ctx, big.NewInt(0).Add(sum.Get(ctx), value))
+//	}, "value")
+//
+// The contracts are installed in tests with test.CreateMockContract(address).WithMethods(adder.InitMethod())
+// and in scenarios with Contract.Deploy, next to the WASM contracts.
+package mocksdk
+
+import (
+	"fmt"
+	"reflect"
+
+	mock "github.com/multiversx/mx-chain-vm-go/mock/context"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+)
+
+var (
+	contextType     = reflect.TypeOf((*Context)(nil))
+	asyncResultType = reflect.TypeOf((*AsyncResult)(nil))
+)
+
+// mutability of the endpoints, as written in the ABI
+const (
+	mutabilityMutable  = "mutable"
+	mutabilityReadonly = "readonly"
+)
+
+// Contract is a mock contract declared with typed endpoints
+type Contract struct {
+	name      string
+	endpoints []*endpoint
+	callbacks []*endpoint
+	events    []*eventDescriptor
+}
+
+type endpoint struct {
+	name       string
+	mutability string
+	handler    reflect.Value
+	isCallback bool
+	inputNames []string
+	inputs     []reflect.Type
+	outputs    []reflect.Type
+	variadic   bool
+	hasError   bool
+}
+
+// NewContract creates an empty contract
+func NewContract(name string) *Contract {
+	return &Contract{
+		name:      name,
+		endpoints: make([]*endpoint, 0),
+		callbacks: make([]*endpoint, 0),
+		events:    make([]*eventDescriptor, 0),
+	}
+}
+
+// Endpoint declares an endpoint which can change the state; the handler is a function of a *Context
+// and of the arguments, whose last parameter may be variadic, and the names are the argument names in the ABI.
+// It panics if the handler has an unsupported signature.
+func (contract *Contract) Endpoint(name string, handler interface{}, argNames ...string) *Contract {
+	contract.endpoints = append(contract.endpoints, newEndpoint(name, mutabilityMutable, handler, argNames, false))
+	return contract
+}
+
+// View declares a readonly endpoint, with the same handlers as Endpoint
+func (contract *Contract) View(name string, handler interface{}, argNames ...string) *Contract {
+	contract.endpoints = append(contract.endpoints, newEndpoint(name, mutabilityReadonly, handler, argNames, false))
+	return contract
+}
+
+// Callback declares an async call callback; the handler is a function of a *Context, of the *AsyncResult
+// and of the closure arguments given to AsyncCall.WithCallback, optionally returning an error.
+// It panics if the handler has an unsupported signature.
+func (contract *Contract) Callback(name string, handler interface{}) *Contract {
+	contract.callbacks = append(contract.callbacks, newEndpoint(name, mutabilityMutable, handler, nil, true))
+	return contract
+}
+
+func newEndpoint(name string, mutability string, handler interface{}, argNames []string, isCallback bool) *endpoint {
+	handlerValue := reflect.ValueOf(handler)
+	err := validateHandler(handlerValue, isCallback)
+	if err != nil {
+		panic(fmt.Sprintf("mocksdk: endpoint %s: %s", name, err))
+	}
+
+	handlerType := handlerValue.Type()
+	firstInput := 1
+	if isCallback {
+		firstInput = 2
+	}
+
+	ep := &endpoint{
+		name:       name,
+		mutability: mutability,
+		handler:    handlerValue,
+		isCallback: isCallback,
+		inputs:     make([]reflect.Type, 0, handlerType.NumIn()),
+		outputs:    make([]reflect.Type, 0, handlerType.NumOut()),
+		variadic:   handlerType.IsVariadic(),
+	}
+	for i := firstInput; i < handlerType.NumIn(); i++ {
+		ep.inputs = append(ep.inputs, handlerType.In(i))
+	}
+	for i := 0; i < handlerType.NumOut(); i++ {
+		if handlerType.Out(i) == errorType {
+			ep.hasError = true
+			continue
+		}
+		ep.outputs = append(ep.outputs, handlerType.Out(i))
+	}
+
+	ep.inputNames = make([]string, len(ep.inputs))
+	for i := range ep.inputNames {
+		if i < len(argNames) {
+			ep.inputNames[i] = argNames[i]
+		} else {
+			ep.inputNames[i] = fmt.Sprintf("arg%d", i)
+		}
+	}
+
+	return ep
+}
+
+func validateHandler(handler reflect.Value, isCallback bool) error {
+	if handler.Kind() != reflect.Func {
+		return fmt.Errorf("the handler is not a function")
+	}
+
+	handlerType := handler.Type()
+	if handlerType.NumIn() == 0 || handlerType.In(0) != contextType {
+		return fmt.Errorf("the first parameter of the handler is not a *Context")
+	}
+
+	firstInput := 1
+	if isCallback {
+		if handlerType.NumIn() < 2 || handlerType.In(1) != asyncResultType {
+			return fmt.Errorf("the second parameter of the callback is not an *AsyncResult")
+		}
+		if handlerType.IsVariadic() {
+			return fmt.Errorf("the callback cannot be variadic")
+		}
+		firstInput = 2
+	}
+
+	for i := firstInput; i < handlerType.NumIn(); i++ {
+		input := handlerType.In(i)
+		if handlerType.IsVariadic() && i == handlerType.NumIn()-1 {
+			input = input.Elem()
+		}
+		if !isSupportedType(input) {
+			return fmt.Errorf("%w: parameter %d has type %s", ErrUnsupportedType, i, input)
+		}
+	}
+
+	for i := 0; i < handlerType.NumOut(); i++ {
+		output := handlerType.Out(i)
+		if output == errorType {
+			if i != handlerType.NumOut()-1 {
+				return fmt.Errorf("the error is not the last result of the handler")
+			}
+			continue
+		}
+		if isCallback {
+			return fmt.Errorf("the callback can only return an error")
+		}
+		if !isSupportedType(output) && !isMultiValueType(output) {
+			return fmt.Errorf("%w: result %d has type %s", ErrUnsupportedType, i, output)
+		}
+	}
+
+	return nil
+}
+
+// Install adds the endpoints and the callbacks of the contract as methods of the mock instance
+func (contract *Contract) Install(instanceMock *mock.InstanceMock) {
+	for _, ep := range contract.endpoints {
+		instanceMock.AddMockMethod(ep.name, contract.mockMethod(instanceMock, ep))
+	}
+	for _, ep := range contract.callbacks {
+		instanceMock.AddMockMethod(ep.name, contract.mockMethod(instanceMock, ep))
+	}
+}
+
+// InitMethod returns the contract as an init method for test.CreateMockContract(address).WithMethods
+func (contract *Contract) InitMethod() func(*mock.InstanceMock, interface{}) {
+	return func(instanceMock *mock.InstanceMock, _ interface{}) {
+		contract.Install(instanceMock)
+	}
+}
+
+// Deploy stores the contract in the executor mock under the given code, so that every account
+// having this code runs the contract. It is how mock contracts are used in scenarios, where the
+// accounts are created by the scenario steps and the other contracts are real WASM contracts.
+func (contract *Contract) Deploy(executorMock *mock.ExecutorMock, host vmhost.VMHost, code []byte) *mock.InstanceMock {
+	instanceMock := executorMock.CreateAndStoreInstanceMock(nil, host, code, nil, nil, nil, 0, 0, false)
+	contract.Install(instanceMock)
+	return instanceMock
+}
+
+func (contract *Contract) mockMethod(instanceMock *mock.InstanceMock, ep *endpoint) func() *mock.InstanceMock {
+	return func() *mock.InstanceMock {
+		host := instanceMock.Host
+		instance := mock.GetMockInstance(host)
+
+		ctx := newContext(host)
+		ctx.run(func() {
+			ep.call(ctx)
+		})
+
+		return instance
+	}
+}
+
+func (ep *endpoint) call(ctx *Context) {
+	arguments := ctx.host.Runtime().Arguments()
+	values := make([]reflect.Value, 0, len(arguments)+2)
+	values = append(values, reflect.ValueOf(ctx))
+
+	if ep.isCallback {
+		if len(arguments) == 0 {
+			ctx.SignalError(ErrWrongNumberOfArguments.Error())
+		}
+		values = append(values, reflect.ValueOf(newAsyncResult(arguments)))
+		arguments = ep.closureArguments(ctx)
+	}
+
+	values = append(values, ep.decodeArguments(ctx, arguments)...)
+	var results []reflect.Value
+	if ep.variadic {
+		results = ep.handler.CallSlice(values)
+	} else {
+		results = ep.handler.Call(values)
+	}
+
+	ep.finish(ctx, results)
+}
+
+func (ep *endpoint) closureArguments(ctx *Context) [][]byte {
+	if len(ep.inputs) == 0 {
+		return nil
+	}
+
+	closure, err := ctx.host.Async().GetCallbackClosure()
+	if err != nil {
+		ctx.Fail(err)
+	}
+	arguments, err := decodeClosure(closure)
+	if err != nil {
+		ctx.Fail(err)
+	}
+	return arguments
+}
+
+func (ep *endpoint) decodeArguments(ctx *Context, arguments [][]byte) []reflect.Value {
+	fixedInputs := len(ep.inputs)
+	if ep.variadic {
+		fixedInputs--
+	}
+	if len(arguments) < fixedInputs || (!ep.variadic && len(arguments) > fixedInputs) {
+		ctx.SignalError(ErrWrongNumberOfArguments.Error())
+	}
+
+	values := make([]reflect.Value, 0, len(ep.inputs))
+	for i := 0; i < fixedInputs; i++ {
+		values = append(values, ep.decodeArgument(ctx, arguments[i], ep.inputs[i], ep.inputNames[i]))
+	}
+	if !ep.variadic {
+		return values
+	}
+
+	variadicType := ep.inputs[fixedInputs]
+	variadicValues := reflect.MakeSlice(variadicType, 0, len(arguments)-fixedInputs)
+	for _, argument := range arguments[fixedInputs:] {
+		value := ep.decodeArgument(ctx, argument, variadicType.Elem(), ep.inputNames[fixedInputs])
+		variadicValues = reflect.Append(variadicValues, value)
+	}
+	return append(values, variadicValues)
+}
+
+func (ep *endpoint) decodeArgument(ctx *Context, argument []byte, argumentType reflect.Type, name string) reflect.Value {
+	value, err := topDecode(argument, argumentType)
+	if err != nil {
+		ctx.SignalError(fmt.Sprintf("argument decode error (%s): %s", name, err))
+	}
+	return value
+}
+
+func (ep *endpoint) finish(ctx *Context, results []reflect.Value) {
+	if ep.hasError {
+		errValue := results[len(results)-1]
+		if !errValue.IsNil() {
+			ctx.SignalError(errValue.Interface().(error).Error())
+		}
+		results = results[:len(results)-1]
+	}
+
+	for _, result := range results {
+		if !isMultiValueType(result.Type()) {
+			ctx.Finish(result.Interface())
+			continue
+		}
+		for i := 0; i < result.Len(); i++ {
+			ctx.Finish(result.Index(i).Interface())
+		}
+	}
+}
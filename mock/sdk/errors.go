@@ -0,0 +1,24 @@
+package mocksdk
+
+import "errors"
+
+// ErrWrongNumberOfArguments signals that an endpoint was called with a wrong number of arguments
+var ErrWrongNumberOfArguments = errors.New("wrong number of arguments")
+
+// ErrInputTooLong signals that an encoded value is longer than its type allows
+var ErrInputTooLong = errors.New("input too long")
+
+// ErrInvalidValue signals that an encoded value is not valid for its type
+var ErrInvalidValue = errors.New("invalid value")
+
+// ErrInvalidAddressLength signals that an address does not have the expected length
+var ErrInvalidAddressLength = errors.New("invalid address length")
+
+// ErrNegativeBigUint signals that a negative big integer was encoded as BigUint
+var ErrNegativeBigUint = errors.New("negative BigUint")
+
+// ErrUnsupportedType signals that a Go type cannot be encoded or decoded by the SDK
+var ErrUnsupportedType = errors.New("unsupported type")
+
+// ErrInvalidCallbackClosure signals that the callback closure cannot be split into arguments
+var ErrInvalidCallbackClosure = errors.New("invalid callback closure")
@@ -0,0 +1,93 @@
+package mocksdk
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// eventTag is the struct tag of the event fields: `event:"name"`, `event:"name,indexed"` or `event:",indexed"`
+const eventTag = "event"
+
+// Event is a contract event whose fields are the fields of the struct T: the indexed fields
+// are written as log topics and the other fields as log data
+type Event[T any] struct {
+	descriptor *eventDescriptor
+}
+
+type eventDescriptor struct {
+	identifier string
+	fields     []*eventField
+}
+
+type eventField struct {
+	name      string
+	index     int
+	indexed   bool
+	fieldType reflect.Type
+}
+
+// NewEvent declares an event of the contract, panicking if T is not a struct of supported types
+func NewEvent[T any](contract *Contract, identifier string) *Event[T] {
+	structType := reflect.TypeOf((*T)(nil)).Elem()
+	if structType.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("mocksdk: event %s: %s is not a struct", identifier, structType))
+	}
+
+	descriptor := &eventDescriptor{
+		identifier: identifier,
+		fields:     make([]*eventField, 0, structType.NumField()),
+	}
+	for i := 0; i < structType.NumField(); i++ {
+		structField := structType.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+		if !isSupportedType(structField.Type) {
+			panic(fmt.Sprintf("mocksdk: event %s: field %s: %s", identifier, structField.Name, fmt.Errorf("%w: %s", ErrUnsupportedType, structField.Type)))
+		}
+
+		name, options, _ := strings.Cut(structField.Tag.Get(eventTag), ",")
+		if len(name) == 0 {
+			name = lowerFirst(structField.Name)
+		}
+		descriptor.fields = append(descriptor.fields, &eventField{
+			name:      name,
+			index:     i,
+			indexed:   options == "indexed",
+			fieldType: structField.Type,
+		})
+	}
+
+	contract.events = append(contract.events, descriptor)
+	return &Event[T]{
+		descriptor: descriptor,
+	}
+}
+
+// Emit writes the event in the logs of the contract
+func (event *Event[T]) Emit(ctx *Context, value T) {
+	structValue := reflect.ValueOf(value)
+	topics := make([][]byte, 0, len(event.descriptor.fields))
+	data := make([][]byte, 0, len(event.descriptor.fields))
+	for _, field := range event.descriptor.fields {
+		encoded, err := topEncode(structValue.Field(field.index))
+		if err != nil {
+			ctx.Fail(err)
+		}
+		if field.indexed {
+			topics = append(topics, encoded)
+		} else {
+			data = append(data, encoded)
+		}
+	}
+
+	ctx.host.Output().WriteLogWithIdentifier(ctx.SelfAddress(), topics, data, []byte(event.descriptor.identifier))
+}
+
+func lowerFirst(name string) string {
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
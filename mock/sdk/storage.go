@@ -0,0 +1,104 @@
+package mocksdk
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// StorageField is a typed storage entry of a contract; its key is the field name followed by
+// the nested encoding of the key arguments, like the storage mappers of the Rust framework
+type StorageField[T any] struct {
+	name      []byte
+	valueType reflect.Type
+}
+
+// NewStorageField creates a storage field, panicking if the value type is not supported
+func NewStorageField[T any](name string) *StorageField[T] {
+	valueType := reflect.TypeOf((*T)(nil)).Elem()
+	if !isSupportedType(valueType) {
+		panic(fmt.Sprintf("mocksdk: storage field %s: %s", name, fmt.Errorf("%w: %s", ErrUnsupportedType, valueType)))
+	}
+
+	return &StorageField[T]{
+		name:      []byte(name),
+		valueType: valueType,
+	}
+}
+
+// Key returns the storage key for the given key arguments, panicking if they cannot be encoded
+func (field *StorageField[T]) Key(keyArgs ...interface{}) []byte {
+	key, err := field.storageKey(keyArgs)
+	if err != nil {
+		panic(fmt.Sprintf("mocksdk: storage field %s: %s", field.name, err))
+	}
+	return key
+}
+
+func (field *StorageField[T]) storageKey(keyArgs []interface{}) ([]byte, error) {
+	key := append([]byte{}, field.name...)
+	for i, keyArg := range keyArgs {
+		if keyArg == nil {
+			return nil, fmt.Errorf("%w: nil key argument at position %d", ErrUnsupportedType, i)
+		}
+		encoded, err := nestedEncode(reflect.ValueOf(keyArg))
+		if err != nil {
+			return nil, err
+		}
+		key = append(key, encoded...)
+	}
+	return key, nil
+}
+
+func (field *StorageField[T]) load(ctx *Context, keyArgs []interface{}) []byte {
+	key, err := field.storageKey(keyArgs)
+	if err != nil {
+		ctx.Fail(err)
+	}
+
+	value, _, _, err := ctx.host.Storage().GetStorage(key)
+	if err != nil {
+		ctx.Fail(err)
+	}
+	return value
+}
+
+func (field *StorageField[T]) store(ctx *Context, value []byte, keyArgs []interface{}) {
+	key, err := field.storageKey(keyArgs)
+	if err != nil {
+		ctx.Fail(err)
+	}
+
+	_, err = ctx.host.Storage().SetStorage(key, value)
+	if err != nil {
+		ctx.Fail(err)
+	}
+	ctx.stopIfFailed()
+}
+
+// Get loads and decodes the value of the field
+func (field *StorageField[T]) Get(ctx *Context, keyArgs ...interface{}) T {
+	decoded, err := topDecode(field.load(ctx, keyArgs), field.valueType)
+	if err != nil {
+		ctx.SignalError(fmt.Sprintf("storage decode error (%s): %s", field.name, err))
+	}
+	return decoded.Interface().(T)
+}
+
+// IsEmpty returns true if nothing is stored in the field
+func (field *StorageField[T]) IsEmpty(ctx *Context, keyArgs ...interface{}) bool {
+	return len(field.load(ctx, keyArgs)) == 0
+}
+
+// Set encodes and stores the value of the field
+func (field *StorageField[T]) Set(ctx *Context, value T, keyArgs ...interface{}) {
+	encoded, err := topEncode(reflect.ValueOf(&value).Elem())
+	if err != nil {
+		ctx.Fail(err)
+	}
+	field.store(ctx, encoded, keyArgs)
+}
+
+// Clear removes the value of the field
+func (field *StorageField[T]) Clear(ctx *Context, keyArgs ...interface{}) {
+	field.store(ctx, nil, keyArgs)
+}
@@ -0,0 +1,168 @@
+package worldmock
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// BlockProducerAddress is the reserved address used by scenarios to configure the block production.
+// Its storage is interpreted as settings instead of being saved as an account.
+// It is the value of the scenario expression "address:block-producer".
+var BlockProducerAddress = []byte("block-producer__________________")
+
+// the default block production settings, as on mainnet
+const (
+	DefaultTxsPerBlock    = 1
+	DefaultRoundsPerBlock = 1
+	DefaultRoundDuration  = 6
+)
+
+// maxKeptBlockhashes is the number of block hashes kept by the block production, the newest first
+const maxKeptBlockhashes = 256
+
+// ErrInvalidBlockProduction signals that the block production settings cannot produce blocks
+var ErrInvalidBlockProduction = errors.New("invalid block production settings")
+
+// BlockProductionConfig holds the settings of the automatic block production.
+type BlockProductionConfig struct {
+	// TxsPerBlock is the number of transactions after which a new block is produced;
+	// 0 means that the blocks are only produced when the tests ask for them
+	TxsPerBlock uint64
+
+	// RoundsPerBlock is the number of rounds between two blocks; more than 1 simulates missed rounds
+	RoundsPerBlock uint64
+
+	// RoundDuration is the duration of a round, in seconds
+	RoundDuration uint64
+
+	// RoundsPerEpoch is the number of rounds in an epoch; 0 keeps the epoch unchanged
+	RoundsPerEpoch uint64
+}
+
+// DefaultBlockProductionConfig produces a block after each transaction, one round apart
+func DefaultBlockProductionConfig() BlockProductionConfig {
+	return BlockProductionConfig{
+		TxsPerBlock:    DefaultTxsPerBlock,
+		RoundsPerBlock: DefaultRoundsPerBlock,
+		RoundDuration:  DefaultRoundDuration,
+		RoundsPerEpoch: 0,
+	}
+}
+
+// BlockProducer advances the block info of the MockWorld, as if the transactions were included in a chain of blocks.
+type BlockProducer struct {
+	Config     BlockProductionConfig
+	txsInBlock uint64
+}
+
+// EnableBlockProduction turns on the automatic block production of the world
+func (b *MockWorld) EnableBlockProduction(config BlockProductionConfig) error {
+	if config.RoundsPerBlock == 0 || config.RoundDuration == 0 {
+		return ErrInvalidBlockProduction
+	}
+
+	b.BlockProduction = &BlockProducer{Config: config}
+	return nil
+}
+
+// DisableBlockProduction turns off the automatic block production; the block info is left as it is
+func (b *MockWorld) DisableBlockProduction() {
+	b.BlockProduction = nil
+}
+
+// TransactionExecuted counts a transaction in the current block, producing a new block
+// once the block holds TxsPerBlock transactions. It does nothing when the block production is off.
+func (b *MockWorld) TransactionExecuted() {
+	if b.BlockProduction == nil || b.BlockProduction.Config.TxsPerBlock == 0 {
+		return
+	}
+
+	b.BlockProduction.txsInBlock++
+	if b.BlockProduction.txsInBlock >= b.BlockProduction.Config.TxsPerBlock {
+		b.ProduceBlocks(1)
+	}
+}
+
+// ProduceBlocks adds blocks after the current one. The settings of the block production are used when it is on,
+// and the default ones otherwise.
+func (b *MockWorld) ProduceBlocks(numBlocks uint64) {
+	config := b.blockProductionConfig()
+	for i := uint64(0); i < numBlocks; i++ {
+		b.produceBlock(config.RoundsPerBlock, config)
+	}
+}
+
+// ProduceBlockAtTimestamp adds a single block, skipping as many rounds as needed for its timestamp
+// to reach the target. It does nothing if the current block is not older than the target.
+func (b *MockWorld) ProduceBlockAtTimestamp(timestamp uint64) {
+	currentTimestamp := b.CurrentTimeStamp()
+	if timestamp <= currentTimestamp {
+		return
+	}
+
+	config := b.blockProductionConfig()
+	numRounds := (timestamp - currentTimestamp + config.RoundDuration - 1) / config.RoundDuration
+	b.produceBlock(numRounds, config)
+}
+
+func (b *MockWorld) blockProductionConfig() BlockProductionConfig {
+	if b.BlockProduction == nil {
+		return DefaultBlockProductionConfig()
+	}
+
+	return b.BlockProduction.Config
+}
+
+// produceBlock rolls the current block into the previous one and creates the next block, numRounds later.
+// The random seed and the hash of the new block are derived from the previous block, so the chain is deterministic.
+func (b *MockWorld) produceBlock(numRounds uint64, config BlockProductionConfig) {
+	previous := b.CurrentBlockInfo
+	if previous == nil {
+		previous = &BlockInfo{}
+	}
+
+	next := &BlockInfo{
+		BlockTimestamp: previous.BlockTimestamp + numRounds*config.RoundDuration,
+		BlockNonce:     previous.BlockNonce + 1,
+		BlockRound:     previous.BlockRound + numRounds,
+		BlockEpoch:     previous.BlockEpoch,
+		RandomSeed:     nextRandomSeed(previous),
+	}
+	if config.RoundsPerEpoch > 0 {
+		next.BlockEpoch += uint32(next.BlockRound/config.RoundsPerEpoch - previous.BlockRound/config.RoundsPerEpoch)
+	}
+
+	b.PreviousBlockInfo = previous
+	b.CurrentBlockInfo = next
+	b.Blockhashes = append([][]byte{b.computeBlockHash(next)}, b.Blockhashes...)
+	if len(b.Blockhashes) > maxKeptBlockhashes {
+		b.Blockhashes = b.Blockhashes[:maxKeptBlockhashes]
+	}
+
+	if b.BlockProduction != nil {
+		b.BlockProduction.txsInBlock = 0
+	}
+}
+
+func (b *MockWorld) computeBlockHash(block *BlockInfo) []byte {
+	var previousHash []byte
+	if len(b.Blockhashes) > 0 {
+		previousHash = b.Blockhashes[0]
+	}
+
+	data := make([]byte, 0, len(previousHash)+8+len(block.RandomSeed))
+	data = append(data, previousHash...)
+	data = binary.BigEndian.AppendUint64(data, block.BlockNonce)
+	data = append(data, block.GetRandomSeedSlice()...)
+	return DefaultHasher.Compute(string(data))
+}
+
+func nextRandomSeed(previous *BlockInfo) *[48]byte {
+	data := binary.BigEndian.AppendUint64(previous.GetRandomSeedSlice(), previous.BlockNonce)
+	firstHash := DefaultHasher.Compute(string(data))
+	secondHash := DefaultHasher.Compute(string(firstHash))
+
+	seed := &[48]byte{}
+	copy(seed[:], append(firstHash, secondHash...))
+	return seed
+}
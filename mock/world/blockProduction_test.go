@@ -0,0 +1,69 @@
+package worldmock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockWorld_BlockProduction(t *testing.T) {
+	t.Parallel()
+
+	require.Len(t, BlockProducerAddress, 32)
+
+	world := NewMockWorld()
+	err := world.EnableBlockProduction(BlockProductionConfig{
+		TxsPerBlock:    2,
+		RoundsPerBlock: 1,
+		RoundDuration:  6,
+		RoundsPerEpoch: 3,
+	})
+	require.Nil(t, err)
+
+	world.TransactionExecuted()
+	require.Equal(t, uint64(0), world.CurrentNonce())
+	world.TransactionExecuted()
+	require.Equal(t, uint64(1), world.CurrentNonce())
+	require.Equal(t, uint64(1), world.CurrentRound())
+	require.Equal(t, uint64(6), world.CurrentTimeStamp())
+	require.Equal(t, uint64(0), world.LastNonce())
+	require.Len(t, world.CurrentRandomSeed(), 48)
+	require.NotEqual(t, make([]byte, 48), world.CurrentRandomSeed())
+
+	previousSeed := world.CurrentRandomSeed()
+	world.ProduceBlocks(2)
+	require.Equal(t, uint64(3), world.CurrentNonce())
+	require.Equal(t, uint32(1), world.CurrentEpoch())
+	require.Equal(t, uint64(2), world.LastNonce())
+	require.NotEqual(t, previousSeed, world.CurrentRandomSeed())
+
+	currentHash, err := world.GetBlockhash(3)
+	require.Nil(t, err)
+	firstHash, err := world.GetBlockhash(1)
+	require.Nil(t, err)
+	require.NotEqual(t, currentHash, firstHash)
+
+	world.ProduceBlockAtTimestamp(100)
+	require.Equal(t, uint64(4), world.CurrentNonce())
+	require.Equal(t, uint64(102), world.CurrentTimeStamp())
+	require.Equal(t, uint64(17), world.CurrentRound())
+	require.Equal(t, uint32(5), world.CurrentEpoch())
+
+	world.ProduceBlockAtTimestamp(50)
+	require.Equal(t, uint64(4), world.CurrentNonce())
+}
+
+func TestMockWorld_BlockProductionIsDeterministic(t *testing.T) {
+	t.Parallel()
+
+	first := NewMockWorld()
+	first.ProduceBlocks(5)
+	second := NewMockWorld()
+	second.ProduceBlocks(5)
+
+	require.Equal(t, first.CurrentBlockInfo, second.CurrentBlockInfo)
+	require.Equal(t, first.Blockhashes, second.Blockhashes)
+
+	err := first.EnableBlockProduction(BlockProductionConfig{RoundsPerBlock: 0, RoundDuration: 6})
+	require.Equal(t, ErrInvalidBlockProduction, err)
+}
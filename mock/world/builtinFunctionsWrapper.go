@@ -21,6 +21,13 @@ type BuiltinFunctionsWrapper struct {
 	MapDNSAddresses map[string]struct{}
 	World           *MockWorld
 	Marshalizer     vmcommon.Marshalizer
+
+	gasScheduleSubscriber gasScheduleSubscriber
+}
+
+// gasScheduleSubscriber is the part of the builtin functions factory which updates the gas costs of the functions
+type gasScheduleSubscriber interface {
+	GasScheduleChange(gasSchedule map[string]map[string]uint64)
 }
 
 // NewBuiltinFunctionsWrapper creates a new BuiltinFunctionsWrapper with
@@ -59,10 +66,17 @@ func NewBuiltinFunctionsWrapper(
 		return nil, err
 	}
 
+	err = addNFTMetaDataFunctions(builtinFuncFactory.BuiltInFunctionContainer(), world, gasMap)
+	if err != nil {
+		return nil, err
+	}
+
 	builtinFuncsWrapper := &BuiltinFunctionsWrapper{
 		Container:       builtinFuncFactory.BuiltInFunctionContainer(),
 		MapDNSAddresses: argsBuiltIn.MapDNSAddresses,
 		World:           world,
+
+		gasScheduleSubscriber: builtinFuncFactory,
 	}
 
 	return builtinFuncsWrapper, nil
@@ -71,6 +85,11 @@ func NewBuiltinFunctionsWrapper(
 // ProcessBuiltInFunction delegates the execution of a real builtin function to
 // the inner BuiltInFunctionContainer.
 func (bf *BuiltinFunctionsWrapper) ProcessBuiltInFunction(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	faultOutput, err := bf.World.Faults.CheckOutput(FaultProcessBuiltInFunction, input.CallerAddr, nil, input.Function)
+	if err != nil || faultOutput != nil {
+		return faultOutput, err
+	}
+
 	caller := bf.getAccountSharded(input.CallerAddr)
 	recipient := bf.getAccountSharded(input.RecipientAddr)
 
@@ -84,6 +103,13 @@ func (bf *BuiltinFunctionsWrapper) ProcessBuiltInFunction(input *vmcommon.Contra
 		return nil, err
 	}
 
+	if vmOutput.ReturnCode == vmcommon.Ok {
+		err = bf.World.updateMetaDataVersionAfterBuiltin(input)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if !check.IfNil(caller) {
 		err = bf.World.AccountsAdapter.SaveAccount(caller)
 		if err != nil {
@@ -101,6 +127,11 @@ func (bf *BuiltinFunctionsWrapper) ProcessBuiltInFunction(input *vmcommon.Contra
 	return vmOutput, nil
 }
 
+// GasScheduleChange applies a new gas schedule to the builtin functions.
+func (bf *BuiltinFunctionsWrapper) GasScheduleChange(gasMap config.GasScheduleMap) {
+	bf.gasScheduleSubscriber.GasScheduleChange(gasMap)
+}
+
 // GetBuiltinFunctionNames returns the list of defined builtin-in functions.
 func (bf *BuiltinFunctionsWrapper) GetBuiltinFunctionNames() vmcommon.FunctionNames {
 	return bf.Container.Keys()
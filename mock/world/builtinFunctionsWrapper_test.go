@@ -0,0 +1,39 @@
+package worldmock
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-go/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltinFunctionsWrapper_GasScheduleChange(t *testing.T) {
+	t.Parallel()
+
+	world := NewMockWorld()
+	err := world.InitBuiltinFunctions(config.MakeGasMap(1, 1))
+	require.Nil(t, err)
+	world.AcctMap.CreateAccount(guardedTestOwner, world)
+
+	setGuardian := func() uint64 {
+		vmOutput, errCall := world.BuiltinFuncs.ProcessBuiltInFunction(&vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr:  guardedTestOwner,
+				CallValue:   big.NewInt(0),
+				GasProvided: guardianTestGasLimit,
+				Arguments:   [][]byte{guardianTestFirst, guardianTestService},
+			},
+			RecipientAddr: guardedTestOwner,
+			Function:      core.BuiltInFunctionSetGuardian,
+		})
+		require.Nil(t, errCall)
+		return guardianTestGasLimit - vmOutput.GasRemaining
+	}
+
+	require.Equal(t, uint64(1), setGuardian())
+	world.BuiltinFuncs.GasScheduleChange(config.MakeGasMap(1000, 1))
+	require.Equal(t, uint64(1000), setGuardian())
+}
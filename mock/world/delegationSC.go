@@ -0,0 +1,483 @@
+package worldmock
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// DelegationManagerSCAddress is the address of the delegation manager system SC, which creates the delegation contracts
+var DelegationManagerSCAddress = []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 4, 255, 255}
+
+// firstDelegationSCIndex is the index encoded in the address of the first delegation contract,
+// chosen so that the delegation contracts never collide with the other system SCs
+const firstDelegationSCIndex = 1 << 16
+
+// DefaultMinCreationDeposit is the value required for creating a delegation contract, 1250 EGLD
+var DefaultMinCreationDeposit = big.NewInt(0).Mul(big.NewInt(1250), big.NewInt(1e18))
+
+// DefaultMinDelegation is the smallest value which can be delegated, 1 EGLD
+var DefaultMinDelegation = big.NewInt(1e18)
+
+// maxServiceFee is the service fee of 100%, the fees being expressed in hundredths of a percent
+const maxServiceFee = 10000
+
+const (
+	delegationManagerStateKey  = "delegationManager"
+	delegationContractStateKey = "delegationContract"
+)
+
+// DelegationManagerState holds the delegation contracts created by the delegation manager
+type DelegationManagerState struct {
+	LastIndex         uint64
+	ContractAddresses [][]byte
+}
+
+// UnStakedFunds is a value undelegated in the given epoch
+type UnStakedFunds struct {
+	Value *big.Int
+	Epoch uint32
+}
+
+// DelegatorInfo is what a delegation contract knows about one of its delegators
+type DelegatorInfo struct {
+	ActiveStake *big.Int
+	UnStaked    []*UnStakedFunds
+	Rewards     *big.Int
+}
+
+// DelegationContractInfo is the state of a delegation contract; the delegators are indexed by their hex address
+type DelegationContractInfo struct {
+	Owner            []byte
+	ServiceFee       uint64
+	MaxDelegationCap *big.Int
+	TotalActiveStake *big.Int
+	Delegators       map[string]*DelegatorInfo
+}
+
+// DelegationManagerSCMock is a Go stand-in for the delegation manager system SC of the metachain.
+// The delegation contracts it creates are served by its Delegation stand-in.
+type DelegationManagerSCMock struct {
+	World      *MockWorld
+	Delegation *DelegationSCMock
+
+	MinCreationDeposit *big.Int
+}
+
+// DelegationSCMock is a Go stand-in for the delegation system SCs of the metachain. A single instance
+// serves all the delegation contracts, each of them keeping its state in its own storage.
+//
+// The protocol rewards are not computed; the tests hand them out with DistributeRewards.
+type DelegationSCMock struct {
+	World *MockWorld
+
+	MinDelegation      *big.Int
+	UnBondPeriodEpochs uint32
+}
+
+// RegisterDelegationManagerSC installs a DelegationManagerSCMock in the system VM, at the address of the delegation manager
+func (b *MockWorld) RegisterDelegationManagerSC() *DelegationManagerSCMock {
+	b.DelegationManagerSC = &DelegationManagerSCMock{
+		World: b,
+		Delegation: &DelegationSCMock{
+			World:              b,
+			MinDelegation:      big.NewInt(0).Set(DefaultMinDelegation),
+			UnBondPeriodEpochs: DefaultUnBondPeriodEpochs,
+		},
+		MinCreationDeposit: big.NewInt(0).Set(DefaultMinCreationDeposit),
+	}
+	b.SystemVM().RegisterContract(DelegationManagerSCAddress, b.DelegationManagerSC)
+	return b.DelegationManagerSC
+}
+
+// GetContractAddresses returns the addresses of the delegation contracts, in the order they were created
+func (sc *DelegationManagerSCMock) GetContractAddresses() [][]byte {
+	return sc.getState().ContractAddresses
+}
+
+// ExecuteCall runs a call on the delegation manager; the errors of the call are returned as user errors in the VMOutput
+func (sc *DelegationManagerSCMock) ExecuteCall(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	return sc.World.executeSystemSCFunction(input, map[string]systemSCFunction{
+		"createNewDelegationContract": sc.createNewDelegationContract,
+		"getAllContractAddresses":     sc.getAllContractAddresses,
+	}), nil
+}
+
+// createNewDelegationContract@maxDelegationCap@serviceFee; the deposit is delegated by the owner of the new contract
+func (sc *DelegationManagerSCMock) createNewDelegationContract(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+	if len(input.Arguments) != 2 {
+		return fmt.Errorf("wrong number of arguments")
+	}
+	if input.CallValue.Cmp(sc.MinCreationDeposit) < 0 {
+		return fmt.Errorf("not enough call value")
+	}
+
+	serviceFee := big.NewInt(0).SetBytes(input.Arguments[1]).Uint64()
+	if serviceFee > maxServiceFee {
+		return fmt.Errorf("service fee too high")
+	}
+
+	state := sc.getState()
+	state.LastIndex++
+	contractAddress := makeDelegationSCAddress(firstDelegationSCIndex + state.LastIndex - 1)
+	state.ContractAddresses = append(state.ContractAddresses, contractAddress)
+	err := saveSystemSCState(output, DelegationManagerSCAddress, delegationManagerStateKey, state)
+	if err != nil {
+		return err
+	}
+
+	contract := &DelegationContractInfo{
+		Owner:            input.CallerAddr,
+		ServiceFee:       serviceFee,
+		MaxDelegationCap: big.NewInt(0).SetBytes(input.Arguments[0]),
+		TotalActiveStake: big.NewInt(0).Set(input.CallValue),
+		Delegators: map[string]*DelegatorInfo{
+			hex.EncodeToString(input.CallerAddr): newDelegatorInfo(input.CallValue),
+		},
+	}
+	err = saveDelegationContract(output, contractAddress, contract)
+	if err != nil {
+		return err
+	}
+	sc.World.SystemVM().RegisterContract(contractAddress, sc.Delegation)
+
+	// the deposit is paid to the delegation manager with the call, which moves it on to the new contract
+	moveBalance(output, DelegationManagerSCAddress, contractAddress, input.CallValue)
+
+	output.ReturnData = [][]byte{contractAddress}
+	return nil
+}
+
+// getAllContractAddresses returns the addresses of all the delegation contracts
+func (sc *DelegationManagerSCMock) getAllContractAddresses(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+	err := checkNotPayable(input)
+	if err != nil {
+		return err
+	}
+
+	output.ReturnData = append(output.ReturnData, sc.GetContractAddresses()...)
+	return nil
+}
+
+func (sc *DelegationManagerSCMock) getState() *DelegationManagerState {
+	state := &DelegationManagerState{}
+	_, _ = sc.World.loadSystemSCState(DelegationManagerSCAddress, delegationManagerStateKey, state)
+	return state
+}
+
+// GetContractInfo returns the state of a delegation contract, or nil if there is no delegation contract at the address
+func (sc *DelegationSCMock) GetContractInfo(contractAddress []byte) *DelegationContractInfo {
+	contract := &DelegationContractInfo{}
+	found, err := sc.World.loadSystemSCState(contractAddress, delegationContractStateKey, contract)
+	if !found || err != nil {
+		return nil
+	}
+
+	return contract
+}
+
+// GetUserActiveStake returns the value actively delegated by an address to a delegation contract
+func (sc *DelegationSCMock) GetUserActiveStake(contractAddress []byte, delegator []byte) *big.Int {
+	contract := sc.GetContractInfo(contractAddress)
+	if contract == nil {
+		return big.NewInt(0)
+	}
+
+	return contract.getDelegator(delegator).ActiveStake
+}
+
+// DistributeRewards hands out rewards to a delegation contract, as the protocol does at the end of an epoch.
+// The owner gets the service fee, and the rest is split among the delegators by their active stake.
+func (sc *DelegationSCMock) DistributeRewards(contractAddress []byte, rewards *big.Int) error {
+	contract := sc.GetContractInfo(contractAddress)
+	if contract == nil {
+		return fmt.Errorf("no delegation contract at address %x", contractAddress)
+	}
+
+	ownerRewards := big.NewInt(0).Mul(rewards, big.NewInt(int64(contract.ServiceFee)))
+	ownerRewards.Div(ownerRewards, big.NewInt(maxServiceFee))
+	delegatorsRewards := big.NewInt(0).Sub(rewards, ownerRewards)
+
+	distributed := big.NewInt(0)
+	if contract.TotalActiveStake.Sign() > 0 {
+		for _, delegator := range contract.Delegators {
+			delegatorRewards := big.NewInt(0).Mul(delegatorsRewards, delegator.ActiveStake)
+			delegatorRewards.Div(delegatorRewards, contract.TotalActiveStake)
+			delegator.Rewards.Add(delegator.Rewards, delegatorRewards)
+			distributed.Add(distributed, delegatorRewards)
+		}
+	}
+
+	// the rounding leftovers go to the owner
+	ownerRewards.Add(ownerRewards, delegatorsRewards.Sub(delegatorsRewards, distributed))
+	owner := contract.getDelegator(contract.Owner)
+	owner.Rewards.Add(owner.Rewards, ownerRewards)
+
+	return sc.World.applySystemSCChanges(func(output *vmcommon.VMOutput) error {
+		outputAccount := getOrCreateOutputAccount(output, contractAddress)
+		outputAccount.BalanceDelta.Add(outputAccount.BalanceDelta, rewards)
+		return saveDelegationContract(output, contractAddress, contract)
+	})
+}
+
+// ExecuteCall runs a call on one of the delegation contracts; the errors of the call are returned as user errors in the VMOutput
+func (sc *DelegationSCMock) ExecuteCall(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	return sc.World.executeSystemSCFunction(input, sc.functions()), nil
+}
+
+func (sc *DelegationSCMock) functions() map[string]systemSCFunction {
+	return map[string]systemSCFunction{
+		"delegate":             sc.delegate,
+		"unDelegate":           sc.unDelegate,
+		"withdraw":             sc.withdraw,
+		"claimRewards":         sc.claimRewards,
+		"reDelegateRewards":    sc.reDelegateRewards,
+		"getUserActiveStake":   sc.viewDelegatorValue(sc.activeStakeOf),
+		"getUserUnStakedValue": sc.viewDelegatorValue(sc.unStakedValueOf),
+		"getUserUnBondable":    sc.viewDelegatorValue(sc.unBondableValueOf),
+		"getClaimableRewards":  sc.viewDelegatorValue(sc.rewardsOf),
+		"getTotalActiveStake":  sc.getTotalActiveStake,
+	}
+}
+
+// delegate, with the delegated value as call value
+func (sc *DelegationSCMock) delegate(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+	contract, err := sc.getCalledContract(input)
+	if err != nil {
+		return err
+	}
+	if input.CallValue.Cmp(sc.MinDelegation) < 0 {
+		return fmt.Errorf("delegate value must be higher than minDelegationAmount %s", sc.MinDelegation)
+	}
+
+	contract.TotalActiveStake.Add(contract.TotalActiveStake, input.CallValue)
+	if contract.MaxDelegationCap.Sign() > 0 && contract.TotalActiveStake.Cmp(contract.MaxDelegationCap) > 0 {
+		return fmt.Errorf("total delegation cap reached")
+	}
+
+	delegator := contract.getDelegator(input.CallerAddr)
+	delegator.ActiveStake.Add(delegator.ActiveStake, input.CallValue)
+	return saveDelegationContract(output, input.RecipientAddr, contract)
+}
+
+// unDelegate@value; the value can be withdrawn after UnBondPeriodEpochs
+func (sc *DelegationSCMock) unDelegate(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+	contract, delegator, err := sc.getCalledContractAndDelegator(input)
+	if err != nil {
+		return err
+	}
+	if len(input.Arguments) != 1 {
+		return fmt.Errorf("wrong number of arguments")
+	}
+
+	value := big.NewInt(0).SetBytes(input.Arguments[0])
+	if value.Sign() == 0 || value.Cmp(delegator.ActiveStake) > 0 {
+		return fmt.Errorf("invalid value to undelegate")
+	}
+
+	remaining := big.NewInt(0).Sub(delegator.ActiveStake, value)
+	if remaining.Sign() > 0 && remaining.Cmp(sc.MinDelegation) < 0 {
+		return fmt.Errorf("invalid value to undelegate - need to undelegate all - remaining is under minimum")
+	}
+
+	delegator.ActiveStake = remaining
+	delegator.UnStaked = append(delegator.UnStaked, &UnStakedFunds{
+		Value: value,
+		Epoch: sc.World.CurrentEpoch(),
+	})
+	contract.TotalActiveStake.Sub(contract.TotalActiveStake, value)
+	return saveDelegationContract(output, input.RecipientAddr, contract)
+}
+
+// withdraw pays back all the value undelegated at least UnBondPeriodEpochs ago
+func (sc *DelegationSCMock) withdraw(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+	contract, delegator, err := sc.getCalledContractAndDelegator(input)
+	if err != nil {
+		return err
+	}
+
+	unBondable := sc.unBondableValueOf(delegator)
+	if unBondable.Sign() == 0 {
+		return fmt.Errorf("nothing to unBond")
+	}
+
+	stillUnBonding := make([]*UnStakedFunds, 0, len(delegator.UnStaked))
+	for _, funds := range delegator.UnStaked {
+		if !sc.isUnBondable(funds) {
+			stillUnBonding = append(stillUnBonding, funds)
+		}
+	}
+	delegator.UnStaked = stillUnBonding
+
+	sendValue(output, input.RecipientAddr, input.CallerAddr, unBondable)
+	return saveDelegationContract(output, input.RecipientAddr, contract)
+}
+
+// claimRewards pays the rewards of the caller
+func (sc *DelegationSCMock) claimRewards(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+	contract, delegator, err := sc.getCalledContractAndDelegator(input)
+	if err != nil {
+		return err
+	}
+	if delegator.Rewards.Sign() == 0 {
+		return fmt.Errorf("no rewards to claim")
+	}
+
+	sendValue(output, input.RecipientAddr, input.CallerAddr, delegator.Rewards)
+	delegator.Rewards = big.NewInt(0)
+	return saveDelegationContract(output, input.RecipientAddr, contract)
+}
+
+// reDelegateRewards adds the rewards of the caller to its active stake
+func (sc *DelegationSCMock) reDelegateRewards(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+	contract, delegator, err := sc.getCalledContractAndDelegator(input)
+	if err != nil {
+		return err
+	}
+	if delegator.Rewards.Sign() == 0 {
+		return fmt.Errorf("no rewards to redelegate")
+	}
+
+	delegator.ActiveStake.Add(delegator.ActiveStake, delegator.Rewards)
+	contract.TotalActiveStake.Add(contract.TotalActiveStake, delegator.Rewards)
+	delegator.Rewards = big.NewInt(0)
+	return saveDelegationContract(output, input.RecipientAddr, contract)
+}
+
+// getTotalActiveStake returns the value actively delegated to the contract
+func (sc *DelegationSCMock) getTotalActiveStake(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+	contract, err := sc.getCalledContract(input)
+	if err != nil {
+		return err
+	}
+	err = checkNotPayable(input)
+	if err != nil {
+		return err
+	}
+
+	output.ReturnData = [][]byte{contract.TotalActiveStake.Bytes()}
+	return nil
+}
+
+// viewDelegatorValue creates the view functions called with the delegator address as argument
+func (sc *DelegationSCMock) viewDelegatorValue(getValue func(delegator *DelegatorInfo) *big.Int) systemSCFunction {
+	return func(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+		contract, err := sc.getCalledContract(input)
+		if err != nil {
+			return err
+		}
+		err = checkNotPayable(input)
+		if err != nil {
+			return err
+		}
+		if len(input.Arguments) != 1 {
+			return fmt.Errorf("wrong number of arguments")
+		}
+
+		delegator, found := contract.Delegators[hex.EncodeToString(input.Arguments[0])]
+		if !found {
+			return fmt.Errorf("view function works only for existing delegators")
+		}
+
+		output.ReturnData = [][]byte{getValue(delegator).Bytes()}
+		return nil
+	}
+}
+
+func (sc *DelegationSCMock) activeStakeOf(delegator *DelegatorInfo) *big.Int {
+	return delegator.ActiveStake
+}
+
+func (sc *DelegationSCMock) unStakedValueOf(delegator *DelegatorInfo) *big.Int {
+	total := big.NewInt(0)
+	for _, funds := range delegator.UnStaked {
+		total.Add(total, funds.Value)
+	}
+
+	return total
+}
+
+func (sc *DelegationSCMock) unBondableValueOf(delegator *DelegatorInfo) *big.Int {
+	total := big.NewInt(0)
+	for _, funds := range delegator.UnStaked {
+		if sc.isUnBondable(funds) {
+			total.Add(total, funds.Value)
+		}
+	}
+
+	return total
+}
+
+func (sc *DelegationSCMock) rewardsOf(delegator *DelegatorInfo) *big.Int {
+	return delegator.Rewards
+}
+
+func (sc *DelegationSCMock) isUnBondable(funds *UnStakedFunds) bool {
+	return sc.World.CurrentEpoch() >= funds.Epoch+sc.UnBondPeriodEpochs
+}
+
+func (sc *DelegationSCMock) getCalledContract(input *vmcommon.ContractCallInput) (*DelegationContractInfo, error) {
+	contract := sc.GetContractInfo(input.RecipientAddr)
+	if contract == nil {
+		return nil, fmt.Errorf("no delegation contract at address %x", input.RecipientAddr)
+	}
+
+	return contract, nil
+}
+
+// getCalledContractAndDelegator returns the contract and the caller, for the non-payable functions called by the delegators
+func (sc *DelegationSCMock) getCalledContractAndDelegator(input *vmcommon.ContractCallInput) (*DelegationContractInfo, *DelegatorInfo, error) {
+	err := checkNotPayable(input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	contract, err := sc.getCalledContract(input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	delegator, found := contract.Delegators[hex.EncodeToString(input.CallerAddr)]
+	if !found {
+		return nil, nil, fmt.Errorf("caller is not a delegator")
+	}
+
+	return contract, delegator, nil
+}
+
+func saveDelegationContract(output *vmcommon.VMOutput, contractAddress []byte, contract *DelegationContractInfo) error {
+	return saveSystemSCState(output, contractAddress, delegationContractStateKey, contract)
+}
+
+// getDelegator returns the delegator with the given address, adding it to the contract if it is new
+func (contract *DelegationContractInfo) getDelegator(address []byte) *DelegatorInfo {
+	key := hex.EncodeToString(address)
+	delegator, found := contract.Delegators[key]
+	if !found {
+		delegator = newDelegatorInfo(big.NewInt(0))
+		contract.Delegators[key] = delegator
+	}
+
+	return delegator
+}
+
+func newDelegatorInfo(activeStake *big.Int) *DelegatorInfo {
+	return &DelegatorInfo{
+		ActiveStake: big.NewInt(0).Set(activeStake),
+		UnStaked:    make([]*UnStakedFunds, 0),
+		Rewards:     big.NewInt(0),
+	}
+}
+
+// makeDelegationSCAddress creates the address of a delegation contract, as a system SC address with the index in its last bytes
+func makeDelegationSCAddress(index uint64) []byte {
+	address := make([]byte, len(DelegationManagerSCAddress))
+	copy(address, DelegationManagerSCAddress[:vmcommon.NumInitCharactersForScAddress])
+	indexBytes := big.NewInt(0).SetUint64(index).Bytes()
+	copy(address[len(address)-2-len(indexBytes):], indexBytes)
+	address[len(address)-2] = 255
+	address[len(address)-1] = 255
+	return address
+}
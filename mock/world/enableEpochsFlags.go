@@ -0,0 +1,228 @@
+package worldmock
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+)
+
+// EnableEpochsAddress is the reserved address used by scenarios to declare the epoch flags they depend on.
+// Its storage is interpreted as flag settings instead of being saved as an account.
+// It is the value of the scenario expression "address:enable-epochs".
+var EnableEpochsAddress = []byte("enable-epochs___________________")
+
+// ErrUnknownFlag signals that the EnableEpochsHandlerStub has no flag with the given name
+var ErrUnknownFlag = errors.New("unknown epoch flag")
+
+// ProtocolRelease groups the epoch flags activated on mainnet by a node release.
+type ProtocolRelease struct {
+	Name  string
+	Flags []string
+}
+
+// ProtocolReleases lists the node releases which activated flags of the EnableEpochsHandlerStub, oldest first.
+// The flags not listed here were active before the first of these releases. Each flag is listed under the
+// mx-chain-go release whose config/enableEpochs.toml introduced its activation epoch, see enableEpochConfigKey.
+// The list can be replaced with the one read by LoadProtocolReleases from the enableEpochs.toml of a node,
+// to follow the activation epochs of a network instead.
+var ProtocolReleases = []ProtocolRelease{
+	{
+		Name: "v1.4",
+		Flags: []string{
+			"AlwaysSaveTokenMetaData",
+			"MaxBlockchainHookCounters",
+			"RuntimeMemStoreLimit",
+			"WipeSingleNFTLiquidityDecrease",
+		},
+	},
+	{
+		Name: "v1.5",
+		Flags: []string{
+			"AutoBalanceDataTries",
+			"ChangeUsername",
+			"ConsistentTokensValuesLengthCheck",
+			"GuardAccount",
+			"RuntimeCodeSizeFix",
+			"SetGuardian",
+		},
+	},
+	{
+		Name: "v1.6",
+		Flags: []string{
+			"DynamicGasCostForDataTrieStorageLoad",
+			"ScToScLogEvent",
+		},
+	},
+}
+
+// enableEpochsConfigSection is the table of the enableEpochs.toml of the node which holds the activation epochs
+const enableEpochsConfigSection = "EnableEpochs"
+
+// enableEpochConfigKeys holds the keys of the enableEpochs.toml of the node for the flags not following
+// the "<flag>EnableEpoch" naming
+var enableEpochConfigKeys = map[string]string{
+	"GuardAccount": "SetGuardianEnableEpoch",
+}
+
+// enableEpochConfigKey returns the key of the enableEpochs.toml of the node holding the activation epoch of the flag
+func enableEpochConfigKey(flagName string) string {
+	key, found := enableEpochConfigKeys[flagName]
+	if found {
+		return key
+	}
+
+	return flagName + "EnableEpoch"
+}
+
+// LoadProtocolReleases reads the activation epochs of the flags from the enableEpochs.toml of a node and groups
+// the flags activated in the same epoch into a release named after the epoch, e.g. "epoch-1265", oldest first.
+// The flags missing from the file are considered active before the first of these releases.
+func LoadProtocolReleases(enableEpochsPath string) ([]ProtocolRelease, error) {
+	tree, err := toml.LoadFile(enableEpochsPath)
+	if err != nil {
+		return nil, err
+	}
+	section, ok := tree.Get(enableEpochsConfigSection).(*toml.Tree)
+	if !ok {
+		return nil, fmt.Errorf("%s: no [%s] table", enableEpochsPath, enableEpochsConfigSection)
+	}
+
+	flagsByEpoch := make(map[int64][]string)
+	for _, flagName := range EnableEpochsFlagNames() {
+		key := enableEpochConfigKey(flagName)
+		value := section.Get(key)
+		if value == nil {
+			continue
+		}
+		epoch, ok := value.(int64)
+		if !ok || epoch < 0 {
+			return nil, fmt.Errorf("%s: invalid epoch for %s", enableEpochsPath, key)
+		}
+		flagsByEpoch[epoch] = append(flagsByEpoch[epoch], flagName)
+	}
+
+	epochs := make([]int64, 0, len(flagsByEpoch))
+	for epoch := range flagsByEpoch {
+		epochs = append(epochs, epoch)
+	}
+	sort.Slice(epochs, func(i, j int) bool {
+		return epochs[i] < epochs[j]
+	})
+
+	releases := make([]ProtocolRelease, 0, len(epochs))
+	for _, epoch := range epochs {
+		releases = append(releases, ProtocolRelease{
+			Name:  fmt.Sprintf("epoch-%d", epoch),
+			Flags: flagsByEpoch[epoch],
+		})
+	}
+
+	return releases, nil
+}
+
+// EnableEpochsFlagNames returns the sorted names of the boolean flags of the EnableEpochsHandlerStub.
+// The name of a flag is the name of its field, without the "Is" prefix and the "FlagEnabledField"
+// or "EnabledField" suffix, e.g. "RefactorContext" for IsRefactorContextFlagEnabledField.
+func EnableEpochsFlagNames() []string {
+	stubType := reflect.TypeOf(EnableEpochsHandlerStub{})
+
+	names := make([]string, 0, stubType.NumField())
+	for i := 0; i < stubType.NumField(); i++ {
+		field := stubType.Field(i)
+		if field.Type.Kind() == reflect.Bool {
+			names = append(names, flagNameFromField(field.Name))
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// GetProtocolRelease returns the release with the given name
+func GetProtocolRelease(name string) (ProtocolRelease, bool) {
+	for _, release := range ProtocolReleases {
+		if release.Name == name {
+			return release, true
+		}
+	}
+
+	return ProtocolRelease{}, false
+}
+
+// ReleaseFlags returns the state of all the flags after the activation of the given release:
+// the flags of the release and of the previous ones are active, while those of the next ones are not.
+func ReleaseFlags(name string) (map[string]bool, error) {
+	_, found := GetProtocolRelease(name)
+	if !found {
+		return nil, fmt.Errorf("unknown protocol release \"%s\"", name)
+	}
+
+	flags := make(map[string]bool)
+	for _, flagName := range EnableEpochsFlagNames() {
+		flags[flagName] = true
+	}
+
+	activated := true
+	for _, release := range ProtocolReleases {
+		for _, flagName := range release.Flags {
+			flags[flagName] = activated
+		}
+		if release.Name == name {
+			activated = false
+		}
+	}
+
+	return flags, nil
+}
+
+// SetFlag enables or disables the flag with the given name
+func (stub *EnableEpochsHandlerStub) SetFlag(name string, enabled bool) error {
+	field, err := stub.flagField(name)
+	if err != nil {
+		return err
+	}
+
+	field.SetBool(enabled)
+	return nil
+}
+
+// IsFlagSet returns whether the flag with the given name is enabled
+func (stub *EnableEpochsHandlerStub) IsFlagSet(name string) (bool, error) {
+	field, err := stub.flagField(name)
+	if err != nil {
+		return false, err
+	}
+
+	return field.Bool(), nil
+}
+
+func (stub *EnableEpochsHandlerStub) flagField(name string) (reflect.Value, error) {
+	stubValue := reflect.ValueOf(stub).Elem()
+	for _, fieldName := range []string{
+		"Is" + name + "FlagEnabledField",
+		"Is" + name + "EnabledField",
+		name + "EnabledField",
+	} {
+		field := stubValue.FieldByName(fieldName)
+		if field.IsValid() && field.Kind() == reflect.Bool {
+			return field, nil
+		}
+	}
+
+	return reflect.Value{}, fmt.Errorf("%w \"%s\"", ErrUnknownFlag, name)
+}
+
+func flagNameFromField(fieldName string) string {
+	name := strings.TrimPrefix(fieldName, "Is")
+	for _, suffix := range []string{"FlagEnabledField", "EnabledField"} {
+		if strings.HasSuffix(name, suffix) {
+			return strings.TrimSuffix(name, suffix)
+		}
+	}
+
+	return name
+}
@@ -0,0 +1,96 @@
+package worldmock
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnableEpochsHandlerStub_Flags(t *testing.T) {
+	t.Parallel()
+
+	require.Len(t, EnableEpochsAddress, 32)
+
+	stub := EnableEpochsHandlerStubNoFlags()
+	names := EnableEpochsFlagNames()
+	require.Contains(t, names, "RefactorContext")
+	require.Contains(t, names, "FixOldTokenLiquidity")
+	require.Contains(t, names, "ScToScLogEvent")
+
+	for _, name := range names {
+		enabled, err := stub.IsFlagSet(name)
+		require.Nil(t, err)
+		require.False(t, enabled)
+	}
+
+	err := stub.SetFlag("RefactorContext", true)
+	require.Nil(t, err)
+	require.True(t, stub.IsRefactorContextFlagEnabled())
+	err = stub.SetFlag("ScToScLogEvent", true)
+	require.Nil(t, err)
+	require.True(t, stub.IsScToScEventLogEnabled())
+
+	err = stub.SetFlag("NoSuchFlag", true)
+	require.True(t, errors.Is(err, ErrUnknownFlag))
+	_, err = stub.IsFlagSet("RefactorContextEnableEpoch")
+	require.True(t, errors.Is(err, ErrUnknownFlag))
+}
+
+func TestReleaseFlags(t *testing.T) {
+	t.Parallel()
+
+	stub := EnableEpochsHandlerStubNoFlags()
+	for _, release := range ProtocolReleases {
+		for _, name := range release.Flags {
+			_, err := stub.IsFlagSet(name)
+			require.Nil(t, err, "release %s", release.Name)
+		}
+	}
+
+	flags, err := ReleaseFlags("v1.5")
+	require.Nil(t, err)
+	require.Len(t, flags, len(EnableEpochsFlagNames()))
+	require.True(t, flags["RefactorContext"])
+	require.True(t, flags["AlwaysSaveTokenMetaData"])
+	require.True(t, flags["SetGuardian"])
+	require.False(t, flags["ScToScLogEvent"])
+
+	_, err = ReleaseFlags("v0.1")
+	require.NotNil(t, err)
+}
+
+func TestLoadProtocolReleases(t *testing.T) {
+	t.Parallel()
+
+	enableEpochsPath := filepath.Join(t.TempDir(), "enableEpochs.toml")
+	err := os.WriteFile(enableEpochsPath, []byte(`[EnableEpochs]
+    SCDeployEnableEpoch = 0
+    SetGuardianEnableEpoch = 1265
+    ScToScLogEventEnableEpoch = 1393
+    DynamicGasCostForDataTrieStorageLoadEnableEpoch = 1265
+    UnknownFlagEnableEpoch = 7
+    BLSMultiSignerEnableEpoch = [{ EnableEpoch = 0, Type = "no-KOSK" }]
+`), 0644)
+	require.Nil(t, err)
+
+	releases, err := LoadProtocolReleases(enableEpochsPath)
+	require.Nil(t, err)
+	require.Equal(t, []ProtocolRelease{
+		{Name: "epoch-0", Flags: []string{"SCDeploy"}},
+		{Name: "epoch-1265", Flags: []string{"DynamicGasCostForDataTrieStorageLoad", "GuardAccount", "SetGuardian"}},
+		{Name: "epoch-1393", Flags: []string{"ScToScLogEvent"}},
+	}, releases)
+
+	err = os.WriteFile(enableEpochsPath, []byte(`[EnableEpochs]
+    SetGuardianEnableEpoch = "soon"
+`), 0644)
+	require.Nil(t, err)
+	_, err = LoadProtocolReleases(enableEpochsPath)
+	require.NotNil(t, err)
+
+	_, err = LoadProtocolReleases(filepath.Join(t.TempDir(), "missing.toml"))
+	require.NotNil(t, err)
+}
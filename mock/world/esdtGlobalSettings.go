@@ -0,0 +1,83 @@
+package worldmock
+
+import (
+	"bytes"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+)
+
+// esdtKeyPrefix is the prefix of the ESDT keys, both in the accounts holding
+// tokens and in the system account.
+var esdtKeyPrefix = []byte(core.ProtectedKeyPrefix + core.ESDTKeyIdentifier)
+
+// esdtGlobalSettingsKeyPrefix is the prefix of the keys holding the global settings of the tokens in the system account.
+// The protocol stores them under the ESDT key of the token, without a nonce, which only the length of the token
+// identifier tells apart from the NFT metadata keys, so the mock keeps them under a prefix of their own.
+var esdtGlobalSettingsKeyPrefix = []byte(core.ProtectedKeyPrefix + "globalsettings")
+
+const esdtIdentifierSeparator = "-"
+
+// MakeESDTGlobalSettingsKey creates the key under which the global settings of a token
+// (paused, limited transfer, burn for all) are stored in the storage of the system account.
+func MakeESDTGlobalSettingsKey(tokenIdentifier []byte) []byte {
+	key := make([]byte, 0, len(esdtGlobalSettingsKeyPrefix)+len(tokenIdentifier))
+	key = append(key, esdtGlobalSettingsKeyPrefix...)
+	return append(key, tokenIdentifier...)
+}
+
+// makeESDTKey creates the ESDT key of a token without a nonce, which in the accounts
+// holding the token is the key of the fungible balance.
+func makeESDTKey(tokenIdentifier []byte) []byte {
+	key := make([]byte, 0, len(esdtKeyPrefix)+len(tokenIdentifier))
+	key = append(key, esdtKeyPrefix...)
+	return append(key, tokenIdentifier...)
+}
+
+// GetESDTGlobalSettings returns the global settings of a token, as stored in
+// the system account; a token without settings has all of them unset.
+func (b *MockWorld) GetESDTGlobalSettings(tokenIdentifier []byte) builtInFunctions.ESDTGlobalMetadata {
+	systemAccount := b.AcctMap.GetAccount(vmcommon.SystemAccountAddress)
+	if systemAccount == nil {
+		return builtInFunctions.ESDTGlobalMetadata{}
+	}
+
+	value := systemAccount.Storage[string(MakeESDTGlobalSettingsKey(tokenIdentifier))]
+	return builtInFunctions.ESDTGlobalMetadataFromBytes(value)
+}
+
+// SetESDTGlobalSettings saves the global settings of a token in the system
+// account, creating the system account if it does not exist yet.
+func (b *MockWorld) SetESDTGlobalSettings(tokenIdentifier []byte, settings builtInFunctions.ESDTGlobalMetadata) {
+	systemAccount := b.AcctMap.GetAccount(vmcommon.SystemAccountAddress)
+	if systemAccount == nil {
+		systemAccount = b.AcctMap.CreateAccount(vmcommon.SystemAccountAddress, b)
+	}
+
+	systemAccount.SetStorageValue(string(MakeESDTGlobalSettingsKey(tokenIdentifier)), settings.ToBytes())
+}
+
+// GetSystemAccountTokenMetadata returns the NFT metadata saved in the storage of the
+// system account, leaving out the global settings of the tokens.
+func (am AccountMap) GetSystemAccountTokenMetadata() map[string][]byte {
+	tokenMetadata := make(map[string][]byte)
+	systemAccount := am.GetAccount(vmcommon.SystemAccountAddress)
+	if systemAccount == nil {
+		return tokenMetadata
+	}
+
+	for key, value := range systemAccount.Storage {
+		if IsESDTGlobalSettingsKey([]byte(key)) {
+			continue
+		}
+		tokenMetadata[key] = value
+	}
+
+	return tokenMetadata
+}
+
+// IsESDTGlobalSettingsKey returns true if the key holds the global settings of a token in the system account.
+func IsESDTGlobalSettingsKey(key []byte) bool {
+	return bytes.HasPrefix(key, esdtGlobalSettingsKeyPrefix)
+}
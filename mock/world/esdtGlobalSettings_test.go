@@ -0,0 +1,60 @@
+package worldmock
+
+import (
+	"testing"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockWorld_ESDTGlobalSettingsPerToken(t *testing.T) {
+	t.Parallel()
+
+	world := NewMockWorld()
+	pausedToken := []byte("PAUSED-abcdef")
+	limitedToken := []byte("LIMITED-abcdef")
+	otherToken := []byte("OTHER-abcdef")
+
+	require.False(t, world.IsPaused(pausedToken))
+	require.Nil(t, world.AcctMap.GetAccount(vmcommon.SystemAccountAddress))
+
+	world.SetESDTGlobalSettings(pausedToken, builtInFunctions.ESDTGlobalMetadata{Paused: true})
+	world.SetESDTGlobalSettings(limitedToken, builtInFunctions.ESDTGlobalMetadata{LimitedTransfer: true, BurnRoleForAll: true})
+
+	require.True(t, world.IsPaused(pausedToken))
+	require.False(t, world.IsLimitedTransfer(pausedToken))
+	require.False(t, world.IsPaused(limitedToken))
+	require.True(t, world.IsLimitedTransfer(limitedToken))
+	require.True(t, world.GetESDTGlobalSettings(limitedToken).BurnRoleForAll)
+	require.False(t, world.IsPaused(otherToken))
+	require.False(t, world.IsLimitedTransfer(otherToken))
+
+	// stored in the format of the protocol, under a key prefix of their own
+	systemAccount := world.AcctMap.GetAccount(vmcommon.SystemAccountAddress)
+	require.Equal(t, []byte{builtInFunctions.MetadataPaused, 0}, systemAccount.Storage["ELRONDglobalsettingsPAUSED-abcdef"])
+
+	world.IsPausedValue = true
+	require.True(t, world.IsPaused(otherToken))
+}
+
+func TestAccountMap_GetSystemAccountTokenMetadata(t *testing.T) {
+	t.Parallel()
+
+	world := NewMockWorld()
+	world.SetESDTGlobalSettings([]byte("NFT-abcdef"), builtInFunctions.ESDTGlobalMetadata{Paused: true})
+	systemAccount := world.AcctMap.GetAccount(vmcommon.SystemAccountAddress)
+	systemAccount.Storage["ELRONDesdtNFT-abcdef\x01"] = []byte("metadata")
+	systemAccount.Storage["ELRONDesdtNFT-abcdef\x2d"] = []byte("metadata")
+
+	tokenMetadata := world.AcctMap.GetSystemAccountTokenMetadata()
+	require.Equal(t, map[string][]byte{
+		"ELRONDesdtNFT-abcdef\x01": []byte("metadata"),
+		"ELRONDesdtNFT-abcdef\x2d": []byte("metadata"),
+	}, tokenMetadata)
+
+	require.True(t, IsESDTGlobalSettingsKey([]byte("ELRONDglobalsettingsNFT-abcdef")))
+	require.False(t, IsESDTGlobalSettingsKey([]byte("ELRONDesdtNFT-abcdef")))
+	require.False(t, IsESDTGlobalSettingsKey([]byte("ELRONDesdtNFT-abcdef\x2d")))
+	require.False(t, IsESDTGlobalSettingsKey([]byte("NFT-abcdef")))
+}
@@ -0,0 +1,686 @@
+package worldmock
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	"github.com/multiversx/mx-chain-scenario-go/esdtconvert"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+)
+
+// MetaESDT is the type of the Meta-ESDT tokens, which the system SC registers next to the core ESDT types.
+const MetaESDT = "MetaESDT"
+
+const (
+	minTickerLength    = 3
+	maxTickerLength    = 10
+	minTokenNameLength = 3
+	maxTokenNameLength = 20
+
+	tokenRandomSequenceBytes = 3
+)
+
+// the token properties, which can be set when issuing a token
+const (
+	propertyCanFreeze                 = "canFreeze"
+	propertyCanWipe                   = "canWipe"
+	propertyCanPause                  = "canPause"
+	propertyCanTransferNFTCreateRole  = "canTransferNFTCreateRole"
+	propertyCanChangeOwner            = "canChangeOwner"
+	propertyCanUpgrade                = "canUpgrade"
+	propertyCanAddSpecialRoles        = "canAddSpecialRoles"
+	propertyCanCreateMultiShard       = "canCreateMultiShard"
+	registerAndSetAllRolesFungible    = "FNG"
+	registerAndSetAllRolesNonFungible = "NFT"
+	registerAndSetAllRolesSemiFung    = "SFT"
+	registerAndSetAllRolesMeta        = "META"
+)
+
+var allTokenProperties = []string{
+	propertyCanFreeze,
+	propertyCanWipe,
+	propertyCanPause,
+	propertyCanTransferNFTCreateRole,
+	propertyCanChangeOwner,
+	propertyCanUpgrade,
+	propertyCanAddSpecialRoles,
+	propertyCanCreateMultiShard,
+}
+
+// allRolesByTokenType are the roles set by registerAndSetAllRoles
+var allRolesByTokenType = map[string][]string{
+	core.FungibleESDT:     {core.ESDTRoleLocalMint, core.ESDTRoleLocalBurn},
+	core.NonFungibleESDT:  {core.ESDTRoleNFTCreate, core.ESDTRoleNFTBurn, core.ESDTRoleNFTUpdateAttributes, core.ESDTRoleNFTAddURI},
+	core.SemiFungibleESDT: {core.ESDTRoleNFTCreate, core.ESDTRoleNFTBurn, core.ESDTRoleNFTAddQuantity},
+	MetaESDT:              {core.ESDTRoleNFTCreate, core.ESDTRoleNFTBurn, core.ESDTRoleNFTAddQuantity},
+	DynamicNFT:            append([]string{core.ESDTRoleNFTCreate, core.ESDTRoleNFTBurn, core.ESDTRoleNFTUpdateAttributes, core.ESDTRoleNFTAddURI}, nftMetaDataRoles...),
+	DynamicSFT:            append([]string{core.ESDTRoleNFTCreate, core.ESDTRoleNFTBurn, core.ESDTRoleNFTAddQuantity}, nftMetaDataRoles...),
+	DynamicMeta:           append([]string{core.ESDTRoleNFTCreate, core.ESDTRoleNFTBurn, core.ESDTRoleNFTAddQuantity}, nftMetaDataRoles...),
+}
+
+// nftMetaDataRoles are the roles allowing to change the metadata of the instances, which all the non-fungible tokens accept
+var nftMetaDataRoles = []string{
+	ESDTRoleNFTRecreate,
+	ESDTRoleNFTUpdate,
+	ESDTRoleModifyRoyalties,
+	ESDTRoleSetNewURI,
+	ESDTRoleModifyCreator,
+}
+
+// dynamicTypes are the dynamic counterparts of the non-fungible token types
+var dynamicTypes = map[string]string{
+	core.NonFungibleESDT:  DynamicNFT,
+	core.SemiFungibleESDT: DynamicSFT,
+	MetaESDT:              DynamicMeta,
+}
+
+// ESDTTokenInfo holds what the ESDT system SC knows about a token
+type ESDTTokenInfo struct {
+	Identifier  string
+	Name        string
+	Ticker      string
+	Type        string
+	Owner       []byte
+	NumDecimals uint64
+	Properties  map[string]bool
+}
+
+// ESDTSystemSCMock is a Go stand-in for the ESDT system smart contract of the metachain.
+// It is an OtherVMHandler of the system VM, so the contracts reach it with async calls and
+// get their callbacks. The token state it changes, in the system SC account and in the accounts
+// holding the tokens, is returned as storage updates in the VMOutput of the call, the same changes
+// the protocol would make once the system SC results are processed.
+//
+// The tokens are kept in the storage of the system SC account, so they are reverted with the rest of the world.
+type ESDTSystemSCMock struct {
+	World *MockWorld
+
+	// IssueCost is the value which must be paid for issuing a token; any value is accepted when nil
+	IssueCost *big.Int
+}
+
+// RegisterESDTSystemSC installs an ESDTSystemSCMock in the system VM, at the address of the ESDT system SC
+func (b *MockWorld) RegisterESDTSystemSC() *ESDTSystemSCMock {
+	b.ESDTSystemSC = &ESDTSystemSCMock{
+		World: b,
+	}
+	b.SystemVM().RegisterContract(core.ESDTSCAddress, b.ESDTSystemSC)
+	return b.ESDTSystemSC
+}
+
+// IsESDTSystemSCAddress returns true if the address is the address of the ESDT system SC
+func IsESDTSystemSCAddress(address []byte) bool {
+	return bytes.Equal(address, core.ESDTSCAddress)
+}
+
+// GetTokenInfo returns the token issued through the system SC, or nil if there is no such token
+func (sc *ESDTSystemSCMock) GetTokenInfo(tokenIdentifier []byte) *ESDTTokenInfo {
+	token := &ESDTTokenInfo{}
+	found, err := sc.World.loadSystemSCState(core.ESDTSCAddress, string(tokenIdentifier), token)
+	if !found || err != nil {
+		return nil
+	}
+
+	return token
+}
+
+// ExecuteCall runs a call on the system SC; the errors of the call are returned as user errors in the VMOutput
+func (sc *ESDTSystemSCMock) ExecuteCall(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	return sc.World.executeSystemSCFunction(input, sc.functions()), nil
+}
+
+func (sc *ESDTSystemSCMock) functions() map[string]systemSCFunction {
+	return map[string]systemSCFunction{
+		"issue":                         sc.issueFungible,
+		"issueNonFungible":              sc.issueWithoutSupply(core.NonFungibleESDT),
+		"issueSemiFungible":             sc.issueWithoutSupply(core.SemiFungibleESDT),
+		"registerMetaESDT":              sc.registerMetaESDT,
+		"registerAndSetAllRoles":        sc.registerAndSetAllRoles,
+		"registerDynamic":               sc.registerDynamic(false),
+		"registerAndSetAllRolesDynamic": sc.registerDynamic(true),
+		"changeToDynamic":               sc.changeToDynamic,
+		"setSpecialRole":                sc.setSpecialRole,
+		"unSetSpecialRole":              sc.unSetSpecialRole,
+		"pause":                         sc.setPaused(true),
+		"unPause":                       sc.setPaused(false),
+		"freeze":                        sc.setFrozen(true),
+		"unFreeze":                      sc.setFrozen(false),
+		"wipe":                          sc.wipe,
+		"transferOwnership":             sc.transferOwnership,
+	}
+}
+
+// issue@name@ticker@initialSupply@numDecimals@properties...
+func (sc *ESDTSystemSCMock) issueFungible(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+	err := checkNumArguments(input, 4)
+	if err != nil {
+		return err
+	}
+
+	initialSupply := big.NewInt(0).SetBytes(input.Arguments[2])
+	numDecimals := big.NewInt(0).SetBytes(input.Arguments[3]).Uint64()
+	token, err := sc.issueToken(input, output, core.FungibleESDT, numDecimals, input.Arguments[4:])
+	if err != nil {
+		return err
+	}
+
+	if initialSupply.Sign() == 0 {
+		output.ReturnData = [][]byte{[]byte(token.Identifier)}
+		return nil
+	}
+
+	return sc.sendTokens(input.CallerAddr, []byte(token.Identifier), initialSupply, output)
+}
+
+// issueNonFungible@name@ticker@properties... and issueSemiFungible@name@ticker@properties...
+func (sc *ESDTSystemSCMock) issueWithoutSupply(tokenType string) systemSCFunction {
+	return func(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+		err := checkNumArguments(input, 2)
+		if err != nil {
+			return err
+		}
+
+		token, err := sc.issueToken(input, output, tokenType, 0, input.Arguments[2:])
+		if err != nil {
+			return err
+		}
+
+		output.ReturnData = [][]byte{[]byte(token.Identifier)}
+		return nil
+	}
+}
+
+// registerMetaESDT@name@ticker@numDecimals@properties...
+func (sc *ESDTSystemSCMock) registerMetaESDT(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+	err := checkNumArguments(input, 3)
+	if err != nil {
+		return err
+	}
+
+	numDecimals := big.NewInt(0).SetBytes(input.Arguments[2]).Uint64()
+	token, err := sc.issueToken(input, output, MetaESDT, numDecimals, input.Arguments[3:])
+	if err != nil {
+		return err
+	}
+
+	output.ReturnData = [][]byte{[]byte(token.Identifier)}
+	return nil
+}
+
+// registerAndSetAllRoles@name@ticker@type@numDecimals
+func (sc *ESDTSystemSCMock) registerAndSetAllRoles(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+	if len(input.Arguments) != 4 {
+		return fmt.Errorf("arguments length mismatch")
+	}
+
+	tokenType, err := tokenTypeFromRegisterArgument(input.Arguments[2])
+	if err != nil {
+		return err
+	}
+
+	numDecimals := big.NewInt(0).SetBytes(input.Arguments[3]).Uint64()
+	token, err := sc.issueToken(input, output, tokenType, numDecimals, nil)
+	if err != nil {
+		return err
+	}
+
+	err = sc.changeRoles(output, input.CallerAddr, []byte(token.Identifier), allRolesByTokenType[tokenType], true)
+	if err != nil {
+		return err
+	}
+
+	output.ReturnData = [][]byte{[]byte(token.Identifier)}
+	return nil
+}
+
+// registerDynamic@name@ticker@type@numDecimals and registerAndSetAllRolesDynamic@name@ticker@type@numDecimals,
+// where the type is one of NFT, SFT and META, and the number of decimals is only given for META
+func (sc *ESDTSystemSCMock) registerDynamic(setAllRoles bool) systemSCFunction {
+	return func(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+		err := checkNumArguments(input, 3)
+		if err != nil {
+			return err
+		}
+
+		tokenType, err := tokenTypeFromRegisterArgument(input.Arguments[2])
+		if err != nil {
+			return err
+		}
+		dynamicType, ok := dynamicTypes[tokenType]
+		if !ok {
+			return fmt.Errorf("cannot register a dynamic %s token", tokenType)
+		}
+
+		numDecimals := uint64(0)
+		if tokenType == MetaESDT {
+			if len(input.Arguments) != 4 {
+				return fmt.Errorf("arguments length mismatch")
+			}
+			numDecimals = big.NewInt(0).SetBytes(input.Arguments[3]).Uint64()
+		} else if len(input.Arguments) != 3 {
+			return fmt.Errorf("arguments length mismatch")
+		}
+
+		token, err := sc.issueToken(input, output, dynamicType, numDecimals, nil)
+		if err != nil {
+			return err
+		}
+
+		if setAllRoles {
+			err = sc.changeRoles(output, input.CallerAddr, []byte(token.Identifier), allRolesByTokenType[dynamicType], true)
+			if err != nil {
+				return err
+			}
+		}
+
+		output.ReturnData = [][]byte{[]byte(token.Identifier)}
+		return nil
+	}
+}
+
+// changeToDynamic@tokenID
+func (sc *ESDTSystemSCMock) changeToDynamic(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+	if len(input.Arguments) != 1 {
+		return fmt.Errorf("invalid number of arguments, wanted 1")
+	}
+
+	token, err := sc.getTokenOwnedByCaller(input)
+	if err != nil {
+		return err
+	}
+	dynamicType, ok := dynamicTypes[token.Type]
+	if !ok {
+		return fmt.Errorf("cannot change %s tokens to dynamic", token.Type)
+	}
+
+	token.Type = dynamicType
+	return saveToken(output, token)
+}
+
+// setSpecialRole@tokenID@address@roles...
+func (sc *ESDTSystemSCMock) setSpecialRole(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+	return sc.changeSpecialRoles(input, output, true)
+}
+
+// unSetSpecialRole@tokenID@address@roles...
+func (sc *ESDTSystemSCMock) unSetSpecialRole(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+	return sc.changeSpecialRoles(input, output, false)
+}
+
+func (sc *ESDTSystemSCMock) changeSpecialRoles(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput, set bool) error {
+	err := checkNumArguments(input, 3)
+	if err != nil {
+		return err
+	}
+
+	token, err := sc.getTokenOwnedByCaller(input)
+	if err != nil {
+		return err
+	}
+	if set && !token.Properties[propertyCanAddSpecialRoles] {
+		return fmt.Errorf("cannot add special roles")
+	}
+
+	roles := make([]string, 0, len(input.Arguments)-2)
+	for _, role := range input.Arguments[2:] {
+		if !isRoleAllowedForTokenType(string(role), token.Type) {
+			return fmt.Errorf("invalid argument: %s is not a valid role for %s", role, token.Type)
+		}
+		roles = append(roles, string(role))
+	}
+
+	return sc.changeRoles(output, input.Arguments[1], []byte(token.Identifier), roles, set)
+}
+
+// pause@tokenID and unPause@tokenID
+func (sc *ESDTSystemSCMock) setPaused(paused bool) systemSCFunction {
+	return func(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+		if len(input.Arguments) != 1 {
+			return fmt.Errorf("invalid number of arguments, wanted 1")
+		}
+
+		token, err := sc.getTokenOwnedByCaller(input)
+		if err != nil {
+			return err
+		}
+		if !token.Properties[propertyCanPause] {
+			return fmt.Errorf("cannot pause/un-pause")
+		}
+
+		tokenIdentifier := []byte(token.Identifier)
+		settings := sc.World.GetESDTGlobalSettings(tokenIdentifier)
+		if settings.Paused == paused {
+			return fmt.Errorf("cannot pause an already paused contract or unpause a not paused one")
+		}
+
+		settings.Paused = paused
+		return sc.World.updateAccountStorage(output, vmcommon.SystemAccountAddress, func(storage map[string][]byte) error {
+			storage[string(MakeESDTGlobalSettingsKey(tokenIdentifier))] = settings.ToBytes()
+			return nil
+		})
+	}
+}
+
+// freeze@tokenID@address and unFreeze@tokenID@address
+func (sc *ESDTSystemSCMock) setFrozen(frozen bool) systemSCFunction {
+	return func(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+		if len(input.Arguments) != 2 {
+			return fmt.Errorf("invalid number of arguments, wanted 2")
+		}
+
+		token, err := sc.getTokenOwnedByCaller(input)
+		if err != nil {
+			return err
+		}
+		if !token.Properties[propertyCanFreeze] {
+			return fmt.Errorf("cannot freeze")
+		}
+
+		holder := sc.World.AcctMap.GetAccount(input.Arguments[1])
+		if holder == nil {
+			return fmt.Errorf("invalid address to freeze/unfreeze")
+		}
+
+		tokenIdentifier := []byte(token.Identifier)
+		return sc.World.updateAccountStorage(output, holder.Address, func(storage map[string][]byte) error {
+			tokenData, err := esdtconvert.GetTokenData(tokenIdentifier, 0, storage, make(map[string][]byte))
+			if err != nil {
+				return err
+			}
+
+			tokenData.Properties = esdtconvert.MakeESDTUserMetadataBytes(frozen)
+			return esdtconvert.SetTokenData(tokenIdentifier, 0, tokenData, storage)
+		})
+	}
+}
+
+// wipe@tokenID@address; only frozen accounts can be wiped
+func (sc *ESDTSystemSCMock) wipe(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+	if len(input.Arguments) != 2 {
+		return fmt.Errorf("invalid number of arguments, wanted 2")
+	}
+
+	token, err := sc.getTokenOwnedByCaller(input)
+	if err != nil {
+		return err
+	}
+	if !token.Properties[propertyCanWipe] {
+		return fmt.Errorf("cannot wipe")
+	}
+
+	holder := sc.World.AcctMap.GetAccount(input.Arguments[1])
+	if holder == nil {
+		return fmt.Errorf("invalid address to wipe")
+	}
+
+	tokenIdentifier := []byte(token.Identifier)
+	return sc.World.updateAccountStorage(output, holder.Address, func(storage map[string][]byte) error {
+		tokenData, err := esdtconvert.GetTokenData(tokenIdentifier, 0, storage, make(map[string][]byte))
+		if err != nil {
+			return err
+		}
+		if !builtInFunctions.ESDTUserMetadataFromBytes(tokenData.Properties).Frozen {
+			return fmt.Errorf("cannot wipe because the account is not frozen for this esdt token")
+		}
+
+		delete(storage, string(makeESDTKey(tokenIdentifier)))
+		return nil
+	})
+}
+
+// transferOwnership@tokenID@newOwner
+func (sc *ESDTSystemSCMock) transferOwnership(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+	if len(input.Arguments) != 2 {
+		return fmt.Errorf("expected num of arguments 2")
+	}
+
+	token, err := sc.getTokenOwnedByCaller(input)
+	if err != nil {
+		return err
+	}
+	if !token.Properties[propertyCanChangeOwner] {
+		return fmt.Errorf("cannot change owner of the token")
+	}
+	if len(input.Arguments[1]) != len(input.CallerAddr) {
+		return fmt.Errorf("destination address of invalid length")
+	}
+
+	token.Owner = input.Arguments[1]
+	return saveToken(output, token)
+}
+
+func (sc *ESDTSystemSCMock) issueToken(
+	input *vmcommon.ContractCallInput,
+	output *vmcommon.VMOutput,
+	tokenType string,
+	numDecimals uint64,
+	propertyArgs [][]byte,
+) (*ESDTTokenInfo, error) {
+	if sc.IssueCost != nil && (input.CallValue == nil || input.CallValue.Cmp(sc.IssueCost) != 0) {
+		return nil, fmt.Errorf("callValue not equals with baseIssuingCost")
+	}
+
+	name := input.Arguments[0]
+	ticker := input.Arguments[1]
+	if !isValidTokenName(name) {
+		return nil, fmt.Errorf("token name is not valid")
+	}
+	if !isValidTicker(ticker) {
+		return nil, fmt.Errorf("ticker name is not valid")
+	}
+
+	properties, err := parseTokenProperties(propertyArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	token := &ESDTTokenInfo{
+		Identifier:  sc.newTokenIdentifier(input.CallerAddr, ticker),
+		Name:        string(name),
+		Ticker:      string(ticker),
+		Type:        tokenType,
+		Owner:       input.CallerAddr,
+		NumDecimals: numDecimals,
+		Properties:  properties,
+	}
+	if sc.GetTokenInfo([]byte(token.Identifier)) != nil {
+		return nil, fmt.Errorf("token identifier %s already exists", token.Identifier)
+	}
+
+	err = saveToken(output, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// newTokenIdentifier appends a random sequence to the ticker, derived from the caller and the random seed like in the protocol
+func (sc *ESDTSystemSCMock) newTokenIdentifier(caller []byte, ticker []byte) string {
+	randomBase := append(append([]byte{}, caller...), sc.World.CurrentRandomSeed()...)
+	randomSequence := DefaultHasher.Compute(string(randomBase))[:tokenRandomSequenceBytes]
+	return string(ticker) + esdtIdentifierSeparator + hex.EncodeToString(randomSequence)
+}
+
+func (sc *ESDTSystemSCMock) getTokenOwnedByCaller(input *vmcommon.ContractCallInput) (*ESDTTokenInfo, error) {
+	token := sc.GetTokenInfo(input.Arguments[0])
+	if token == nil {
+		return nil, fmt.Errorf("no ticker with given name")
+	}
+	if !bytes.Equal(token.Owner, input.CallerAddr) {
+		return nil, fmt.Errorf("can be called by owner only")
+	}
+
+	return token, nil
+}
+
+func saveToken(output *vmcommon.VMOutput, token *ESDTTokenInfo) error {
+	return saveSystemSCState(output, core.ESDTSCAddress, token.Identifier, token)
+}
+
+func (sc *ESDTSystemSCMock) changeRoles(output *vmcommon.VMOutput, address []byte, tokenIdentifier []byte, roles []string, set bool) error {
+	return sc.World.updateAccountStorage(output, address, func(storage map[string][]byte) error {
+		return changeTokenRoles(storage, tokenIdentifier, roles, set)
+	})
+}
+
+func changeTokenRoles(storage map[string][]byte, tokenIdentifier []byte, roles []string, set bool) error {
+	currentRoles, err := esdtconvert.GetTokenRoles(tokenIdentifier, storage)
+	if err != nil {
+		return err
+	}
+
+	newRoles := make([][]byte, 0, len(currentRoles)+len(roles))
+	for _, role := range currentRoles {
+		if !set && containsString(roles, string(role)) {
+			continue
+		}
+		newRoles = append(newRoles, role)
+	}
+	if set {
+		for _, role := range roles {
+			if !containsBytes(newRoles, []byte(role)) {
+				newRoles = append(newRoles, []byte(role))
+			}
+		}
+	}
+
+	return esdtconvert.SetTokenRoles(tokenIdentifier, newRoles, storage)
+}
+
+// sendTokens credits the tokens, as a storage update of the destination, and adds the transfer to the output,
+// for the callback to receive them as payment; the host does not execute the transfer of an async callback
+func (sc *ESDTSystemSCMock) sendTokens(destination []byte, tokenIdentifier []byte, value *big.Int, output *vmcommon.VMOutput) error {
+	tokenData := &esdt.ESDigitalToken{
+		Value: value,
+		Type:  uint32(core.Fungible),
+	}
+	err := sc.World.updateAccountStorage(output, destination, func(storage map[string][]byte) error {
+		return esdtconvert.SetTokenData(tokenIdentifier, 0, tokenData, storage)
+	})
+	if err != nil {
+		return err
+	}
+
+	data := core.BuiltInFunctionESDTTransfer + "@" + hex.EncodeToString(tokenIdentifier) + "@" + hex.EncodeToString(value.Bytes())
+	outputAccount := getOrCreateOutputAccount(output, destination)
+	outputAccount.OutputTransfers = append(outputAccount.OutputTransfers, vmcommon.OutputTransfer{
+		Value:         big.NewInt(0),
+		Data:          []byte(data),
+		CallType:      vm.AsynchronousCallBack,
+		SenderAddress: core.ESDTSCAddress,
+	})
+
+	return nil
+}
+
+func parseTokenProperties(args [][]byte) (map[string]bool, error) {
+	properties := map[string]bool{
+		propertyCanUpgrade:         true,
+		propertyCanAddSpecialRoles: true,
+	}
+	if len(args)%2 != 0 {
+		return nil, fmt.Errorf("odd number of arguments")
+	}
+
+	for i := 0; i < len(args); i += 2 {
+		name := string(args[i])
+		if !containsString(allTokenProperties, name) {
+			return nil, fmt.Errorf("invalid argument: %s", name)
+		}
+
+		switch string(args[i+1]) {
+		case "true":
+			properties[name] = true
+		case "false":
+			properties[name] = false
+		default:
+			return nil, fmt.Errorf("invalid argument: %s", args[i+1])
+		}
+	}
+
+	return properties, nil
+}
+
+func tokenTypeFromRegisterArgument(arg []byte) (string, error) {
+	switch string(arg) {
+	case registerAndSetAllRolesFungible:
+		return core.FungibleESDT, nil
+	case registerAndSetAllRolesNonFungible:
+		return core.NonFungibleESDT, nil
+	case registerAndSetAllRolesSemiFung:
+		return core.SemiFungibleESDT, nil
+	case registerAndSetAllRolesMeta:
+		return MetaESDT, nil
+	default:
+		return "", fmt.Errorf("invalid argument: %s", arg)
+	}
+}
+
+func isRoleAllowedForTokenType(role string, tokenType string) bool {
+	switch role {
+	case core.ESDTRoleTransfer:
+		return true
+	case core.ESDTRoleNFTCreateMultiShard:
+		return tokenType != core.FungibleESDT
+	case ESDTRoleNFTRecreate, ESDTRoleNFTUpdate, ESDTRoleModifyRoyalties, ESDTRoleSetNewURI:
+		return tokenType != core.FungibleESDT
+	default:
+		return containsString(allRolesByTokenType[tokenType], role)
+	}
+}
+
+func isValidTicker(ticker []byte) bool {
+	if len(ticker) < minTickerLength || len(ticker) > maxTickerLength {
+		return false
+	}
+
+	for _, ch := range ticker {
+		isUpperCaseLetter := ch >= 'A' && ch <= 'Z'
+		isDigit := ch >= '0' && ch <= '9'
+		if !isUpperCaseLetter && !isDigit {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isValidTokenName(name []byte) bool {
+	if len(name) < minTokenNameLength || len(name) > maxTokenNameLength {
+		return false
+	}
+
+	return strings.IndexFunc(string(name), func(ch rune) bool {
+		isLetter := (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+		isDigit := ch >= '0' && ch <= '9'
+		return !isLetter && !isDigit
+	}) < 0
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsBytes(values [][]byte, value []byte) bool {
+	for _, candidate := range values {
+		if bytes.Equal(candidate, value) {
+			return true
+		}
+	}
+
+	return false
+}
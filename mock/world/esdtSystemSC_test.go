@@ -0,0 +1,195 @@
+package worldmock
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-scenario-go/esdtconvert"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+	"github.com/multiversx/mx-chain-vm-common-go/parsers"
+	"github.com/stretchr/testify/require"
+)
+
+var esdtSCTestOwner = []byte("owner___________________________")
+var esdtSCTestHolder = []byte("holder__________________________")
+
+func callESDTSystemSC(t *testing.T, world *MockWorld, caller []byte, function string, args ...[]byte) *vmcommon.VMOutput {
+	return callSystemSC(t, world, core.ESDTSCAddress, caller, big.NewInt(0), function, args...)
+}
+
+func issueTestToken(t *testing.T, world *MockWorld, properties ...[]byte) []byte {
+	args := append([][]byte{[]byte("TestToken"), []byte("TEST"), big.NewInt(1000).Bytes(), {18}}, properties...)
+	vmOutput := callESDTSystemSC(t, world, esdtSCTestOwner, "issue", args...)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+
+	transfers := vmOutput.OutputAccounts[string(esdtSCTestOwner)].OutputTransfers
+	require.Len(t, transfers, 1)
+
+	function, transferArgs, err := parsers.NewCallArgsParser().ParseData(string(transfers[0].Data))
+	require.Nil(t, err)
+	require.Equal(t, core.BuiltInFunctionESDTTransfer, function)
+	return transferArgs[0]
+}
+
+func TestESDTSystemSCMock_IssueFungible(t *testing.T) {
+	t.Parallel()
+
+	world := NewMockWorld()
+	world.RegisterESDTSystemSC()
+	world.AcctMap.CreateAccount(esdtSCTestOwner, world)
+
+	tokenIdentifier := issueTestToken(t, world)
+	require.Regexp(t, "^TEST-[0-9a-f]{6}$", string(tokenIdentifier))
+
+	balance, err := world.AcctMap.GetAccount(esdtSCTestOwner).GetTokenBalance(tokenIdentifier, 0)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(1000), balance)
+
+	token := world.ESDTSystemSC.GetTokenInfo(tokenIdentifier)
+	require.Equal(t, core.FungibleESDT, token.Type)
+	require.Equal(t, esdtSCTestOwner, token.Owner)
+	require.Equal(t, uint64(18), token.NumDecimals)
+
+	// the same random sequence in the same block
+	vmOutput := callESDTSystemSC(t, world, esdtSCTestOwner, "issue", []byte("TestToken"), []byte("TEST"), big.NewInt(1).Bytes(), []byte{0})
+	require.Equal(t, vmcommon.UserError, vmOutput.ReturnCode)
+
+	vmOutput = callESDTSystemSC(t, world, esdtSCTestOwner, "issue", []byte("TestToken"), []byte("test"), big.NewInt(1).Bytes(), []byte{0})
+	require.Equal(t, vmcommon.UserError, vmOutput.ReturnCode)
+	require.Equal(t, "ticker name is not valid", vmOutput.ReturnMessage)
+
+	vmOutput = callESDTSystemSC(t, world, esdtSCTestOwner, "issue", []byte("TestToken"), []byte("OTHER"), big.NewInt(1).Bytes())
+	require.Equal(t, vmcommon.UserError, vmOutput.ReturnCode)
+	require.Equal(t, "not enough arguments", vmOutput.ReturnMessage)
+
+	world.ESDTSystemSC.IssueCost = big.NewInt(50)
+	vmOutput = callSystemSC(t, world, core.ESDTSCAddress, esdtSCTestOwner, nil, "issue", []byte("TestToken"), []byte("OTHER"), big.NewInt(1).Bytes(), []byte{0})
+	require.Equal(t, vmcommon.UserError, vmOutput.ReturnCode)
+	require.Equal(t, "callValue not equals with baseIssuingCost", vmOutput.ReturnMessage)
+}
+
+func TestESDTSystemSCMock_RegisterAndSetRoles(t *testing.T) {
+	t.Parallel()
+
+	world := NewMockWorld()
+	world.RegisterESDTSystemSC()
+
+	vmOutput := callESDTSystemSC(t, world, esdtSCTestOwner, "registerAndSetAllRoles", []byte("Collection"), []byte("NFT"), []byte("NFT"), []byte{0})
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	require.Len(t, vmOutput.ReturnData, 1)
+	collection := vmOutput.ReturnData[0]
+
+	roles, err := esdtconvert.GetTokenRoles(collection, world.AcctMap.GetAccount(esdtSCTestOwner).Storage)
+	require.Nil(t, err)
+	require.Len(t, roles, len(allRolesByTokenType[core.NonFungibleESDT]))
+
+	vmOutput = callESDTSystemSC(t, world, esdtSCTestOwner, "setSpecialRole", collection, esdtSCTestHolder, []byte(core.ESDTRoleNFTBurn))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	vmOutput = callESDTSystemSC(t, world, esdtSCTestOwner, "setSpecialRole", collection, esdtSCTestHolder, []byte(core.ESDTRoleLocalMint))
+	require.Equal(t, vmcommon.UserError, vmOutput.ReturnCode)
+	vmOutput = callESDTSystemSC(t, world, esdtSCTestHolder, "setSpecialRole", collection, esdtSCTestHolder, []byte(core.ESDTRoleNFTCreate))
+	require.Equal(t, "can be called by owner only", vmOutput.ReturnMessage)
+
+	roles, err = esdtconvert.GetTokenRoles(collection, world.AcctMap.GetAccount(esdtSCTestHolder).Storage)
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{[]byte(core.ESDTRoleNFTBurn)}, roles)
+
+	vmOutput = callESDTSystemSC(t, world, esdtSCTestOwner, "unSetSpecialRole", collection, esdtSCTestHolder, []byte(core.ESDTRoleNFTBurn))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	roles, err = esdtconvert.GetTokenRoles(collection, world.AcctMap.GetAccount(esdtSCTestHolder).Storage)
+	require.Nil(t, err)
+	require.Empty(t, roles)
+}
+
+func TestESDTSystemSCMock_PauseFreezeWipe(t *testing.T) {
+	t.Parallel()
+
+	world := NewMockWorld()
+	world.RegisterESDTSystemSC()
+	world.AcctMap.CreateAccount(esdtSCTestOwner, world)
+	holder := world.AcctMap.CreateAccount(esdtSCTestHolder, world)
+
+	tokenIdentifier := issueTestToken(t, world, []byte("canPause"), []byte("true"), []byte("canFreeze"), []byte("true"), []byte("canWipe"), []byte("true"))
+	require.Nil(t, holder.SetTokenBalanceUint64(tokenIdentifier, 0, 50))
+
+	vmOutput := callESDTSystemSC(t, world, esdtSCTestOwner, "pause", tokenIdentifier)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	require.True(t, world.IsPaused(tokenIdentifier))
+	vmOutput = callESDTSystemSC(t, world, esdtSCTestOwner, "unPause", tokenIdentifier)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	require.False(t, world.IsPaused(tokenIdentifier))
+
+	vmOutput = callESDTSystemSC(t, world, esdtSCTestOwner, "wipe", tokenIdentifier, esdtSCTestHolder)
+	require.Equal(t, "cannot wipe because the account is not frozen for this esdt token", vmOutput.ReturnMessage)
+
+	vmOutput = callESDTSystemSC(t, world, esdtSCTestOwner, "freeze", tokenIdentifier, esdtSCTestHolder)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	tokenData, err := holder.GetTokenData(tokenIdentifier, 0, nil)
+	require.Nil(t, err)
+	require.True(t, builtInFunctions.ESDTUserMetadataFromBytes(tokenData.Properties).Frozen)
+
+	vmOutput = callESDTSystemSC(t, world, esdtSCTestOwner, "wipe", tokenIdentifier, esdtSCTestHolder)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	balance, err := holder.GetTokenBalance(tokenIdentifier, 0)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(0), balance)
+}
+
+func TestESDTSystemSCMock_TransferOwnership(t *testing.T) {
+	t.Parallel()
+
+	world := NewMockWorld()
+	world.RegisterESDTSystemSC()
+	world.AcctMap.CreateAccount(esdtSCTestOwner, world)
+
+	tokenIdentifier := issueTestToken(t, world)
+	vmOutput := callESDTSystemSC(t, world, esdtSCTestOwner, "transferOwnership", tokenIdentifier, esdtSCTestHolder)
+	require.Equal(t, "cannot change owner of the token", vmOutput.ReturnMessage)
+
+	vmOutput = callESDTSystemSC(t, world, esdtSCTestOwner, "issue", []byte("Second"), []byte("SECOND"), []byte{}, []byte{0}, []byte("canChangeOwner"), []byte("true"))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	secondToken := vmOutput.ReturnData[0]
+
+	vmOutput = callESDTSystemSC(t, world, esdtSCTestOwner, "transferOwnership", secondToken, esdtSCTestHolder)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	require.Equal(t, esdtSCTestHolder, world.ESDTSystemSC.GetTokenInfo(secondToken).Owner)
+}
+
+func TestESDTSystemSCMock_DynamicTokens(t *testing.T) {
+	t.Parallel()
+
+	world := NewMockWorld()
+	world.RegisterESDTSystemSC()
+
+	vmOutput := callESDTSystemSC(t, world, esdtSCTestOwner, "registerAndSetAllRolesDynamic", []byte("Collection"), []byte("DNFT"), []byte("NFT"))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	collection := vmOutput.ReturnData[0]
+	require.Equal(t, DynamicNFT, world.GetESDTTokenType(collection))
+
+	roles, err := esdtconvert.GetTokenRoles(collection, world.AcctMap.GetAccount(esdtSCTestOwner).Storage)
+	require.Nil(t, err)
+	require.Len(t, roles, len(allRolesByTokenType[DynamicNFT]))
+
+	vmOutput = callESDTSystemSC(t, world, esdtSCTestOwner, "registerDynamic", []byte("Collection"), []byte("FUNG"), []byte("FNG"))
+	require.Equal(t, vmcommon.UserError, vmOutput.ReturnCode)
+
+	// only the dynamic tokens accept the role to modify the creator
+	vmOutput = callESDTSystemSC(t, world, esdtSCTestOwner, "issueNonFungible", []byte("Collection"), []byte("NFT"))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	nft := vmOutput.ReturnData[0]
+	vmOutput = callESDTSystemSC(t, world, esdtSCTestOwner, "setSpecialRole", nft, esdtSCTestHolder, []byte(ESDTRoleModifyCreator))
+	require.Equal(t, vmcommon.UserError, vmOutput.ReturnCode)
+	vmOutput = callESDTSystemSC(t, world, esdtSCTestOwner, "setSpecialRole", nft, esdtSCTestHolder, []byte(ESDTRoleModifyRoyalties))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+
+	vmOutput = callESDTSystemSC(t, world, esdtSCTestOwner, "changeToDynamic", nft)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	require.Equal(t, DynamicNFT, world.GetESDTTokenType(nft))
+	vmOutput = callESDTSystemSC(t, world, esdtSCTestOwner, "setSpecialRole", nft, esdtSCTestHolder, []byte(ESDTRoleModifyCreator))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+
+	vmOutput = callESDTSystemSC(t, world, esdtSCTestOwner, "changeToDynamic", nft)
+	require.Equal(t, vmcommon.UserError, vmOutput.ReturnCode)
+}
@@ -0,0 +1,362 @@
+package worldmock
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// GovernanceSCAddress is the address of the governance system SC
+var GovernanceSCAddress = []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3, 255, 255}
+
+// DefaultProposalFee is the value locked when submitting a proposal, 1000 EGLD
+var DefaultProposalFee = big.NewInt(0).Mul(big.NewInt(1000), big.NewInt(1e18))
+
+const commitHashLength = 40
+
+const (
+	governanceLastNonceKey      = "lastProposalNonce"
+	governanceProposalKeyPrefix = "proposal_"
+)
+
+// the votes which can be cast on a proposal
+const (
+	VoteYes     = "yes"
+	VoteNo      = "no"
+	VoteAbstain = "abstain"
+	VoteVeto    = "veto"
+)
+
+// GovernanceProposal is a proposal submitted to the governance SC, with the votes cast on it.
+// The votes and the voting power used by the contracts voting on behalf of others are indexed by hex address.
+type GovernanceProposal struct {
+	Nonce          uint64
+	CommitHash     []byte
+	Issuer         []byte
+	Cost           *big.Int
+	StartVoteEpoch uint32
+	EndVoteEpoch   uint32
+	Yes            *big.Int
+	No             *big.Int
+	Abstain        *big.Int
+	Veto           *big.Int
+	Votes          map[string]string
+	DelegatedPower map[string]*big.Int
+	Closed         bool
+	Passed         bool
+}
+
+// GovernanceSCMock is a Go stand-in for the governance system SC of the metachain.
+// The proposals are kept in the storage of the governance SC account.
+type GovernanceSCMock struct {
+	World *MockWorld
+
+	ProposalFee *big.Int
+
+	// VotingPower returns the voting power of an address; when nil, the voting power is the stake
+	// of the address in the staking and delegation stand-ins registered in the world
+	VotingPower func(address []byte) *big.Int
+}
+
+// RegisterGovernanceSC installs a GovernanceSCMock in the system VM, at the address of the governance SC
+func (b *MockWorld) RegisterGovernanceSC() *GovernanceSCMock {
+	b.GovernanceSC = &GovernanceSCMock{
+		World:       b,
+		ProposalFee: big.NewInt(0).Set(DefaultProposalFee),
+	}
+	b.SystemVM().RegisterContract(GovernanceSCAddress, b.GovernanceSC)
+	return b.GovernanceSC
+}
+
+// GetProposal returns the proposal with the given nonce, or nil if there is no such proposal
+func (sc *GovernanceSCMock) GetProposal(nonce uint64) *GovernanceProposal {
+	proposal := &GovernanceProposal{}
+	found, err := sc.World.loadSystemSCState(GovernanceSCAddress, makeProposalKey(nonce), proposal)
+	if !found || err != nil {
+		return nil
+	}
+
+	return proposal
+}
+
+// GetVotingPower returns the voting power of an address
+func (sc *GovernanceSCMock) GetVotingPower(address []byte) *big.Int {
+	if sc.VotingPower != nil {
+		return sc.VotingPower(address)
+	}
+
+	votingPower := big.NewInt(0)
+	if sc.World.StakingSC != nil {
+		votingPower.Add(votingPower, sc.World.StakingSC.GetTotalStaked(address))
+	}
+	if sc.World.DelegationManagerSC != nil {
+		delegation := sc.World.DelegationManagerSC.Delegation
+		for _, contractAddress := range sc.World.DelegationManagerSC.GetContractAddresses() {
+			votingPower.Add(votingPower, delegation.GetUserActiveStake(contractAddress, address))
+		}
+	}
+
+	return votingPower
+}
+
+// ExecuteCall runs a call on the governance SC; the errors of the call are returned as user errors in the VMOutput
+func (sc *GovernanceSCMock) ExecuteCall(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	return sc.World.executeSystemSCFunction(input, sc.functions()), nil
+}
+
+func (sc *GovernanceSCMock) functions() map[string]systemSCFunction {
+	return map[string]systemSCFunction{
+		"proposal":      sc.proposal,
+		"vote":          sc.vote,
+		"delegateVote":  sc.delegateVote,
+		"closeProposal": sc.closeProposal,
+		"viewProposal":  sc.viewProposal,
+	}
+}
+
+// proposal@commitHash@startVoteEpoch@endVoteEpoch, with the proposal fee as call value; returns the nonce of the proposal
+func (sc *GovernanceSCMock) proposal(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+	if len(input.Arguments) != 3 {
+		return fmt.Errorf("invalid number of arguments, expected 3")
+	}
+	if input.CallValue.Cmp(sc.ProposalFee) != 0 {
+		return fmt.Errorf("invalid proposal cost, expected %s", sc.ProposalFee)
+	}
+	if len(input.Arguments[0]) != commitHashLength {
+		return fmt.Errorf("invalid github commit length")
+	}
+
+	startVoteEpoch := uint32(big.NewInt(0).SetBytes(input.Arguments[1]).Uint64())
+	endVoteEpoch := uint32(big.NewInt(0).SetBytes(input.Arguments[2]).Uint64())
+	if startVoteEpoch < sc.World.CurrentEpoch() || endVoteEpoch < startVoteEpoch {
+		return fmt.Errorf("invalid starting/ending epoch")
+	}
+
+	nonce := sc.getLastNonce() + 1
+	proposal := &GovernanceProposal{
+		Nonce:          nonce,
+		CommitHash:     input.Arguments[0],
+		Issuer:         input.CallerAddr,
+		Cost:           big.NewInt(0).Set(input.CallValue),
+		StartVoteEpoch: startVoteEpoch,
+		EndVoteEpoch:   endVoteEpoch,
+		Yes:            big.NewInt(0),
+		No:             big.NewInt(0),
+		Abstain:        big.NewInt(0),
+		Veto:           big.NewInt(0),
+		Votes:          make(map[string]string),
+		DelegatedPower: make(map[string]*big.Int),
+	}
+	err := saveProposal(output, proposal)
+	if err != nil {
+		return err
+	}
+	err = saveSystemSCState(output, GovernanceSCAddress, governanceLastNonceKey, nonce)
+	if err != nil {
+		return err
+	}
+
+	output.ReturnData = [][]byte{big.NewInt(0).SetUint64(nonce).Bytes()}
+	return nil
+}
+
+// vote@nonce@vote, with the whole voting power of the caller
+func (sc *GovernanceSCMock) vote(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+	err := checkNotPayable(input)
+	if err != nil {
+		return err
+	}
+	if len(input.Arguments) != 2 {
+		return fmt.Errorf("invalid number of arguments, expected 2")
+	}
+
+	proposal, err := sc.getProposalInVotingPeriod(input.Arguments[0])
+	if err != nil {
+		return err
+	}
+
+	votingPower := sc.GetVotingPower(input.CallerAddr)
+	if votingPower.Sign() == 0 {
+		return fmt.Errorf("not enough voting power to cast a vote")
+	}
+
+	err = proposal.addVote(input.CallerAddr, string(input.Arguments[1]), votingPower)
+	if err != nil {
+		return err
+	}
+
+	return saveProposal(output, proposal)
+}
+
+// delegateVote@nonce@vote@voter@votingPower is called by the contracts which vote on behalf of their users,
+// with a part of their own voting power
+func (sc *GovernanceSCMock) delegateVote(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+	err := checkNotPayable(input)
+	if err != nil {
+		return err
+	}
+	if len(input.Arguments) != 4 {
+		return fmt.Errorf("invalid number of arguments, expected 4")
+	}
+
+	proposal, err := sc.getProposalInVotingPeriod(input.Arguments[0])
+	if err != nil {
+		return err
+	}
+
+	votingPower := big.NewInt(0).SetBytes(input.Arguments[3])
+	usedPower, found := proposal.DelegatedPower[hex.EncodeToString(input.CallerAddr)]
+	if !found {
+		usedPower = big.NewInt(0)
+	}
+	totalUsedPower := big.NewInt(0).Add(usedPower, votingPower)
+	if votingPower.Sign() == 0 || totalUsedPower.Cmp(sc.GetVotingPower(input.CallerAddr)) > 0 {
+		return fmt.Errorf("not enough voting power to cast a vote")
+	}
+
+	err = proposal.addVote(input.Arguments[2], string(input.Arguments[1]), votingPower)
+	if err != nil {
+		return err
+	}
+	proposal.DelegatedPower[hex.EncodeToString(input.CallerAddr)] = totalUsedPower
+
+	return saveProposal(output, proposal)
+}
+
+// closeProposal@nonce, by the issuer, after the voting period; the fee is paid back unless the proposal was vetoed
+func (sc *GovernanceSCMock) closeProposal(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+	err := checkNotPayable(input)
+	if err != nil {
+		return err
+	}
+	if len(input.Arguments) != 1 {
+		return fmt.Errorf("invalid number of arguments, expected 1")
+	}
+
+	proposal, err := sc.getProposal(input.Arguments[0])
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(proposal.Issuer, input.CallerAddr) {
+		return fmt.Errorf("only the issuer can close the proposal")
+	}
+	if proposal.Closed {
+		return fmt.Errorf("proposal is already closed")
+	}
+	if sc.World.CurrentEpoch() <= proposal.EndVoteEpoch {
+		return fmt.Errorf("proposal can be closed only after the voting period")
+	}
+
+	proposal.Closed = true
+	proposal.Passed = proposal.Yes.Cmp(proposal.No) > 0 && !proposal.isVetoed()
+	if !proposal.isVetoed() {
+		sendValue(output, GovernanceSCAddress, proposal.Issuer, proposal.Cost)
+	}
+
+	return saveProposal(output, proposal)
+}
+
+// viewProposal@nonce returns cost, commitHash, nonce, issuer, startVoteEpoch, endVoteEpoch, yes, no, veto, abstain, closed, passed
+func (sc *GovernanceSCMock) viewProposal(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+	err := checkNotPayable(input)
+	if err != nil {
+		return err
+	}
+	if len(input.Arguments) != 1 {
+		return fmt.Errorf("invalid number of arguments, expected 1")
+	}
+
+	proposal, err := sc.getProposal(input.Arguments[0])
+	if err != nil {
+		return err
+	}
+
+	output.ReturnData = [][]byte{
+		proposal.Cost.Bytes(),
+		proposal.CommitHash,
+		big.NewInt(0).SetUint64(proposal.Nonce).Bytes(),
+		proposal.Issuer,
+		big.NewInt(int64(proposal.StartVoteEpoch)).Bytes(),
+		big.NewInt(int64(proposal.EndVoteEpoch)).Bytes(),
+		proposal.Yes.Bytes(),
+		proposal.No.Bytes(),
+		proposal.Veto.Bytes(),
+		proposal.Abstain.Bytes(),
+		[]byte(fmt.Sprint(proposal.Closed)),
+		[]byte(fmt.Sprint(proposal.Passed)),
+	}
+	return nil
+}
+
+func (sc *GovernanceSCMock) getProposal(nonceArgument []byte) (*GovernanceProposal, error) {
+	proposal := sc.GetProposal(big.NewInt(0).SetBytes(nonceArgument).Uint64())
+	if proposal == nil {
+		return nil, fmt.Errorf("proposal does not exist")
+	}
+
+	return proposal, nil
+}
+
+func (sc *GovernanceSCMock) getProposalInVotingPeriod(nonceArgument []byte) (*GovernanceProposal, error) {
+	proposal, err := sc.getProposal(nonceArgument)
+	if err != nil {
+		return nil, err
+	}
+
+	currentEpoch := sc.World.CurrentEpoch()
+	if proposal.Closed || currentEpoch < proposal.StartVoteEpoch || currentEpoch > proposal.EndVoteEpoch {
+		return nil, fmt.Errorf("proposal is not in the voting period")
+	}
+
+	return proposal, nil
+}
+
+func (sc *GovernanceSCMock) getLastNonce() uint64 {
+	lastNonce := uint64(0)
+	_, _ = sc.World.loadSystemSCState(GovernanceSCAddress, governanceLastNonceKey, &lastNonce)
+	return lastNonce
+}
+
+func saveProposal(output *vmcommon.VMOutput, proposal *GovernanceProposal) error {
+	return saveSystemSCState(output, GovernanceSCAddress, makeProposalKey(proposal.Nonce), proposal)
+}
+
+func (proposal *GovernanceProposal) addVote(voter []byte, vote string, votingPower *big.Int) error {
+	voterKey := hex.EncodeToString(voter)
+	if _, voted := proposal.Votes[voterKey]; voted {
+		return fmt.Errorf("double vote is not allowed")
+	}
+
+	switch vote {
+	case VoteYes:
+		proposal.Yes.Add(proposal.Yes, votingPower)
+	case VoteNo:
+		proposal.No.Add(proposal.No, votingPower)
+	case VoteAbstain:
+		proposal.Abstain.Add(proposal.Abstain, votingPower)
+	case VoteVeto:
+		proposal.Veto.Add(proposal.Veto, votingPower)
+	default:
+		return fmt.Errorf("invalid vote type option: %s", vote)
+	}
+
+	proposal.Votes[voterKey] = vote
+	return nil
+}
+
+// isVetoed returns true if at least a third of the voting power cast was veto
+func (proposal *GovernanceProposal) isVetoed() bool {
+	total := big.NewInt(0).Add(proposal.Yes, proposal.No)
+	total.Add(total, proposal.Abstain)
+	total.Add(total, proposal.Veto)
+
+	vetoTimesThree := big.NewInt(0).Mul(proposal.Veto, big.NewInt(3))
+	return proposal.Veto.Sign() > 0 && vetoTimesThree.Cmp(total) >= 0
+}
+
+func makeProposalKey(nonce uint64) string {
+	return fmt.Sprintf("%s%d", governanceProposalKeyPrefix, nonce)
+}
@@ -0,0 +1,229 @@
+package worldmock
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/guardians"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+var _ vmcommon.GuardedAccountHandler = (*GuardianHandler)(nil)
+
+// GuardiansAddress is the reserved address used by scenarios to set the guardians of the accounts.
+// Its storage is interpreted as settings instead of being saved as an account.
+// It is the value of the scenario expression "address:guardians".
+var GuardiansAddress = []byte("guardians_______________________")
+
+// TxGuardianAddress is the reserved address used by scenarios to have the transactions of a sender co-signed
+// by a guardian. Its storage is interpreted as settings instead of being saved as an account.
+// It is the value of the scenario expression "address:tx-guardian".
+var TxGuardianAddress = []byte("tx-guardian_____________________")
+
+// DefaultGuardianActivationEpochs is the number of epochs after which a guardian set without
+// the co-signature of the active guardian becomes active
+const DefaultGuardianActivationEpochs = 20
+
+// GuardiansKey is the protected storage key where the guardians of an account are kept
+var GuardiansKey = []byte(core.ProtectedKeyPrefix + core.GuardiansKeyIdentifier)
+
+// ErrNoGuardianSet signals that the account has no guardian, active or pending
+var ErrNoGuardianSet = errors.New("account has no guardian set")
+
+// ErrNoActiveGuardian signals that the guardians of the account are not active yet
+var ErrNoActiveGuardian = errors.New("account has no active guardian")
+
+// ErrGuardianMismatch signals that a transaction was co-signed by another guardian than the active one
+var ErrGuardianMismatch = errors.New("transaction guardian does not match the active guardian of the account")
+
+// GuardianHandler keeps the guardians of the accounts in their storage and activates them with an epoch delay,
+// the same way the node does. A guardian set with the co-signature of the active guardian is activated right away
+// and replaces all the other guardians.
+type GuardianHandler struct {
+	World            *MockWorld
+	ActivationEpochs uint32
+}
+
+// NewGuardianHandler creates a GuardianHandler reading the current epoch from the world
+func NewGuardianHandler(world *MockWorld) *GuardianHandler {
+	return &GuardianHandler{
+		World:            world,
+		ActivationEpochs: DefaultGuardianActivationEpochs,
+	}
+}
+
+// GetActiveGuardian returns the address of the guardian active in the current epoch
+func (gh *GuardianHandler) GetActiveGuardian(uah vmcommon.UserAccountHandler) ([]byte, error) {
+	configured, err := gh.getGuardians(uah)
+	if err != nil {
+		return nil, err
+	}
+	if len(configured.Slice) == 0 {
+		return nil, ErrNoGuardianSet
+	}
+
+	active := gh.activeGuardian(configured)
+	if active == nil {
+		return nil, ErrNoActiveGuardian
+	}
+
+	return active.Address, nil
+}
+
+// SetGuardian adds a guardian to the account, which becomes active after the activation delay, replacing any
+// pending guardian. When txGuardian is set, it must be the active guardian, and the new guardian is active right away.
+func (gh *GuardianHandler) SetGuardian(uah vmcommon.UserAccountHandler, guardianAddress []byte, txGuardianAddress []byte, guardianServiceUID []byte) error {
+	configured, err := gh.getGuardians(uah)
+	if err != nil {
+		return err
+	}
+
+	currentEpoch := gh.World.CurrentEpoch()
+	active := gh.activeGuardian(configured)
+	if len(txGuardianAddress) > 0 {
+		if active == nil || !bytes.Equal(active.Address, txGuardianAddress) {
+			return ErrGuardianMismatch
+		}
+
+		return gh.saveGuardians(uah, &guardians.Guardians{
+			Slice: []*guardians.Guardian{newGuardian(guardianAddress, currentEpoch, guardianServiceUID)},
+		})
+	}
+
+	updated := &guardians.Guardians{Slice: make([]*guardians.Guardian, 0, 2)}
+	if active != nil {
+		updated.Slice = append(updated.Slice, active)
+	}
+	updated.Slice = append(updated.Slice, newGuardian(guardianAddress, currentEpoch+gh.ActivationEpochs, guardianServiceUID))
+
+	return gh.saveGuardians(uah, updated)
+}
+
+// CleanOtherThanActive removes the pending guardians of the account
+func (gh *GuardianHandler) CleanOtherThanActive(uah vmcommon.UserAccountHandler) {
+	configured, err := gh.getGuardians(uah)
+	if err != nil {
+		return
+	}
+
+	cleaned := &guardians.Guardians{Slice: make([]*guardians.Guardian, 0, 1)}
+	active := gh.activeGuardian(configured)
+	if active != nil {
+		cleaned.Slice = append(cleaned.Slice, active)
+	}
+
+	_ = gh.saveGuardians(uah, cleaned)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (gh *GuardianHandler) IsInterfaceNil() bool {
+	return gh == nil
+}
+
+// activeGuardian returns the most recently activated guardian, or nil if none is active yet
+func (gh *GuardianHandler) activeGuardian(configured *guardians.Guardians) *guardians.Guardian {
+	currentEpoch := gh.World.CurrentEpoch()
+
+	var active *guardians.Guardian
+	for _, guardian := range configured.Slice {
+		if guardian.ActivationEpoch > currentEpoch {
+			continue
+		}
+		if active == nil || guardian.ActivationEpoch >= active.ActivationEpoch {
+			active = guardian
+		}
+	}
+
+	return active
+}
+
+func (gh *GuardianHandler) getGuardians(uah vmcommon.UserAccountHandler) (*guardians.Guardians, error) {
+	configured := &guardians.Guardians{}
+	data, _, err := uah.AccountDataHandler().RetrieveValue(GuardiansKey)
+	if err != nil || len(data) == 0 {
+		return configured, err
+	}
+
+	err = WorldMarshalizer.Unmarshal(configured, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return configured, nil
+}
+
+func (gh *GuardianHandler) saveGuardians(uah vmcommon.UserAccountHandler, configured *guardians.Guardians) error {
+	data, err := WorldMarshalizer.Marshal(configured)
+	if err != nil {
+		return err
+	}
+
+	return uah.AccountDataHandler().SaveKeyValue(GuardiansKey, data)
+}
+
+func newGuardian(address []byte, activationEpoch uint32, serviceUID []byte) *guardians.Guardian {
+	return &guardians.Guardian{
+		Address:         address,
+		ActivationEpoch: activationEpoch,
+		ServiceUID:      serviceUID,
+	}
+}
+
+// SetActiveGuardian sets the guardian of an account, active from the current epoch, and marks the account
+// as guarded, skipping the SetGuardian and GuardAccount transactions; an empty guardian unguards the account.
+func (b *MockWorld) SetActiveGuardian(address []byte, guardianAddress []byte, serviceUID []byte) error {
+	account := b.AcctMap.GetAccount(address)
+	if account == nil {
+		return ErrInvalidAccount
+	}
+
+	codeMetadata := vmcommon.CodeMetadataFromBytes(account.CodeMetadata)
+	codeMetadata.Guarded = len(guardianAddress) > 0
+
+	configured := &guardians.Guardians{}
+	if codeMetadata.Guarded {
+		configured.Slice = []*guardians.Guardian{newGuardian(guardianAddress, b.CurrentEpoch(), serviceUID)}
+	}
+
+	data, err := WorldMarshalizer.Marshal(configured)
+	if err != nil {
+		return err
+	}
+	if len(configured.Slice) == 0 {
+		data = []byte{}
+	}
+
+	account.SetStorageValue(string(GuardiansKey), data)
+	account.CodeMetadata = codeMetadata.ToBytes()
+	return nil
+}
+
+// IsGuarded returns true if the account exists and is marked as guarded
+func (b *MockWorld) IsGuarded(address []byte) bool {
+	account := b.AcctMap.GetAccount(address)
+	if account == nil {
+		return false
+	}
+
+	return vmcommon.CodeMetadataFromBytes(account.CodeMetadata).Guarded
+}
+
+// SetTxGuardian makes the guardian co-sign all the following transactions of the sender;
+// an empty guardian removes the co-signature.
+func (b *MockWorld) SetTxGuardian(sender []byte, guardianAddress []byte) {
+	if len(guardianAddress) == 0 {
+		delete(b.TxGuardians, string(sender))
+		return
+	}
+
+	if b.TxGuardians == nil {
+		b.TxGuardians = make(map[string][]byte)
+	}
+	b.TxGuardians[string(sender)] = guardianAddress
+}
+
+// GetTxGuardian returns the guardian co-signing the transactions of the sender, or nil
+func (b *MockWorld) GetTxGuardian(sender []byte) []byte {
+	return b.TxGuardians[string(sender)]
+}
@@ -0,0 +1,116 @@
+package worldmock
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-go/config"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	guardedTestOwner     = []byte("guarded-owner___________________")
+	guardianTestFirst    = []byte("guardian-first__________________")
+	guardianTestSecond   = []byte("guardian-second_________________")
+	guardianTestService  = []byte("service")
+	guardianTestGasLimit = uint64(10_000_000)
+)
+
+func callGuardianBuiltin(world *MockWorld, function string, txGuardian []byte, args ...[]byte) error {
+	_, err := world.BuiltinFuncs.ProcessBuiltInFunction(&vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  guardedTestOwner,
+			CallValue:   big.NewInt(0),
+			GasProvided: guardianTestGasLimit,
+			Arguments:   args,
+			TxGuardian:  txGuardian,
+		},
+		RecipientAddr: guardedTestOwner,
+		Function:      function,
+	})
+	return err
+}
+
+func TestMockWorld_GuardianLifecycle(t *testing.T) {
+	t.Parallel()
+
+	require.Len(t, GuardiansAddress, 32)
+	require.Len(t, TxGuardianAddress, 32)
+
+	world := NewMockWorld()
+	err := world.InitBuiltinFunctions(config.MakeGasMapForTests())
+	require.Nil(t, err)
+	account := world.AcctMap.CreateAccount(guardedTestOwner, world)
+	handler := world.GuardedAccountHandler.(*GuardianHandler)
+	handler.ActivationEpochs = 10
+
+	setEpoch(world, 1)
+	_, err = handler.GetActiveGuardian(account)
+	require.Equal(t, ErrNoGuardianSet, err)
+
+	err = callGuardianBuiltin(world, core.BuiltInFunctionSetGuardian, nil, guardianTestFirst, guardianTestService)
+	require.Nil(t, err)
+	_, err = handler.GetActiveGuardian(account)
+	require.Equal(t, ErrNoActiveGuardian, err)
+	err = callGuardianBuiltin(world, core.BuiltInFunctionGuardAccount, nil)
+	require.NotNil(t, err)
+
+	setEpoch(world, 11)
+	activeGuardian, err := handler.GetActiveGuardian(account)
+	require.Nil(t, err)
+	require.Equal(t, guardianTestFirst, activeGuardian)
+	err = callGuardianBuiltin(world, core.BuiltInFunctionGuardAccount, nil)
+	require.Nil(t, err)
+	require.True(t, world.IsGuarded(guardedTestOwner))
+
+	// without the co-signature, the new guardian waits for the activation delay
+	err = callGuardianBuiltin(world, core.BuiltInFunctionSetGuardian, nil, guardianTestSecond, guardianTestService)
+	require.Nil(t, err)
+	activeGuardian, err = handler.GetActiveGuardian(account)
+	require.Nil(t, err)
+	require.Equal(t, guardianTestFirst, activeGuardian)
+
+	// with the co-signature of the active guardian, it is active right away
+	err = callGuardianBuiltin(world, core.BuiltInFunctionSetGuardian, guardianTestSecond, guardianTestSecond, guardianTestService)
+	require.Equal(t, ErrGuardianMismatch, err)
+	err = callGuardianBuiltin(world, core.BuiltInFunctionSetGuardian, guardianTestFirst, guardianTestSecond, guardianTestService)
+	require.Nil(t, err)
+	activeGuardian, err = handler.GetActiveGuardian(account)
+	require.Nil(t, err)
+	require.Equal(t, guardianTestSecond, activeGuardian)
+
+	err = callGuardianBuiltin(world, core.BuiltInFunctionUnGuardAccount, nil)
+	require.Nil(t, err)
+	require.False(t, world.IsGuarded(guardedTestOwner))
+}
+
+func TestMockWorld_SetActiveGuardian(t *testing.T) {
+	t.Parallel()
+
+	world := NewMockWorld()
+	account := world.AcctMap.CreateAccount(guardedTestOwner, world)
+	setEpoch(world, 3)
+
+	err := world.SetActiveGuardian([]byte("missing-account_________________"), guardianTestFirst, nil)
+	require.Equal(t, ErrInvalidAccount, err)
+
+	err = world.SetActiveGuardian(guardedTestOwner, guardianTestFirst, guardianTestService)
+	require.Nil(t, err)
+	require.True(t, world.IsGuarded(guardedTestOwner))
+	activeGuardian, err := world.GuardedAccountHandler.GetActiveGuardian(account)
+	require.Nil(t, err)
+	require.Equal(t, guardianTestFirst, activeGuardian)
+
+	err = world.SetActiveGuardian(guardedTestOwner, nil, nil)
+	require.Nil(t, err)
+	require.False(t, world.IsGuarded(guardedTestOwner))
+	_, err = world.GuardedAccountHandler.GetActiveGuardian(account)
+	require.Equal(t, ErrNoGuardianSet, err)
+
+	world.SetTxGuardian(guardedTestOwner, guardianTestFirst)
+	require.Equal(t, guardianTestFirst, world.GetTxGuardian(guardedTestOwner))
+	world.SetTxGuardian(guardedTestOwner, nil)
+	require.Nil(t, world.GetTxGuardian(guardedTestOwner))
+}
@@ -32,11 +32,7 @@ func (bf *BuiltinFunctionsWrapper) GetTokenData(address []byte, tokenIdentifier
 			Value: big.NewInt(0),
 		}, nil
 	}
-	systemAccStorage := make(map[string][]byte)
-	systemAcc := bf.World.AcctMap.GetAccount(vmcommon.SystemAccountAddress)
-	if systemAcc != nil {
-		systemAccStorage = systemAcc.Storage
-	}
+	systemAccStorage := bf.World.AcctMap.GetSystemAccountTokenMetadata()
 	return account.GetTokenData(tokenIdentifier, nonce, systemAccStorage)
 }
 
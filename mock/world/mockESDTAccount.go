@@ -26,13 +26,24 @@ func (a *Account) GetTokenBalanceUint64(tokenIdentifier []byte, nonce uint64) (u
 // SetTokenBalance sets the ESDT balance of the account, specified by the token
 // key.
 func (a *Account) SetTokenBalance(tokenIdentifier []byte, nonce uint64, balance *big.Int) error {
-	return esdtconvert.SetTokenBalance(tokenIdentifier, nonce, balance, a.Storage)
+	tokenData, err := a.GetTokenData(tokenIdentifier, nonce, make(map[string][]byte))
+	if err != nil {
+		return err
+	}
+
+	return a.writeStorage(func(destination map[string][]byte) error {
+		err := esdtconvert.SetTokenData(tokenIdentifier, nonce, tokenData, destination)
+		if err != nil {
+			return err
+		}
+		return esdtconvert.SetTokenBalance(tokenIdentifier, nonce, balance, destination)
+	})
 }
 
 // SetTokenBalanceUint64 sets the ESDT balance of the account, specified by the
 // token key.
 func (a *Account) SetTokenBalanceUint64(tokenIdentifier []byte, nonce uint64, balance uint64) error {
-	return esdtconvert.SetTokenBalance(tokenIdentifier, nonce, big.NewInt(0).SetUint64(balance), a.Storage)
+	return a.SetTokenBalance(tokenIdentifier, nonce, big.NewInt(0).SetUint64(balance))
 }
 
 // GetTokenData gets the ESDT information related to a token from the storage of the account.
@@ -42,10 +53,29 @@ func (a *Account) GetTokenData(tokenIdentifier []byte, nonce uint64, systemAccSt
 
 // SetTokenData sets the ESDT information related to a token into the storage of the account.
 func (a *Account) SetTokenData(tokenIdentifier []byte, nonce uint64, tokenData *esdt.ESDigitalToken) error {
-	return esdtconvert.SetTokenData(tokenIdentifier, nonce, tokenData, a.Storage)
+	return a.writeStorage(func(destination map[string][]byte) error {
+		return esdtconvert.SetTokenData(tokenIdentifier, nonce, tokenData, destination)
+	})
 }
 
 // SetTokenRolesAsStrings sets the specified roles to the account, corresponding to the given tokenName.
 func (a *Account) SetTokenRolesAsStrings(tokenIdentifier []byte, rolesAsStrings []string) error {
-	return esdtconvert.SetTokenRolesAsStrings(tokenIdentifier, rolesAsStrings, a.Storage)
+	return a.writeStorage(func(destination map[string][]byte) error {
+		return esdtconvert.SetTokenRolesAsStrings(tokenIdentifier, rolesAsStrings, destination)
+	})
+}
+
+// writeStorage runs a setter of esdtconvert on an empty storage, then writes the values it set into the storage
+// of the account, so that the simulated data trie follows them.
+func (a *Account) writeStorage(write func(destination map[string][]byte) error) error {
+	written := make(map[string][]byte)
+	err := write(written)
+	if err != nil {
+		return err
+	}
+
+	for key, value := range written {
+		a.SetStorageValue(key, value)
+	}
+	return nil
 }
@@ -0,0 +1,539 @@
+package worldmock
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	"github.com/multiversx/mx-chain-scenario-go/esdtconvert"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+	"github.com/multiversx/mx-chain-vm-go/config"
+)
+
+// the builtin functions which change the metadata of an NFT after its creation,
+// which the builtin functions container does not provide yet
+const (
+	BuiltInFunctionESDTMetaDataRecreate = "ESDTMetaDataRecreate"
+	BuiltInFunctionESDTMetaDataUpdate   = "ESDTMetaDataUpdate"
+	BuiltInFunctionESDTModifyRoyalties  = "ESDTModifyRoyalties"
+	BuiltInFunctionESDTSetNewURIs       = "ESDTSetNewURIs"
+	BuiltInFunctionESDTModifyCreator    = "ESDTModifyCreator"
+)
+
+// the roles allowing the metadata changes
+const (
+	ESDTRoleNFTRecreate     = "ESDTRoleNFTRecreate"
+	ESDTRoleNFTUpdate       = "ESDTRoleNFTUpdate"
+	ESDTRoleModifyRoyalties = "ESDTRoleModifyRoyalties"
+	ESDTRoleSetNewURI       = "ESDTRoleSetNewURI"
+	ESDTRoleModifyCreator   = "ESDTRoleModifyCreator"
+)
+
+// the types of the dynamic tokens, whose instances can get a new creator
+const (
+	DynamicNFT  = "DynamicNonFungibleESDT"
+	DynamicSFT  = "DynamicSemiFungibleESDT"
+	DynamicMeta = "DynamicMetaESDT"
+)
+
+// maxNFTRoyalties is the royalties of 100%, in hundredths of a percent
+const maxNFTRoyalties = 10000
+
+// metaDataVersionKeyPrefix is the prefix of the keys under which the system account keeps the metadata versions,
+// JSON encoded. This layout is specific to the mock and diverges from the protocol, which marshals the versions
+// as an esdt.MetaDataVersion into the Reserved field of the ESDT data of the NFT in the system account; the
+// mx-chain-core-go version used here does not define that type yet.
+// Scenarios read and set the versions only through the NFT metadata account, never through this key.
+var metaDataVersionKeyPrefix = []byte(core.ProtectedKeyPrefix + "metadataversion")
+
+// ErrInvalidRoyalties signals that the royalties are over 100%
+var ErrInvalidRoyalties = errors.New("invalid royalties")
+
+// ErrTokenIsNotDynamic signals a change allowed only for the instances of dynamic tokens
+var ErrTokenIsNotDynamic = errors.New("token is not dynamic")
+
+// ErrUnknownTokenType signals a token type which is neither a core ESDT type nor a dynamic one
+var ErrUnknownTokenType = errors.New("unknown token type")
+
+// ESDTMetaDataVersion holds the round of the last change of each field of the metadata of an NFT.
+// The fields not changed since the NFT was created have version 0.
+type ESDTMetaDataVersion struct {
+	Name       uint64
+	Creator    uint64
+	Royalties  uint64
+	Hash       uint64
+	URIs       uint64
+	Attributes uint64
+}
+
+// IsDynamicESDTType returns true for the types of the dynamic tokens
+func IsDynamicESDTType(tokenType string) bool {
+	return tokenType == DynamicNFT || tokenType == DynamicSFT || tokenType == DynamicMeta
+}
+
+func isKnownESDTType(tokenType string) bool {
+	switch tokenType {
+	case core.FungibleESDT, core.NonFungibleESDT, core.SemiFungibleESDT, MetaESDT:
+		return true
+	default:
+		return IsDynamicESDTType(tokenType)
+	}
+}
+
+// GetESDTTokenType returns the type of a token as known by the ESDT system SC, or an empty string for unknown tokens.
+func (b *MockWorld) GetESDTTokenType(tokenIdentifier []byte) string {
+	token := &ESDTTokenInfo{}
+	found, err := b.loadSystemSCState(core.ESDTSCAddress, string(tokenIdentifier), token)
+	if !found || err != nil {
+		return ""
+	}
+
+	return token.Type
+}
+
+// SetESDTTokenType changes the type of a token in the ESDT system SC, registering the token if it is not known yet.
+func (b *MockWorld) SetESDTTokenType(tokenIdentifier []byte, tokenType string) error {
+	if !isKnownESDTType(tokenType) {
+		return fmt.Errorf("%w \"%s\"", ErrUnknownTokenType, tokenType)
+	}
+
+	token := &ESDTTokenInfo{}
+	found, err := b.loadSystemSCState(core.ESDTSCAddress, string(tokenIdentifier), token)
+	if err != nil {
+		return err
+	}
+	if !found {
+		token = &ESDTTokenInfo{
+			Identifier: string(tokenIdentifier),
+			Properties: make(map[string]bool),
+		}
+	}
+
+	token.Type = tokenType
+	return b.applySystemSCChanges(func(output *vmcommon.VMOutput) error {
+		return saveToken(output, token)
+	})
+}
+
+// GetESDTMetaDataVersion returns the versions of the metadata fields of an NFT, as kept in the system account.
+func (b *MockWorld) GetESDTMetaDataVersion(tokenIdentifier []byte, nonce uint64) (ESDTMetaDataVersion, error) {
+	version := ESDTMetaDataVersion{}
+	systemAccount := b.AcctMap.GetAccount(vmcommon.SystemAccountAddress)
+	if systemAccount == nil {
+		return version, nil
+	}
+
+	serializedVersion := systemAccount.Storage[string(makeMetaDataVersionKey(tokenIdentifier, nonce))]
+	if len(serializedVersion) == 0 {
+		return version, nil
+	}
+
+	err := json.Unmarshal(serializedVersion, &version)
+	return version, err
+}
+
+// SetESDTMetaDataVersion saves the versions of the metadata fields of an NFT in the system account,
+// creating the system account if it does not exist yet.
+func (b *MockWorld) SetESDTMetaDataVersion(tokenIdentifier []byte, nonce uint64, version ESDTMetaDataVersion) error {
+	systemAccount := b.AcctMap.GetAccount(vmcommon.SystemAccountAddress)
+	if systemAccount == nil {
+		systemAccount = b.AcctMap.CreateAccount(vmcommon.SystemAccountAddress, b)
+	}
+
+	key := string(makeMetaDataVersionKey(tokenIdentifier, nonce))
+	if version == (ESDTMetaDataVersion{}) {
+		delete(systemAccount.Storage, key)
+		systemAccount.updateDataTrie(key, nil)
+		return nil
+	}
+
+	serializedVersion, err := json.Marshal(version)
+	if err != nil {
+		return err
+	}
+
+	systemAccount.SetStorageValue(key, serializedVersion)
+	return nil
+}
+
+// GetNFTMetaData returns the metadata of an NFT held by an account, taking it from
+// the system account when the protocol keeps it there.
+func (b *MockWorld) GetNFTMetaData(holder []byte, tokenIdentifier []byte, nonce uint64) (*esdt.MetaData, error) {
+	account := b.AcctMap.GetAccount(holder)
+	if account == nil {
+		return nil, ErrInvalidAccount
+	}
+
+	tokenData, err := account.GetTokenData(tokenIdentifier, nonce, b.AcctMap.GetSystemAccountTokenMetadata())
+	if err != nil {
+		return nil, err
+	}
+	if nonce == 0 || tokenData.TokenMetaData == nil {
+		return nil, builtInFunctions.ErrNFTDoesNotHaveMetadata
+	}
+
+	return tokenData.TokenMetaData, nil
+}
+
+// SetNFTMetaData replaces the metadata of an NFT held by an account. The metadata is changed where
+// the protocol keeps it: in the system account, for all the holders, or otherwise in the account of the holder.
+func (b *MockWorld) SetNFTMetaData(holder []byte, tokenIdentifier []byte, nonce uint64, metaData *esdt.MetaData) error {
+	_, err := b.GetNFTMetaData(holder, tokenIdentifier, nonce)
+	if err != nil {
+		return err
+	}
+
+	metaData.Nonce = nonce
+	systemAccount := b.AcctMap.GetAccount(vmcommon.SystemAccountAddress)
+	if systemAccount != nil {
+		systemTokenData, errGet := esdtconvert.GetTokenData(tokenIdentifier, nonce, systemAccount.Storage, make(map[string][]byte))
+		if errGet != nil {
+			return errGet
+		}
+		if systemTokenData.TokenMetaData != nil {
+			systemTokenData.TokenMetaData = metaData
+			return systemAccount.SetTokenData(tokenIdentifier, nonce, systemTokenData)
+		}
+	}
+
+	account := b.AcctMap.GetAccount(holder)
+	tokenData, err := esdtconvert.GetTokenData(tokenIdentifier, nonce, account.Storage, make(map[string][]byte))
+	if err != nil {
+		return err
+	}
+
+	tokenData.TokenMetaData = metaData
+	return account.SetTokenData(tokenIdentifier, nonce, tokenData)
+}
+
+// increaseMetaDataVersion sets the current round as the version of the fields chosen by the given function
+func (b *MockWorld) increaseMetaDataVersion(tokenIdentifier []byte, nonce uint64, changedFields func(version *ESDTMetaDataVersion, round uint64)) error {
+	version, err := b.GetESDTMetaDataVersion(tokenIdentifier, nonce)
+	if err != nil {
+		return err
+	}
+
+	changedFields(&version, b.CurrentRound())
+	return b.SetESDTMetaDataVersion(tokenIdentifier, nonce, version)
+}
+
+// updateMetaDataVersionAfterBuiltin keeps the metadata versions up to date after the builtin functions of the
+// container which change the metadata: ESDTNFTUpdateAttributes and ESDTNFTAddURI
+func (b *MockWorld) updateMetaDataVersionAfterBuiltin(input *vmcommon.ContractCallInput) error {
+	if len(input.Arguments) < 2 {
+		return nil
+	}
+
+	tokenIdentifier := input.Arguments[0]
+	nonce := big.NewInt(0).SetBytes(input.Arguments[1]).Uint64()
+	switch input.Function {
+	case core.BuiltInFunctionESDTNFTUpdateAttributes:
+		return b.increaseMetaDataVersion(tokenIdentifier, nonce, func(version *ESDTMetaDataVersion, round uint64) {
+			version.Attributes = round
+		})
+	case core.BuiltInFunctionESDTNFTAddURI:
+		return b.increaseMetaDataVersion(tokenIdentifier, nonce, func(version *ESDTMetaDataVersion, round uint64) {
+			version.URIs = round
+		})
+	default:
+		return nil
+	}
+}
+
+func makeMetaDataVersionKey(tokenIdentifier []byte, nonce uint64) []byte {
+	key := append([]byte{}, metaDataVersionKeyPrefix...)
+	key = append(key, tokenIdentifier...)
+	return append(key, big.NewInt(0).SetUint64(nonce).Bytes()...)
+}
+
+// nftMetaDataChange changes the metadata of an NFT from the arguments of a builtin function
+// and marks the changed fields in the version
+type nftMetaDataChange func(input *vmcommon.ContractCallInput, metaData *esdt.MetaData, version *ESDTMetaDataVersion, round uint64) error
+
+// nftMetaDataFunction is the mock of a builtin function changing the metadata of an NFT held by the caller,
+// allowed to the accounts with a role of the token.
+type nftMetaDataFunction struct {
+	world             *MockWorld
+	identifier        string
+	role              string
+	onlyDynamicTokens bool
+	minNumArguments   int
+	maxNumArguments   int
+	change            nftMetaDataChange
+
+	mutGasCost   sync.RWMutex
+	funcGasCost  uint64
+	storePerByte uint64
+}
+
+// addNFTMetaDataFunctions adds the mocks of the metadata builtin functions to the container
+func addNFTMetaDataFunctions(container vmcommon.BuiltInFunctionContainer, world *MockWorld, gasMap config.GasScheduleMap) error {
+	const anyNumArguments = -1
+	functions := []*nftMetaDataFunction{
+		{
+			identifier:      BuiltInFunctionESDTMetaDataRecreate,
+			role:            ESDTRoleNFTRecreate,
+			minNumArguments: 7,
+			maxNumArguments: anyNumArguments,
+			change:          recreateNFTMetaData,
+		},
+		{
+			identifier:      BuiltInFunctionESDTMetaDataUpdate,
+			role:            ESDTRoleNFTUpdate,
+			minNumArguments: 7,
+			maxNumArguments: anyNumArguments,
+			change:          updateNFTMetaData,
+		},
+		{
+			identifier:      BuiltInFunctionESDTModifyRoyalties,
+			role:            ESDTRoleModifyRoyalties,
+			minNumArguments: 3,
+			maxNumArguments: 3,
+			change:          modifyNFTRoyalties,
+		},
+		{
+			identifier:      BuiltInFunctionESDTSetNewURIs,
+			role:            ESDTRoleSetNewURI,
+			minNumArguments: 3,
+			maxNumArguments: anyNumArguments,
+			change:          setNewNFTURIs,
+		},
+		{
+			identifier:        BuiltInFunctionESDTModifyCreator,
+			role:              ESDTRoleModifyCreator,
+			onlyDynamicTokens: true,
+			minNumArguments:   2,
+			maxNumArguments:   2,
+			change:            modifyNFTCreator,
+		},
+	}
+
+	for _, function := range functions {
+		function.world = world
+		function.funcGasCost = gasMap[core.BuiltInCostString]["ESDTNFTUpdateAttributes"]
+		function.storePerByte = gasMap[core.BaseOperationCostString]["StorePerByte"]
+		err := container.Add(function.identifier, function)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ProcessBuiltinFunction changes the metadata of the NFT given by the first two arguments
+func (f *nftMetaDataFunction) ProcessBuiltinFunction(
+	_, _ vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	if len(vmInput.Arguments) < f.minNumArguments || (f.maxNumArguments >= 0 && len(vmInput.Arguments) > f.maxNumArguments) {
+		return nil, builtInFunctions.ErrInvalidNumberOfArguments
+	}
+	if !bytes.Equal(vmInput.CallerAddr, vmInput.RecipientAddr) {
+		return nil, builtInFunctions.ErrInvalidRcvAddr
+	}
+
+	f.mutGasCost.RLock()
+	gasCost := f.funcGasCost
+	for _, arg := range vmInput.Arguments[2:] {
+		gasCost += uint64(len(arg)) * f.storePerByte
+	}
+	f.mutGasCost.RUnlock()
+	if vmInput.GasProvided < gasCost {
+		return nil, builtInFunctions.ErrNotEnoughGas
+	}
+
+	tokenIdentifier := vmInput.Arguments[0]
+	nonce := big.NewInt(0).SetBytes(vmInput.Arguments[1]).Uint64()
+	err := f.checkAllowed(vmInput.CallerAddr, tokenIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	metaData, err := f.world.GetNFTMetaData(vmInput.CallerAddr, tokenIdentifier, nonce)
+	if err != nil {
+		return nil, err
+	}
+	version, err := f.world.GetESDTMetaDataVersion(tokenIdentifier, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	newMetaData := *metaData
+	err = f.change(vmInput, &newMetaData, &version, f.world.CurrentRound())
+	if err != nil {
+		return nil, err
+	}
+
+	err = f.world.SetNFTMetaData(vmInput.CallerAddr, tokenIdentifier, nonce, &newMetaData)
+	if err != nil {
+		return nil, err
+	}
+	err = f.world.SetESDTMetaDataVersion(tokenIdentifier, nonce, version)
+	if err != nil {
+		return nil, err
+	}
+
+	topics := [][]byte{tokenIdentifier, vmInput.Arguments[1], {}}
+	topics = append(topics, vmInput.Arguments[2:]...)
+	return &vmcommon.VMOutput{
+		ReturnCode:   vmcommon.Ok,
+		GasRemaining: vmInput.GasProvided - gasCost,
+		Logs: []*vmcommon.LogEntry{{
+			Identifier: []byte(f.identifier),
+			Address:    vmInput.CallerAddr,
+			Topics:     topics,
+		}},
+	}, nil
+}
+
+func (f *nftMetaDataFunction) checkAllowed(caller []byte, tokenIdentifier []byte) error {
+	account := f.world.AcctMap.GetAccount(caller)
+	if account == nil {
+		return builtInFunctions.ErrNilUserAccount
+	}
+
+	roles, err := esdtconvert.GetTokenRoles(tokenIdentifier, account.Storage)
+	if err != nil {
+		return err
+	}
+	if !containsBytes(roles, []byte(f.role)) {
+		return builtInFunctions.ErrActionNotAllowed
+	}
+
+	if f.onlyDynamicTokens && !IsDynamicESDTType(f.world.GetESDTTokenType(tokenIdentifier)) {
+		return ErrTokenIsNotDynamic
+	}
+
+	return nil
+}
+
+// SetNewGasConfig updates the gas cost of the function
+func (f *nftMetaDataFunction) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	if gasCost == nil {
+		return
+	}
+
+	f.mutGasCost.Lock()
+	f.funcGasCost = gasCost.BuiltInCost.ESDTNFTUpdateAttributes
+	f.storePerByte = gasCost.BaseOperationCost.StorePerByte
+	f.mutGasCost.Unlock()
+}
+
+// IsActive returns true, the mocks are always active
+func (f *nftMetaDataFunction) IsActive() bool {
+	return true
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (f *nftMetaDataFunction) IsInterfaceNil() bool {
+	return f == nil
+}
+
+// ESDTMetaDataRecreate@tokenID@nonce@name@royalties@hash@attributes@uris...
+func recreateNFTMetaData(input *vmcommon.ContractCallInput, metaData *esdt.MetaData, version *ESDTMetaDataVersion, round uint64) error {
+	royalties, err := parseNFTRoyalties(input.Arguments[3])
+	if err != nil {
+		return err
+	}
+
+	metaData.Name = input.Arguments[2]
+	metaData.Creator = input.CallerAddr
+	metaData.Royalties = royalties
+	metaData.Hash = input.Arguments[4]
+	metaData.Attributes = input.Arguments[5]
+	metaData.URIs = input.Arguments[6:]
+	*version = ESDTMetaDataVersion{
+		Name:       round,
+		Creator:    round,
+		Royalties:  round,
+		Hash:       round,
+		URIs:       round,
+		Attributes: round,
+	}
+
+	return nil
+}
+
+// ESDTMetaDataUpdate@tokenID@nonce@name@royalties@hash@attributes@uris..., where the empty arguments keep the fields unchanged
+func updateNFTMetaData(input *vmcommon.ContractCallInput, metaData *esdt.MetaData, version *ESDTMetaDataVersion, round uint64) error {
+	if len(input.Arguments[2]) > 0 {
+		metaData.Name = input.Arguments[2]
+		version.Name = round
+	}
+	if len(input.Arguments[3]) > 0 {
+		royalties, err := parseNFTRoyalties(input.Arguments[3])
+		if err != nil {
+			return err
+		}
+		metaData.Royalties = royalties
+		version.Royalties = round
+	}
+	if len(input.Arguments[4]) > 0 {
+		metaData.Hash = input.Arguments[4]
+		version.Hash = round
+	}
+	if len(input.Arguments[5]) > 0 {
+		metaData.Attributes = input.Arguments[5]
+		version.Attributes = round
+	}
+	if !areAllEmpty(input.Arguments[6:]) {
+		metaData.URIs = input.Arguments[6:]
+		version.URIs = round
+	}
+
+	metaData.Creator = input.CallerAddr
+	version.Creator = round
+	return nil
+}
+
+// ESDTModifyRoyalties@tokenID@nonce@royalties
+func modifyNFTRoyalties(input *vmcommon.ContractCallInput, metaData *esdt.MetaData, version *ESDTMetaDataVersion, round uint64) error {
+	royalties, err := parseNFTRoyalties(input.Arguments[2])
+	if err != nil {
+		return err
+	}
+
+	metaData.Royalties = royalties
+	version.Royalties = round
+	return nil
+}
+
+// ESDTSetNewURIs@tokenID@nonce@uris...
+func setNewNFTURIs(input *vmcommon.ContractCallInput, metaData *esdt.MetaData, version *ESDTMetaDataVersion, round uint64) error {
+	metaData.URIs = input.Arguments[2:]
+	version.URIs = round
+	return nil
+}
+
+// ESDTModifyCreator@tokenID@nonce, the caller becomes the creator
+func modifyNFTCreator(input *vmcommon.ContractCallInput, metaData *esdt.MetaData, version *ESDTMetaDataVersion, round uint64) error {
+	metaData.Creator = input.CallerAddr
+	version.Creator = round
+	return nil
+}
+
+func parseNFTRoyalties(arg []byte) (uint32, error) {
+	royalties := big.NewInt(0).SetBytes(arg)
+	if !royalties.IsUint64() || royalties.Uint64() > maxNFTRoyalties {
+		return 0, ErrInvalidRoyalties
+	}
+
+	return uint32(royalties.Uint64()), nil
+}
+
+func areAllEmpty(args [][]byte) bool {
+	for _, arg := range args {
+		if len(arg) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
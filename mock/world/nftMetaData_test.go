@@ -0,0 +1,152 @@
+package worldmock
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+	"github.com/multiversx/mx-chain-vm-go/config"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	nftTestHolder     = []byte("nft-holder______________________")
+	nftTestCreator    = []byte("nft-creator_____________________")
+	nftTestIdentifier = []byte("NFT-123456")
+	nftTestGasLimit   = uint64(10_000_000)
+)
+
+func newNFTMetaDataTestWorld(t *testing.T, roles ...string) *MockWorld {
+	world := NewMockWorld()
+	err := world.InitBuiltinFunctions(config.MakeGasMapForTests())
+	require.Nil(t, err)
+	world.CurrentBlockInfo = &BlockInfo{BlockRound: 7}
+
+	account := world.AcctMap.CreateAccount(nftTestHolder, world)
+	err = account.SetTokenData(nftTestIdentifier, 1, &esdt.ESDigitalToken{
+		Type:  uint32(core.NonFungible),
+		Value: big.NewInt(1),
+		TokenMetaData: &esdt.MetaData{
+			Nonce:      1,
+			Name:       []byte("first"),
+			Creator:    nftTestCreator,
+			Royalties:  500,
+			Hash:       []byte("hash"),
+			URIs:       [][]byte{[]byte("uri")},
+			Attributes: []byte("attributes"),
+		},
+	})
+	require.Nil(t, err)
+	err = account.SetTokenRolesAsStrings(nftTestIdentifier, roles)
+	require.Nil(t, err)
+
+	return world
+}
+
+func callNFTMetaDataBuiltin(world *MockWorld, function string, args ...[]byte) error {
+	_, err := world.BuiltinFuncs.ProcessBuiltInFunction(&vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  nftTestHolder,
+			CallValue:   big.NewInt(0),
+			GasProvided: nftTestGasLimit,
+			Arguments:   append([][]byte{nftTestIdentifier, {1}}, args...),
+		},
+		RecipientAddr: nftTestHolder,
+		Function:      function,
+	})
+	return err
+}
+
+func TestMockWorld_NFTMetaDataBuiltins(t *testing.T) {
+	t.Parallel()
+
+	world := newNFTMetaDataTestWorld(t, ESDTRoleModifyRoyalties, ESDTRoleNFTUpdate, ESDTRoleModifyCreator, core.ESDTRoleNFTUpdateAttributes)
+
+	err := callNFTMetaDataBuiltin(world, BuiltInFunctionESDTSetNewURIs, []byte("new-uri"))
+	require.Equal(t, builtInFunctions.ErrActionNotAllowed, err)
+	err = callNFTMetaDataBuiltin(world, BuiltInFunctionESDTModifyRoyalties, big.NewInt(10001).Bytes())
+	require.Equal(t, ErrInvalidRoyalties, err)
+
+	err = callNFTMetaDataBuiltin(world, BuiltInFunctionESDTModifyRoyalties, big.NewInt(1000).Bytes())
+	require.Nil(t, err)
+	metaData, err := world.GetNFTMetaData(nftTestHolder, nftTestIdentifier, 1)
+	require.Nil(t, err)
+	require.Equal(t, uint32(1000), metaData.Royalties)
+	version, err := world.GetESDTMetaDataVersion(nftTestIdentifier, 1)
+	require.Nil(t, err)
+	require.Equal(t, ESDTMetaDataVersion{Royalties: 7}, version)
+
+	// the empty arguments keep the fields unchanged
+	world.CurrentBlockInfo.BlockRound = 8
+	err = callNFTMetaDataBuiltin(world, BuiltInFunctionESDTMetaDataUpdate, []byte("second"), nil, nil, nil, []byte("new-uri"))
+	require.Nil(t, err)
+	metaData, err = world.GetNFTMetaData(nftTestHolder, nftTestIdentifier, 1)
+	require.Nil(t, err)
+	require.Equal(t, []byte("second"), metaData.Name)
+	require.Equal(t, nftTestHolder, metaData.Creator)
+	require.Equal(t, uint32(1000), metaData.Royalties)
+	require.Equal(t, []byte("attributes"), metaData.Attributes)
+	require.Equal(t, [][]byte{[]byte("new-uri")}, metaData.URIs)
+	version, err = world.GetESDTMetaDataVersion(nftTestIdentifier, 1)
+	require.Nil(t, err)
+	require.Equal(t, ESDTMetaDataVersion{Name: 8, Creator: 8, Royalties: 7, URIs: 8}, version)
+
+	// the changes made by the builtin functions of the container have versions too
+	world.CurrentBlockInfo.BlockRound = 9
+	err = callNFTMetaDataBuiltin(world, core.BuiltInFunctionESDTNFTUpdateAttributes, []byte("new-attributes"))
+	require.Nil(t, err)
+	version, err = world.GetESDTMetaDataVersion(nftTestIdentifier, 1)
+	require.Nil(t, err)
+	require.Equal(t, uint64(9), version.Attributes)
+
+	err = callNFTMetaDataBuiltin(world, BuiltInFunctionESDTModifyCreator)
+	require.Equal(t, ErrTokenIsNotDynamic, err)
+	err = world.SetESDTTokenType(nftTestIdentifier, DynamicNFT)
+	require.Nil(t, err)
+	err = callNFTMetaDataBuiltin(world, BuiltInFunctionESDTModifyCreator)
+	require.Nil(t, err)
+	version, err = world.GetESDTMetaDataVersion(nftTestIdentifier, 1)
+	require.Nil(t, err)
+	require.Equal(t, uint64(9), version.Creator)
+
+	err = world.SetESDTTokenType(nftTestIdentifier, "NoSuchType")
+	require.ErrorIs(t, err, ErrUnknownTokenType)
+}
+
+func TestMockWorld_SetNFTMetaDataInSystemAccount(t *testing.T) {
+	t.Parallel()
+
+	world := newNFTMetaDataTestWorld(t, ESDTRoleNFTRecreate)
+	systemAccount := world.AcctMap.CreateAccount(vmcommon.SystemAccountAddress, world)
+	err := systemAccount.SetTokenData(nftTestIdentifier, 1, &esdt.ESDigitalToken{
+		Value:         big.NewInt(0),
+		TokenMetaData: &esdt.MetaData{Nonce: 1, Name: []byte("shared"), Creator: nftTestCreator},
+		Reserved:      []byte{1},
+	})
+	require.Nil(t, err)
+
+	err = callNFTMetaDataBuiltin(world, BuiltInFunctionESDTMetaDataRecreate,
+		[]byte("recreated"), big.NewInt(250).Bytes(), []byte("hash"), []byte("attributes"), []byte("uri"))
+	require.Nil(t, err)
+
+	// the metadata is changed for all the holders, while the account keeps its own copy
+	systemTokenData, err := systemAccount.GetTokenData(nftTestIdentifier, 1, make(map[string][]byte))
+	require.Nil(t, err)
+	require.Equal(t, []byte("recreated"), systemTokenData.TokenMetaData.Name)
+	require.Equal(t, uint32(250), systemTokenData.TokenMetaData.Royalties)
+	require.Equal(t, []byte{1}, systemTokenData.Reserved)
+	holderTokenData, err := world.AcctMap.GetAccount(nftTestHolder).GetTokenData(nftTestIdentifier, 1, make(map[string][]byte))
+	require.Nil(t, err)
+	require.Equal(t, []byte("first"), holderTokenData.TokenMetaData.Name)
+
+	version, err := world.GetESDTMetaDataVersion(nftTestIdentifier, 1)
+	require.Nil(t, err)
+	require.Equal(t, ESDTMetaDataVersion{Name: 7, Creator: 7, Royalties: 7, Hash: 7, URIs: 7, Attributes: 7}, version)
+
+	err = world.SetESDTMetaDataVersion(nftTestIdentifier, 1, ESDTMetaDataVersion{})
+	require.Nil(t, err)
+	require.NotContains(t, systemAccount.Storage, string(makeMetaDataVersionKey(nftTestIdentifier, 1)))
+}
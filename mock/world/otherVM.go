@@ -0,0 +1,304 @@
+package worldmock
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// SystemVMType is the VM type of the metachain system smart contracts
+var SystemVMType = []byte{0, 1}
+
+// OtherVMHandler is a Go stand-in for a VM other than the one being tested. It receives
+// the calls which the host forwards through ExecuteSmartContractCallOnOtherVM, can read
+// the state of the MockWorld, and returns its changes in the VMOutput of the call,
+// which the host merges into its own output, to be committed with it.
+type OtherVMHandler interface {
+	ExecuteCall(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error)
+}
+
+// OtherVMHandlerFunc adapts a function to the OtherVMHandler interface
+type OtherVMHandlerFunc func(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error)
+
+// ExecuteCall calls the function
+func (f OtherVMHandlerFunc) ExecuteCall(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	return f(input)
+}
+
+// CannedOutputHandler returns an OtherVMHandler which answers every call with the same VMOutput
+func CannedOutputHandler(vmOutput *vmcommon.VMOutput) OtherVMHandler {
+	return OtherVMHandlerFunc(func(_ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+		return vmOutput, nil
+	})
+}
+
+// RegisterOtherVM installs the handler of the calls to the contracts of the given VM type,
+// replacing the handler previously registered for it; the VM types without a handler
+// answer with their output in OtherVMOutputMap
+func (b *MockWorld) RegisterOtherVM(vmType []byte, handler OtherVMHandler) {
+	b.OtherVMHandlers[string(vmType)] = handler
+}
+
+// GetOtherVM returns the handler registered for the given VM type, or nil if there is none
+func (b *MockWorld) GetOtherVM(vmType []byte) OtherVMHandler {
+	return b.OtherVMHandlers[string(vmType)]
+}
+
+// SystemVMHandler is the OtherVMHandler of the system VM, which dispatches the calls
+// to the system smart contracts registered at their addresses.
+//
+// The system SCs only read the committed state of the MockWorld, not the changes pending in the
+// output of the host: two calls to the system SCs in the same transaction do not see each other,
+// as the second one reads the state from before the first one.
+type SystemVMHandler struct {
+	contracts map[string]OtherVMHandler
+}
+
+// NewSystemVMHandler creates a SystemVMHandler without any contracts
+func NewSystemVMHandler() *SystemVMHandler {
+	return &SystemVMHandler{
+		contracts: make(map[string]OtherVMHandler),
+	}
+}
+
+// RegisterContract installs the handler of the calls to the system SC at the given address
+func (handler *SystemVMHandler) RegisterContract(address []byte, contract OtherVMHandler) {
+	handler.contracts[string(address)] = contract
+}
+
+// GetContract returns the system SC registered at the given address, or nil if there is none
+func (handler *SystemVMHandler) GetContract(address []byte) OtherVMHandler {
+	return handler.contracts[string(address)]
+}
+
+// ExecuteCall forwards the call to the system SC at the recipient address
+func (handler *SystemVMHandler) ExecuteCall(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	contract := handler.contracts[string(input.RecipientAddr)]
+	if contract == nil {
+		return userErrorOutput(fmt.Sprintf("no system smart contract at address %x", input.RecipientAddr)), nil
+	}
+
+	return contract.ExecuteCall(input)
+}
+
+// SystemVM returns the SystemVMHandler registered for the system VM type,
+// registering a new one if the system VM has no handler yet
+func (b *MockWorld) SystemVM() *SystemVMHandler {
+	systemVM, isSystemVM := b.GetOtherVM(SystemVMType).(*SystemVMHandler)
+	if !isSystemVM {
+		systemVM = NewSystemVMHandler()
+		b.RegisterOtherVM(SystemVMType, systemVM)
+	}
+
+	return systemVM
+}
+
+// RegisterSystemSCs installs the stand-ins of all the system SCs in the system VM
+func (b *MockWorld) RegisterSystemSCs() {
+	b.RegisterESDTSystemSC()
+	b.RegisterStakingSC()
+	b.RegisterDelegationManagerSC()
+	b.RegisterGovernanceSC()
+}
+
+// IsSystemSCAddress returns true if the address belongs to a contract of the system VM
+func IsSystemSCAddress(address []byte) bool {
+	if !vmcommon.IsSmartContractAddress(address) {
+		return false
+	}
+
+	vmType, err := vmcommon.ParseVMTypeFromContractAddress(address)
+	return err == nil && bytes.Equal(vmType, SystemVMType)
+}
+
+// systemSCFunction runs a system SC function, adding the changes it makes to the output;
+// the output is discarded when the function returns an error
+type systemSCFunction func(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error
+
+// executeSystemSCFunction runs the function called by the input, returning its errors as user errors;
+// the call value is paid to the system SC only if the function succeeds. The system SCs read the
+// committed state of the MockWorld and never change it: all their changes are in the returned VMOutput.
+func (b *MockWorld) executeSystemSCFunction(input *vmcommon.ContractCallInput, functions map[string]systemSCFunction) *vmcommon.VMOutput {
+	function, found := functions[input.Function]
+	if !found {
+		return userErrorOutput(fmt.Sprintf("invalid method to call: %s", input.Function))
+	}
+
+	output := &vmcommon.VMOutput{
+		ReturnCode:     vmcommon.Ok,
+		GasRemaining:   input.GasProvided,
+		OutputAccounts: make(map[string]*vmcommon.OutputAccount),
+	}
+	err := function(input, output)
+	if err != nil {
+		return userErrorOutput(err.Error())
+	}
+
+	receiveCallValue(input, output)
+	return output
+}
+
+// checkNotPayable returns an error if a non-payable system SC function received value
+func checkNotPayable(input *vmcommon.ContractCallInput) error {
+	if input.CallValue != nil && input.CallValue.Sign() != 0 {
+		return fmt.Errorf("callValue must be 0")
+	}
+
+	return nil
+}
+
+// checkNumArguments returns an error if the call has fewer arguments than required
+func checkNumArguments(input *vmcommon.ContractCallInput, required int) error {
+	if len(input.Arguments) < required {
+		return fmt.Errorf("not enough arguments")
+	}
+
+	return nil
+}
+
+// loadSystemSCState decodes the state saved by a system SC under the given key;
+// it returns false if there is nothing saved under the key
+func (b *MockWorld) loadSystemSCState(address []byte, key string, state interface{}) (bool, error) {
+	account := b.AcctMap.GetAccount(address)
+	if account == nil {
+		return false, nil
+	}
+
+	serializedState := account.Storage[key]
+	if len(serializedState) == 0 {
+		return false, nil
+	}
+
+	return true, json.Unmarshal(serializedState, state)
+}
+
+// saveSystemSCState saves the state of a system SC in its storage, as a storage update in the output of the call
+func saveSystemSCState(output *vmcommon.VMOutput, address []byte, key string, state interface{}) error {
+	serializedState, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	setStorageUpdate(output, address, []byte(key), serializedState)
+	return nil
+}
+
+// applySystemSCChanges commits at once the changes which the change function adds to an output,
+// for the changes the protocol makes to the system SCs outside of a transaction
+func (b *MockWorld) applySystemSCChanges(change func(output *vmcommon.VMOutput) error) error {
+	output := &vmcommon.VMOutput{
+		OutputAccounts: make(map[string]*vmcommon.OutputAccount),
+	}
+	err := change(output)
+	if err != nil {
+		return err
+	}
+
+	return b.UpdateAccounts(output.OutputAccounts, nil)
+}
+
+// updateAccountStorage lets the update function change the storage of an account, as the account
+// would be after the output is committed, and adds the keys it changed to the output as storage updates.
+// It allows changing the storage with the esdtconvert functions, which work on storage maps.
+func (b *MockWorld) updateAccountStorage(output *vmcommon.VMOutput, address []byte, update func(storage map[string][]byte) error) error {
+	storage := make(map[string][]byte)
+	account := b.AcctMap.GetAccount(address)
+	if account != nil {
+		for key, value := range account.Storage {
+			storage[key] = value
+		}
+	}
+	outputAccount, found := output.OutputAccounts[string(address)]
+	if found {
+		for key, storageUpdate := range outputAccount.StorageUpdates {
+			storage[key] = storageUpdate.Data
+		}
+	}
+
+	updatedStorage := make(map[string][]byte, len(storage))
+	for key, value := range storage {
+		updatedStorage[key] = value
+	}
+	err := update(updatedStorage)
+	if err != nil {
+		return err
+	}
+
+	for key, value := range updatedStorage {
+		if !bytes.Equal(storage[key], value) {
+			setStorageUpdate(output, address, []byte(key), value)
+		}
+	}
+	for key := range storage {
+		_, kept := updatedStorage[key]
+		if !kept {
+			setStorageUpdate(output, address, []byte(key), nil)
+		}
+	}
+
+	return nil
+}
+
+func setStorageUpdate(output *vmcommon.VMOutput, address []byte, key []byte, value []byte) {
+	outputAccount := getOrCreateOutputAccount(output, address)
+	outputAccount.StorageUpdates[string(key)] = &vmcommon.StorageUpdate{
+		Offset:  key,
+		Data:    value,
+		Written: true,
+	}
+}
+
+// receiveCallValue moves the value of a successful call from the caller to the called system SC
+func receiveCallValue(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) {
+	if input.CallValue == nil || input.CallValue.Sign() == 0 {
+		return
+	}
+
+	moveBalance(output, input.CallerAddr, input.RecipientAddr, input.CallValue)
+}
+
+// moveBalance changes the balance deltas of the output accounts, as a value transfer between them
+func moveBalance(output *vmcommon.VMOutput, sender []byte, destination []byte, value *big.Int) {
+	senderAccount := getOrCreateOutputAccount(output, sender)
+	senderAccount.BalanceDelta.Sub(senderAccount.BalanceDelta, value)
+	destinationAccount := getOrCreateOutputAccount(output, destination)
+	destinationAccount.BalanceDelta.Add(destinationAccount.BalanceDelta, value)
+}
+
+// sendValue pays value from a system SC, as a transfer which the callback of the destination receives as call value
+func sendValue(output *vmcommon.VMOutput, sender []byte, destination []byte, value *big.Int) {
+	moveBalance(output, sender, destination, value)
+
+	destinationAccount := getOrCreateOutputAccount(output, destination)
+	destinationAccount.OutputTransfers = append(destinationAccount.OutputTransfers, vmcommon.OutputTransfer{
+		Value:         big.NewInt(0).Set(value),
+		SenderAddress: sender,
+		CallType:      vm.AsynchronousCallBack,
+	})
+}
+
+func getOrCreateOutputAccount(output *vmcommon.VMOutput, address []byte) *vmcommon.OutputAccount {
+	outputAccount, found := output.OutputAccounts[string(address)]
+	if !found {
+		outputAccount = &vmcommon.OutputAccount{
+			Address:        address,
+			BalanceDelta:   big.NewInt(0),
+			StorageUpdates: make(map[string]*vmcommon.StorageUpdate),
+		}
+		output.OutputAccounts[string(address)] = outputAccount
+	}
+
+	return outputAccount
+}
+
+func userErrorOutput(message string) *vmcommon.VMOutput {
+	return &vmcommon.VMOutput{
+		ReturnCode:    vmcommon.UserError,
+		ReturnMessage: message,
+		GasRemaining:  0,
+	}
+}
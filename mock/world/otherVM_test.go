@@ -0,0 +1,121 @@
+package worldmock
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/stretchr/testify/require"
+)
+
+func callSystemSC(t *testing.T, world *MockWorld, address []byte, caller []byte, value *big.Int, function string, args ...[]byte) *vmcommon.VMOutput {
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  caller,
+			Arguments:   args,
+			CallValue:   value,
+			GasProvided: 1000,
+		},
+		RecipientAddr: address,
+		Function:      function,
+	}
+
+	vmOutput, err := world.ExecuteSmartContractCallOnOtherVM(input)
+	require.Nil(t, err)
+	if vmOutput.ReturnCode == vmcommon.Ok {
+		// the host would commit the output of a successful call along with its own
+		err = world.UpdateAccounts(vmOutput.OutputAccounts, nil)
+		require.Nil(t, err)
+	}
+	return vmOutput
+}
+
+func TestMockWorld_OtherVMHandlers(t *testing.T) {
+	t.Parallel()
+
+	fakeVMType := []byte{0xbe, 0xaf}
+	contractAddress := []byte("\x00\x00\x00\x00\x00\x00\x00\x00\xbe\xafcontract______________")
+	world := NewMockWorld()
+
+	vmOutput := callSystemSC(t, world, contractAddress, esdtSCTestOwner, big.NewInt(0), "f")
+	require.Equal(t, &vmcommon.VMOutput{}, vmOutput)
+
+	mapOutput := &vmcommon.VMOutput{ReturnData: [][]byte{[]byte("from map")}}
+	world.OtherVMOutputMap[string(fakeVMType)] = mapOutput
+	vmOutput = callSystemSC(t, world, contractAddress, esdtSCTestOwner, big.NewInt(0), "f")
+	require.Equal(t, mapOutput, vmOutput)
+
+	// the registered handlers take precedence over the outputs in the map
+	cannedOutput := &vmcommon.VMOutput{ReturnData: [][]byte{[]byte("canned")}}
+	world.RegisterOtherVM(fakeVMType, CannedOutputHandler(cannedOutput))
+	vmOutput = callSystemSC(t, world, contractAddress, esdtSCTestOwner, big.NewInt(0), "f")
+	require.Equal(t, cannedOutput, vmOutput)
+
+	world.RegisterOtherVM(fakeVMType, OtherVMHandlerFunc(func(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+		return &vmcommon.VMOutput{ReturnData: [][]byte{[]byte(input.Function)}}, nil
+	}))
+	vmOutput = callSystemSC(t, world, contractAddress, esdtSCTestOwner, big.NewInt(0), "computed")
+	require.Equal(t, [][]byte{[]byte("computed")}, vmOutput.ReturnData)
+}
+
+func TestMockWorld_SystemVM(t *testing.T) {
+	t.Parallel()
+
+	world := NewMockWorld()
+	world.RegisterSystemSCs()
+	require.True(t, IsSystemSCAddress(GovernanceSCAddress))
+	require.False(t, IsSystemSCAddress(esdtSCTestOwner))
+
+	vmOutput := callSystemSC(t, world, makeDelegationSCAddress(firstDelegationSCIndex), esdtSCTestOwner, big.NewInt(0), "delegate")
+	require.Equal(t, vmcommon.UserError, vmOutput.ReturnCode)
+
+	vmOutput = callSystemSC(t, world, GovernanceSCAddress, esdtSCTestOwner, big.NewInt(0), "unknown")
+	require.Equal(t, "invalid method to call: unknown", vmOutput.ReturnMessage)
+}
+
+func TestMockWorld_SystemSCChangesOnlyInOutput(t *testing.T) {
+	t.Parallel()
+
+	world := NewMockWorld()
+	world.RegisterESDTSystemSC()
+
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  esdtSCTestOwner,
+			Arguments:   [][]byte{[]byte("Collection"), []byte("NFT"), []byte("NFT"), {0}},
+			CallValue:   big.NewInt(0),
+			GasProvided: 1000,
+		},
+		RecipientAddr: core.ESDTSCAddress,
+		Function:      "registerAndSetAllRoles",
+	}
+	vmOutput, err := world.ExecuteSmartContractCallOnOtherVM(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	collection := vmOutput.ReturnData[0]
+
+	// nothing is changed until the output is committed
+	require.Nil(t, world.ESDTSystemSC.GetTokenInfo(collection))
+	require.Nil(t, world.AcctMap.GetAccount(esdtSCTestOwner))
+	require.Contains(t, vmOutput.OutputAccounts, string(core.ESDTSCAddress))
+	require.Contains(t, vmOutput.OutputAccounts, string(esdtSCTestOwner))
+
+	err = world.UpdateAccounts(vmOutput.OutputAccounts, nil)
+	require.Nil(t, err)
+	require.Equal(t, core.NonFungibleESDT, world.ESDTSystemSC.GetTokenInfo(collection).Type)
+	require.True(t, world.IsSmartContract(core.ESDTSCAddress))
+
+	// the changes of a failing function are dropped with its output
+	vmOutput = world.executeSystemSCFunction(input, map[string]systemSCFunction{
+		"registerAndSetAllRoles": func(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+			err := saveToken(output, &ESDTTokenInfo{Identifier: "OTHER-abcdef"})
+			require.Nil(t, err)
+			return errors.New("roles not set")
+		},
+	})
+	require.Equal(t, vmcommon.UserError, vmOutput.ReturnCode)
+	require.Empty(t, vmOutput.OutputAccounts)
+	require.Nil(t, world.ESDTSystemSC.GetTokenInfo([]byte("OTHER-abcdef")))
+}
@@ -0,0 +1,238 @@
+package worldmock
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// ValidatorSCAddress is the address of the validator system SC, where the validator nodes are staked
+var ValidatorSCAddress = []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 255, 255}
+
+// DefaultNodePrice is the stake required for a validator node, 2500 EGLD
+var DefaultNodePrice = big.NewInt(0).Mul(big.NewInt(2500), big.NewInt(1e18))
+
+// DefaultUnBondPeriodEpochs is the number of epochs after which the unstaked value can be withdrawn
+const DefaultUnBondPeriodEpochs = 10
+
+// StakedNode is a validator node registered with the staking SC
+type StakedNode struct {
+	BLSKey        []byte
+	Staked        bool
+	UnStakedEpoch uint32
+}
+
+// StakerInfo is what the staking SC knows about an address which staked nodes
+type StakerInfo struct {
+	Nodes      []*StakedNode
+	TotalStake *big.Int
+}
+
+// StakingSCMock is a Go stand-in for the staking of validator nodes, done through the validator
+// system SC of the metachain. The stakers are kept in the storage of the validator SC account.
+type StakingSCMock struct {
+	World *MockWorld
+
+	NodePrice          *big.Int
+	UnBondPeriodEpochs uint32
+}
+
+// RegisterStakingSC installs a StakingSCMock in the system VM, at the address of the validator SC
+func (b *MockWorld) RegisterStakingSC() *StakingSCMock {
+	b.StakingSC = &StakingSCMock{
+		World:              b,
+		NodePrice:          big.NewInt(0).Set(DefaultNodePrice),
+		UnBondPeriodEpochs: DefaultUnBondPeriodEpochs,
+	}
+	b.SystemVM().RegisterContract(ValidatorSCAddress, b.StakingSC)
+	return b.StakingSC
+}
+
+// GetStakerInfo returns the nodes and the stake of an address, or nil if the address did not stake
+func (sc *StakingSCMock) GetStakerInfo(address []byte) *StakerInfo {
+	staker := &StakerInfo{}
+	found, err := sc.World.loadSystemSCState(ValidatorSCAddress, hex.EncodeToString(address), staker)
+	if !found || err != nil {
+		return nil
+	}
+
+	return staker
+}
+
+// GetTotalStaked returns the value staked by an address, including what was unstaked but not yet unbonded
+func (sc *StakingSCMock) GetTotalStaked(address []byte) *big.Int {
+	staker := sc.GetStakerInfo(address)
+	if staker == nil {
+		return big.NewInt(0)
+	}
+
+	return staker.TotalStake
+}
+
+// ExecuteCall runs a call on the staking SC; the errors of the call are returned as user errors in the VMOutput
+func (sc *StakingSCMock) ExecuteCall(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	return sc.World.executeSystemSCFunction(input, sc.functions()), nil
+}
+
+func (sc *StakingSCMock) functions() map[string]systemSCFunction {
+	return map[string]systemSCFunction{
+		"stake":          sc.stake,
+		"unStake":        sc.unStake,
+		"unBond":         sc.unBond,
+		"getTotalStaked": sc.getTotalStaked,
+	}
+}
+
+// stake@numNodes@(blsKey@signature)...; the value above the price of the nodes is kept as top-up
+func (sc *StakingSCMock) stake(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+	err := checkNumArguments(input, 1)
+	if err != nil {
+		return err
+	}
+
+	numNodes := big.NewInt(0).SetBytes(input.Arguments[0]).Uint64()
+	if uint64(len(input.Arguments)-1) != 2*numNodes {
+		return fmt.Errorf("invalid number of arguments")
+	}
+
+	staker := sc.GetStakerInfo(input.CallerAddr)
+	if staker == nil {
+		staker = &StakerInfo{TotalStake: big.NewInt(0)}
+	}
+
+	for i := uint64(0); i < numNodes; i++ {
+		blsKey := input.Arguments[1+2*i]
+		if sc.isRegisteredNode(staker, blsKey) {
+			return fmt.Errorf("bls key already registered")
+		}
+		staker.Nodes = append(staker.Nodes, &StakedNode{BLSKey: blsKey, Staked: true})
+	}
+
+	staker.TotalStake.Add(staker.TotalStake, input.CallValue)
+	if staker.TotalStake.Cmp(sc.requiredStake(staker)) < 0 {
+		return fmt.Errorf("not enough stake to cover %d nodes", countStakedNodes(staker))
+	}
+
+	return saveStaker(output, input.CallerAddr, staker)
+}
+
+// unStake@blsKey...
+func (sc *StakingSCMock) unStake(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+	staker, err := sc.getStakerForNodeCall(input)
+	if err != nil {
+		return err
+	}
+
+	for _, blsKey := range input.Arguments {
+		node := findNode(staker, blsKey)
+		if node == nil || !node.Staked {
+			return fmt.Errorf("cannot unStake node %x which is not staked", blsKey)
+		}
+		node.Staked = false
+		node.UnStakedEpoch = sc.World.CurrentEpoch()
+	}
+
+	return saveStaker(output, input.CallerAddr, staker)
+}
+
+// unBond@blsKey...; pays back the price of the nodes unstaked at least UnBondPeriodEpochs ago
+func (sc *StakingSCMock) unBond(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+	staker, err := sc.getStakerForNodeCall(input)
+	if err != nil {
+		return err
+	}
+
+	currentEpoch := sc.World.CurrentEpoch()
+	for _, blsKey := range input.Arguments {
+		node := findNode(staker, blsKey)
+		if node == nil || node.Staked {
+			return fmt.Errorf("cannot unBond node %x which is not unStaked", blsKey)
+		}
+		if currentEpoch < node.UnStakedEpoch+sc.UnBondPeriodEpochs {
+			return fmt.Errorf("unBond is not possible for node %x, the unBond period did not pass", blsKey)
+		}
+		staker.Nodes = removeNode(staker.Nodes, blsKey)
+	}
+
+	unBondValue := big.NewInt(0).Mul(sc.NodePrice, big.NewInt(int64(len(input.Arguments))))
+	staker.TotalStake.Sub(staker.TotalStake, unBondValue)
+	sendValue(output, ValidatorSCAddress, input.CallerAddr, unBondValue)
+
+	return saveStaker(output, input.CallerAddr, staker)
+}
+
+// getTotalStaked returns the total stake of the caller
+func (sc *StakingSCMock) getTotalStaked(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) error {
+	err := checkNotPayable(input)
+	if err != nil {
+		return err
+	}
+
+	output.ReturnData = [][]byte{sc.GetTotalStaked(input.CallerAddr).Bytes()}
+	return nil
+}
+
+func (sc *StakingSCMock) getStakerForNodeCall(input *vmcommon.ContractCallInput) (*StakerInfo, error) {
+	err := checkNotPayable(input)
+	if err != nil {
+		return nil, err
+	}
+	err = checkNumArguments(input, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	staker := sc.GetStakerInfo(input.CallerAddr)
+	if staker == nil {
+		return nil, fmt.Errorf("caller has not staked")
+	}
+
+	return staker, nil
+}
+
+func (sc *StakingSCMock) isRegisteredNode(staker *StakerInfo, blsKey []byte) bool {
+	return findNode(staker, blsKey) != nil
+}
+
+func (sc *StakingSCMock) requiredStake(staker *StakerInfo) *big.Int {
+	return big.NewInt(0).Mul(sc.NodePrice, big.NewInt(int64(countStakedNodes(staker))))
+}
+
+func saveStaker(output *vmcommon.VMOutput, address []byte, staker *StakerInfo) error {
+	return saveSystemSCState(output, ValidatorSCAddress, hex.EncodeToString(address), staker)
+}
+
+func countStakedNodes(staker *StakerInfo) int {
+	numStaked := 0
+	for _, node := range staker.Nodes {
+		if node.Staked {
+			numStaked++
+		}
+	}
+
+	return numStaked
+}
+
+func findNode(staker *StakerInfo, blsKey []byte) *StakedNode {
+	for _, node := range staker.Nodes {
+		if bytes.Equal(node.BLSKey, blsKey) {
+			return node
+		}
+	}
+
+	return nil
+}
+
+func removeNode(nodes []*StakedNode, blsKey []byte) []*StakedNode {
+	remaining := make([]*StakedNode, 0, len(nodes))
+	for _, node := range nodes {
+		if !bytes.Equal(node.BLSKey, blsKey) {
+			remaining = append(remaining, node)
+		}
+	}
+
+	return remaining
+}
@@ -0,0 +1,140 @@
+package worldmock
+
+import (
+	"math/big"
+	"testing"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/stretchr/testify/require"
+)
+
+var oneEGLD = big.NewInt(1e18)
+
+func egld(amount int64) *big.Int {
+	return big.NewInt(0).Mul(big.NewInt(amount), oneEGLD)
+}
+
+func setEpoch(world *MockWorld, epoch uint32) {
+	world.CurrentBlockInfo = &BlockInfo{BlockEpoch: epoch}
+}
+
+func requireBalanceDelta(t *testing.T, vmOutput *vmcommon.VMOutput, address []byte, expected *big.Int) {
+	outputAccount, found := vmOutput.OutputAccounts[string(address)]
+	require.True(t, found)
+	require.Zero(t, expected.Cmp(outputAccount.BalanceDelta), "balance delta %s, expected %s", outputAccount.BalanceDelta, expected)
+}
+
+func TestStakingSCMock_StakeUnStakeUnBond(t *testing.T) {
+	t.Parallel()
+
+	world := NewMockWorld()
+	staking := world.RegisterStakingSC()
+	blsKey := []byte("bls_key")
+
+	vmOutput := callSystemSC(t, world, ValidatorSCAddress, esdtSCTestOwner, egld(2000), "stake", []byte{1}, blsKey, []byte("signature"))
+	require.Equal(t, "not enough stake to cover 1 nodes", vmOutput.ReturnMessage)
+
+	vmOutput = callSystemSC(t, world, ValidatorSCAddress, esdtSCTestOwner, egld(2600), "stake", []byte{1}, blsKey, []byte("signature"))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	requireBalanceDelta(t, vmOutput, esdtSCTestOwner, big.NewInt(0).Neg(egld(2600)))
+	require.Equal(t, egld(2600), staking.GetTotalStaked(esdtSCTestOwner))
+
+	setEpoch(world, 5)
+	vmOutput = callSystemSC(t, world, ValidatorSCAddress, esdtSCTestOwner, big.NewInt(0), "unStake", blsKey)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+
+	setEpoch(world, 14)
+	vmOutput = callSystemSC(t, world, ValidatorSCAddress, esdtSCTestOwner, big.NewInt(0), "unBond", blsKey)
+	require.Equal(t, vmcommon.UserError, vmOutput.ReturnCode)
+
+	setEpoch(world, 15)
+	vmOutput = callSystemSC(t, world, ValidatorSCAddress, esdtSCTestOwner, big.NewInt(0), "unBond", blsKey)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	requireBalanceDelta(t, vmOutput, esdtSCTestOwner, egld(2500))
+	require.Equal(t, egld(100), staking.GetTotalStaked(esdtSCTestOwner))
+}
+
+func TestDelegationSCMock_DelegateAndWithdraw(t *testing.T) {
+	t.Parallel()
+
+	world := NewMockWorld()
+	manager := world.RegisterDelegationManagerSC()
+
+	vmOutput := callSystemSC(t, world, DelegationManagerSCAddress, esdtSCTestOwner, egld(1250), "createNewDelegationContract", []byte{}, big.NewInt(1000).Bytes())
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	contractAddress := vmOutput.ReturnData[0]
+	require.True(t, IsSystemSCAddress(contractAddress))
+	require.Equal(t, [][]byte{contractAddress}, manager.GetContractAddresses())
+	requireBalanceDelta(t, vmOutput, contractAddress, egld(1250))
+	requireBalanceDelta(t, vmOutput, DelegationManagerSCAddress, big.NewInt(0))
+
+	vmOutput = callSystemSC(t, world, contractAddress, esdtSCTestHolder, big.NewInt(1), "delegate")
+	require.Equal(t, vmcommon.UserError, vmOutput.ReturnCode)
+
+	vmOutput = callSystemSC(t, world, contractAddress, esdtSCTestHolder, egld(750), "delegate")
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+
+	vmOutput = callSystemSC(t, world, contractAddress, esdtSCTestHolder, big.NewInt(0), "getTotalActiveStake")
+	require.Equal(t, [][]byte{egld(2000).Bytes()}, vmOutput.ReturnData)
+
+	require.Nil(t, manager.Delegation.DistributeRewards(contractAddress, egld(200)))
+	vmOutput = callSystemSC(t, world, contractAddress, esdtSCTestHolder, big.NewInt(0), "getClaimableRewards", esdtSCTestHolder)
+	require.Equal(t, [][]byte{big.NewInt(0).Div(egld(675), big.NewInt(10)).Bytes()}, vmOutput.ReturnData)
+
+	vmOutput = callSystemSC(t, world, contractAddress, esdtSCTestHolder, big.NewInt(0), "claimRewards")
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	transfers := vmOutput.OutputAccounts[string(esdtSCTestHolder)].OutputTransfers
+	require.Len(t, transfers, 1)
+	require.Equal(t, big.NewInt(0).Div(egld(675), big.NewInt(10)), transfers[0].Value)
+
+	vmOutput = callSystemSC(t, world, contractAddress, esdtSCTestHolder, big.NewInt(0), "unDelegate", big.NewInt(0).Sub(egld(750), big.NewInt(1)).Bytes())
+	require.Equal(t, "invalid value to undelegate - need to undelegate all - remaining is under minimum", vmOutput.ReturnMessage)
+
+	vmOutput = callSystemSC(t, world, contractAddress, esdtSCTestHolder, big.NewInt(0), "unDelegate", egld(750).Bytes())
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+
+	vmOutput = callSystemSC(t, world, contractAddress, esdtSCTestHolder, big.NewInt(0), "withdraw")
+	require.Equal(t, "nothing to unBond", vmOutput.ReturnMessage)
+
+	setEpoch(world, DefaultUnBondPeriodEpochs)
+	vmOutput = callSystemSC(t, world, contractAddress, esdtSCTestHolder, big.NewInt(0), "withdraw")
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	requireBalanceDelta(t, vmOutput, esdtSCTestHolder, egld(750))
+	require.Zero(t, manager.Delegation.GetUserActiveStake(contractAddress, esdtSCTestHolder).Sign())
+}
+
+func TestGovernanceSCMock_ProposalAndVotes(t *testing.T) {
+	t.Parallel()
+
+	world := NewMockWorld()
+	world.RegisterSystemSCs()
+	commitHash := []byte("0123456789012345678901234567890123456789")
+
+	vmOutput := callSystemSC(t, world, GovernanceSCAddress, esdtSCTestOwner, egld(1000), "proposal", commitHash, []byte{1}, []byte{2})
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	nonce := vmOutput.ReturnData[0]
+
+	vmOutput = callSystemSC(t, world, GovernanceSCAddress, esdtSCTestHolder, big.NewInt(0), "vote", nonce, []byte(VoteYes))
+	require.Equal(t, "proposal is not in the voting period", vmOutput.ReturnMessage)
+
+	setEpoch(world, 1)
+	vmOutput = callSystemSC(t, world, GovernanceSCAddress, esdtSCTestHolder, big.NewInt(0), "vote", nonce, []byte(VoteYes))
+	require.Equal(t, "not enough voting power to cast a vote", vmOutput.ReturnMessage)
+
+	vmOutput = callSystemSC(t, world, ValidatorSCAddress, esdtSCTestHolder, egld(2500), "stake", []byte{1}, []byte("bls_key"), []byte("signature"))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	vmOutput = callSystemSC(t, world, GovernanceSCAddress, esdtSCTestHolder, big.NewInt(0), "vote", nonce, []byte(VoteYes))
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	vmOutput = callSystemSC(t, world, GovernanceSCAddress, esdtSCTestHolder, big.NewInt(0), "vote", nonce, []byte(VoteNo))
+	require.Equal(t, "double vote is not allowed", vmOutput.ReturnMessage)
+
+	setEpoch(world, 3)
+	vmOutput = callSystemSC(t, world, GovernanceSCAddress, esdtSCTestOwner, big.NewInt(0), "closeProposal", nonce)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	requireBalanceDelta(t, vmOutput, esdtSCTestOwner, egld(1000))
+
+	proposal := world.GovernanceSC.GetProposal(1)
+	require.True(t, proposal.Closed)
+	require.True(t, proposal.Passed)
+	require.Equal(t, egld(2500), proposal.Yes)
+}
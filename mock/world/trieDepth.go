@@ -0,0 +1,179 @@
+package worldmock
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+)
+
+// dataTriesMutex guards the simulated data tries of the accounts, which the storage reads build lazily
+var dataTriesMutex sync.Mutex
+
+// StorageTrieDepth returns the depth at which the data trie of the account finds the key, or gives up looking for it,
+// with the root at depth 0. The trie only keeps the shape the node gives its Patricia-Merkle data tries, where the keys
+// are hashed and split in nibbles, the branch nodes have one child per nibble and the extension nodes hold the prefixes
+// shared by all the keys below them. It is built from the storage on the first read of the account, then kept up to
+// date by the writes which go through SetStorageValue, SaveKeyValue and UpdateAccounts.
+func (b *MockWorld) StorageTrieDepth(account *Account, key []byte) uint32 {
+	if account == nil {
+		return 0
+	}
+
+	dataTriesMutex.Lock()
+	defer dataTriesMutex.Unlock()
+
+	if account.dataTrie == nil {
+		account.dataTrie = newDataTrie(account.Storage)
+	}
+
+	return computeTrieDepth(trieKey(string(key)), account.dataTrie.sortedPaths)
+}
+
+// SetStorageValue writes a value into the storage of the account, and into its simulated data trie.
+// The code writing the Storage map directly, after the trie was built, has to call ResetDataTrie.
+func (a *Account) SetStorageValue(key string, value []byte) {
+	a.Storage[key] = value
+	a.updateDataTrie(key, value)
+}
+
+// ResetDataTrie drops the simulated data trie of the account, which the next storage read rebuilds from the storage.
+func (a *Account) ResetDataTrie() {
+	dataTriesMutex.Lock()
+	a.dataTrie = nil
+	dataTriesMutex.Unlock()
+}
+
+func (a *Account) updateDataTrie(key string, value []byte) {
+	dataTriesMutex.Lock()
+	defer dataTriesMutex.Unlock()
+
+	if a.dataTrie == nil {
+		return
+	}
+
+	_, inTrie := a.dataTrie.paths[key]
+	switch {
+	case len(value) == 0 && inTrie:
+		// empty values are removed from the trie
+		a.dataTrie.remove(key)
+	case len(value) > 0 && !inTrie:
+		a.dataTrie.insert(key)
+	}
+}
+
+// dataTrie keeps the paths of the keys in the data trie of an account, sorted, so that the keys below
+// any node of the trie are next to each other.
+type dataTrie struct {
+	paths       map[string][]byte
+	sortedPaths [][]byte
+}
+
+func newDataTrie(storage map[string][]byte) *dataTrie {
+	trie := &dataTrie{
+		paths:       make(map[string][]byte, len(storage)),
+		sortedPaths: make([][]byte, 0, len(storage)),
+	}
+	for key, value := range storage {
+		if len(value) == 0 {
+			continue
+		}
+
+		path := trieKey(key)
+		trie.paths[key] = path
+		trie.sortedPaths = append(trie.sortedPaths, path)
+	}
+	sort.Slice(trie.sortedPaths, func(i, j int) bool {
+		return bytes.Compare(trie.sortedPaths[i], trie.sortedPaths[j]) < 0
+	})
+
+	return trie
+}
+
+func (trie *dataTrie) insert(key string) {
+	path := trieKey(key)
+	trie.paths[key] = path
+
+	index := trie.search(path)
+	trie.sortedPaths = append(trie.sortedPaths, nil)
+	copy(trie.sortedPaths[index+1:], trie.sortedPaths[index:])
+	trie.sortedPaths[index] = path
+}
+
+func (trie *dataTrie) remove(key string) {
+	path := trie.paths[key]
+	delete(trie.paths, key)
+
+	index := trie.search(path)
+	trie.sortedPaths = append(trie.sortedPaths[:index], trie.sortedPaths[index+1:]...)
+}
+
+func (trie *dataTrie) search(path []byte) int {
+	return sort.Search(len(trie.sortedPaths), func(i int) bool {
+		return bytes.Compare(trie.sortedPaths[i], path) >= 0
+	})
+}
+
+// trieKey returns the path of a storage key in the data trie, as the nibbles of its hash
+func trieKey(key string) []byte {
+	return keyBytesToNibbles(DefaultHasher.Compute(key))
+}
+
+// computeTrieDepth walks down the trie of the sorted keys along the target path, counting the nodes passed
+func computeTrieDepth(target []byte, sortedKeys [][]byte) uint32 {
+	depth := uint32(0)
+	position := 0
+	keys := sortedKeys
+	for len(keys) > 1 {
+		// the keys are sorted, so the first and the last share the prefix of all of them
+		sharedLength := sharedPrefixLength(keys[0], keys[len(keys)-1], position)
+		if sharedLength > 0 {
+			// extension node
+			if !bytes.Equal(target[position:position+sharedLength], keys[0][position:position+sharedLength]) {
+				return depth
+			}
+			depth++
+			position += sharedLength
+		}
+
+		// branch node
+		keys = keysWithNibble(keys, position, target[position])
+		if len(keys) == 0 {
+			return depth
+		}
+		depth++
+		position++
+	}
+
+	// the leaf, or the empty trie
+	return depth
+}
+
+func sharedPrefixLength(first []byte, last []byte, position int) int {
+	length := 0
+	for position+length < len(first) && first[position+length] == last[position+length] {
+		length++
+	}
+
+	return length
+}
+
+// keysWithNibble returns the keys having the nibble at the position, from sorted keys which share the prefix before it
+func keysWithNibble(keys [][]byte, position int, nibble byte) [][]byte {
+	start := sort.Search(len(keys), func(i int) bool {
+		return keys[i][position] >= nibble
+	})
+	end := sort.Search(len(keys), func(i int) bool {
+		return keys[i][position] > nibble
+	})
+
+	return keys[start:end]
+}
+
+func keyBytesToNibbles(key []byte) []byte {
+	nibbles := make([]byte, 0, 2*len(key))
+	for _, b := range key {
+		nibbles = append(nibbles, b/16, b%16)
+	}
+
+	return nibbles
+}
@@ -0,0 +1,135 @@
+package worldmock
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeTrieDepth(t *testing.T) {
+	t.Parallel()
+
+	keyA := []byte{1, 2, 3, 4}
+	keyB := []byte{1, 2, 5, 6}
+	keyC := []byte{7, 0, 0, 0}
+
+	require.Equal(t, uint32(0), computeTrieDepth(keyA, nil))
+	require.Equal(t, uint32(0), computeTrieDepth(keyA, [][]byte{keyA}))
+
+	// extension 1,2 -> branch -> leaf
+	require.Equal(t, uint32(2), computeTrieDepth(keyA, [][]byte{keyA, keyB}))
+	// branch -> extension 2 -> branch -> leaf
+	require.Equal(t, uint32(3), computeTrieDepth(keyA, [][]byte{keyA, keyB, keyC}))
+	require.Equal(t, uint32(1), computeTrieDepth(keyC, [][]byte{keyA, keyB, keyC}))
+
+	// missing keys stop where the path leaves the trie
+	require.Equal(t, uint32(0), computeTrieDepth([]byte{9, 0, 0, 0}, [][]byte{keyA, keyB}))
+	require.Equal(t, uint32(2), computeTrieDepth([]byte{1, 2, 9, 0}, [][]byte{keyA, keyB, keyC}))
+}
+
+func TestMockWorld_GetStorageDataTrieDepth(t *testing.T) {
+	t.Parallel()
+
+	world := NewMockWorld()
+	account := world.AcctMap.CreateAccount(esdtSCTestOwner, world)
+	account.Storage["key"] = []byte("value")
+
+	_, trieDepth, err := world.GetStorageData(esdtSCTestOwner, []byte("key"))
+	require.Nil(t, err)
+	require.Equal(t, uint32(0), trieDepth)
+
+	world.SimulateTrieDepth = true
+	_, trieDepth, err = world.GetStorageData(esdtSCTestOwner, []byte("key"))
+	require.Nil(t, err)
+	require.Equal(t, uint32(0), trieDepth)
+
+	for i := 0; i < 5000; i++ {
+		account.SetStorageValue(fmt.Sprintf("key%d", i), []byte("value"))
+	}
+	_, trieDepth, err = world.GetStorageData(esdtSCTestOwner, []byte("key"))
+	require.Nil(t, err)
+	require.GreaterOrEqual(t, trieDepth, uint32(3))
+	require.LessOrEqual(t, trieDepth, uint32(6))
+
+	// removed keys are not in the trie
+	for i := 0; i < 5000; i++ {
+		account.SetStorageValue(fmt.Sprintf("key%d", i), []byte{})
+	}
+	_, trieDepth, err = world.GetStorageData(esdtSCTestOwner, []byte("key"))
+	require.Nil(t, err)
+	require.Equal(t, uint32(0), trieDepth)
+}
+
+func TestMockWorld_StorageTrieDepthFollowsStorageWrites(t *testing.T) {
+	t.Parallel()
+
+	world := NewMockWorld()
+	account := world.AcctMap.CreateAccount(esdtSCTestOwner, world)
+	freshDepth := func(key string) uint32 {
+		return computeTrieDepth(trieKey(key), newDataTrie(account.Storage).sortedPaths)
+	}
+	requireSameDepths := func() {
+		for i := 0; i < 300; i += 7 {
+			key := fmt.Sprintf("key%d", i)
+			require.Equal(t, freshDepth(key), world.StorageTrieDepth(account, []byte(key)), key)
+		}
+	}
+
+	for i := 0; i < 200; i++ {
+		account.Storage[fmt.Sprintf("key%d", i)] = []byte("value")
+	}
+	requireSameDepths()
+
+	for i := 0; i < 200; i += 3 {
+		require.Nil(t, account.SaveKeyValue([]byte(fmt.Sprintf("key%d", i)), []byte{}))
+	}
+	storageUpdates := make(map[string]*vmcommon.StorageUpdate)
+	for i := 200; i < 300; i++ {
+		key := fmt.Sprintf("key%d", i)
+		storageUpdates[key] = &vmcommon.StorageUpdate{Offset: []byte(key), Data: []byte("value")}
+	}
+	err := world.UpdateAccounts(map[string]*vmcommon.OutputAccount{
+		string(esdtSCTestOwner): {Address: esdtSCTestOwner, StorageUpdates: storageUpdates},
+	}, nil)
+	require.Nil(t, err)
+	require.Nil(t, account.SetTokenBalance([]byte("TOKEN-123456"), 0, big.NewInt(5)))
+	requireSameDepths()
+
+	// the storage written directly is only seen once the trie is reset
+	account.Storage = map[string][]byte{"key0": []byte("value")}
+	account.ResetDataTrie()
+	requireSameDepths()
+	require.Len(t, account.dataTrie.sortedPaths, 1)
+
+	// the cleared world drops the accounts, along with their tries
+	world.Clear()
+	require.Nil(t, world.AcctMap.GetAccount(esdtSCTestOwner))
+}
+
+func TestMockWorld_StorageTrieDepthConcurrentReads(t *testing.T) {
+	t.Parallel()
+
+	world := NewMockWorld()
+	world.SimulateTrieDepth = true
+	account := world.AcctMap.CreateAccount(esdtSCTestOwner, world)
+	for i := 0; i < 100; i++ {
+		account.Storage[fmt.Sprintf("key%d", i)] = []byte("value")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_, _, err := world.GetStorageData(esdtSCTestOwner, []byte(fmt.Sprintf("key%d", j)))
+				require.Nil(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -34,6 +34,9 @@ type Account struct {
 	ShardID         uint32
 	IsSmartContract bool
 	MockWorld       *MockWorld
+
+	// the simulated data trie of the storage, built by the first storage read with SimulateTrieDepth
+	dataTrie *dataTrie
 }
 
 var storageDefaultValue = make([]byte, 0)
@@ -227,7 +230,7 @@ func (a *Account) MigrateDataTrieLeaves(_ vmcommon.ArgsMigrateDataTrieLeaves) er
 
 // SaveKeyValue -
 func (a *Account) SaveKeyValue(key []byte, value []byte) error {
-	a.Storage[string(key)] = value
+	a.SetStorageValue(string(key), value)
 	if a.MockWorld == nil {
 		return ErrNilWorldMock
 	}
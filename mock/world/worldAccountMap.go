@@ -127,6 +127,7 @@ func (am AccountMap) LoadAccountStorageFrom(otherAM AccountMap) error {
 				hex.EncodeToString([]byte(address)))
 		}
 		account.Storage = otherAccount.Storage
+		account.ResetDataTrie()
 	}
 
 	return nil
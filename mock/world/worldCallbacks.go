@@ -51,6 +51,12 @@ func (b *MockWorld) GetStorageData(accountAddress []byte, key []byte) ([]byte, u
 		return nil, 0, b.Err
 	}
 
+	// injected fault
+	err := b.Faults.Check(FaultGetStorageData, accountAddress, key, "")
+	if err != nil {
+		return nil, 0, err
+	}
+
 	acct := b.AcctMap.GetAccount(accountAddress)
 	if acct == nil {
 		return []byte{}, 0, nil
@@ -64,7 +70,12 @@ func (b *MockWorld) GetStorageData(accountAddress []byte, key []byte) ([]byte, u
 		}
 	}
 
-	return foundValue, 0, nil
+	trieDepth := uint32(0)
+	if b.SimulateTrieDepth {
+		trieDepth = b.StorageTrieDepth(acct, key)
+	}
+
+	return foundValue, trieDepth, nil
 }
 
 // GetBlockhash should return the hash of the nth previous blockchain.
@@ -190,6 +201,12 @@ func (b *MockWorld) GetESDTToken(address []byte, tokenIdentifier []byte, nonce u
 		return nil, b.Err
 	}
 
+	// injected fault
+	err := b.Faults.Check(FaultGetESDTToken, address, tokenIdentifier, "")
+	if err != nil {
+		return nil, err
+	}
+
 	if b.BuiltinFuncs == nil {
 		return nil, ErrBuiltinFuncWrapperNotInitialized
 	}
@@ -291,14 +308,14 @@ func (b *MockWorld) ClearCompiledCodes() {
 	b.CompiledCode = make(map[string][]byte)
 }
 
-// IsPaused -
-func (b *MockWorld) IsPaused(_ []byte) bool {
-	return b.IsPausedValue
+// IsPaused returns true if the token is paused, or if IsPausedValue pauses all the tokens
+func (b *MockWorld) IsPaused(tokenID []byte) bool {
+	return b.IsPausedValue || b.GetESDTGlobalSettings(tokenID).Paused
 }
 
-// IsLimitedTransfer -
-func (b *MockWorld) IsLimitedTransfer(_ []byte) bool {
-	return b.IsLimitedTransferValue
+// IsLimitedTransfer returns true if the token has limited transfers, or if IsLimitedTransferValue limits all the tokens
+func (b *MockWorld) IsLimitedTransfer(tokenID []byte) bool {
+	return b.IsLimitedTransferValue || b.GetESDTGlobalSettings(tokenID).LimitedTransfer
 }
 
 // IsInterfaceNil returns true if underlying implementation is nil
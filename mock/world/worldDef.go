@@ -52,6 +52,20 @@ type MockWorld struct {
 	ProvidedBlockchainHook     vmcommon.BlockchainHook
 	EnableEpochsHandler        vmcommon.EnableEpochsHandler
 	OtherVMOutputMap           map[string]*vmcommon.VMOutput
+	OtherVMHandlers            map[string]OtherVMHandler
+	Faults                     *FaultInjector
+	ESDTSystemSC               *ESDTSystemSCMock
+	StakingSC                  *StakingSCMock
+	DelegationManagerSC        *DelegationManagerSCMock
+	GovernanceSC               *GovernanceSCMock
+	BlockProduction            *BlockProducer
+
+	// TxGuardians holds the guardians co-signing the transactions of the senders, by sender address
+	TxGuardians map[string][]byte
+
+	// SimulateTrieDepth makes GetStorageData return the depth of the key in a simulated data trie,
+	// instead of 0, so that the dynamic storage load gas is charged as on a real node
+	SimulateTrieDepth bool
 }
 
 // NewMockWorld creates a new MockWorld instance
@@ -69,9 +83,11 @@ func NewMockWorld() *MockWorld {
 		BuiltinFuncs:        nil,
 		EnableEpochsHandler: EnableEpochsHandlerStubAllFlags(),
 		OtherVMOutputMap:    make(map[string]*vmcommon.VMOutput),
+		OtherVMHandlers:     make(map[string]OtherVMHandler),
+		Faults:              NewFaultInjector(),
 	}
 	world.AccountsAdapter = NewMockAccountsAdapter(world)
-	world.GuardedAccountHandler = NewMockGuardedAccountHandler()
+	world.GuardedAccountHandler = NewGuardianHandler(world)
 
 	return world
 }
@@ -100,8 +116,11 @@ func (b *MockWorld) Clear() {
 	b.PreviousBlockInfo = nil
 	b.CurrentBlockInfo = nil
 	b.Blockhashes = nil
+	b.BlockProduction = nil
+	b.TxGuardians = nil
 	b.NewAddressMocks = nil
 	b.CompiledCode = make(map[string][]byte)
+	b.Faults = NewFaultInjector()
 }
 
 // SetCurrentBlockHash -
@@ -159,10 +178,20 @@ func (b *MockWorld) RevertToSnapshot(snapshot int) error {
 
 // ExecuteSmartContractCallOnOtherVM -
 func (b *MockWorld) ExecuteSmartContractCallOnOtherVM(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	faultOutput, err := b.Faults.CheckOutput(FaultExecuteOnOtherVM, input.RecipientAddr, nil, input.Function)
+	if err != nil || faultOutput != nil {
+		return faultOutput, err
+	}
+
 	vmType, err := vmcommon.ParseVMTypeFromContractAddress(input.RecipientAddr)
 	if err != nil {
 		return nil, err
 	}
+	handler := b.GetOtherVM(vmType)
+	if handler != nil {
+		return handler.ExecuteCall(input)
+	}
+
 	vmOutput := b.OtherVMOutputMap[string(vmType)]
 	if vmOutput == nil {
 		return &vmcommon.VMOutput{}, nil
@@ -0,0 +1,391 @@
+package worldmock
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// FaultPoint identifies a blockchain hook or builtin function entry point where a fault can be injected.
+type FaultPoint string
+
+const (
+	// FaultGetStorageData targets storage reads through GetStorageData.
+	FaultGetStorageData FaultPoint = "GetStorageData"
+
+	// FaultProcessBuiltInFunction targets builtin function calls.
+	FaultProcessBuiltInFunction FaultPoint = "ProcessBuiltInFunction"
+
+	// FaultGetESDTToken targets ESDT token data lookups.
+	FaultGetESDTToken FaultPoint = "GetESDTToken"
+
+	// FaultExecuteOnOtherVM targets calls forwarded to other VMs.
+	FaultExecuteOnOtherVM FaultPoint = "ExecuteSmartContractCallOnOtherVM"
+)
+
+// FaultInjectorAddress is the reserved address used by scenarios to configure fault rules.
+// Its storage is interpreted as named rules instead of being saved as an account.
+// It is the value of the scenario expression "address:fault-injector".
+var FaultInjectorAddress = []byte("fault-injector__________________")
+
+// ErrInjectedFault signals that a call failed because of a fault rule.
+var ErrInjectedFault = errors.New("injected fault")
+
+// ErrInvalidFaultRule signals that a fault rule could not be decoded.
+var ErrInvalidFaultRule = errors.New("invalid fault rule")
+
+// FaultRule describes which calls are made to fail and with which error.
+// Empty selectors match any value.
+type FaultRule struct {
+	Point FaultPoint
+
+	// Address is the account being read for storage and ESDT lookups,
+	// the caller for builtin functions and the recipient for calls on other VMs.
+	Address []byte
+
+	// Key is the storage key for storage reads and the token identifier for ESDT lookups.
+	Key []byte
+
+	// Function is the name of the builtin function or of the function called on another VM.
+	Function string
+
+	// Nth makes only the nth matching call fail, counting from 1. Zero makes all matching calls fail.
+	Nth uint64
+
+	// Err is the error returned by the failing call. ErrInjectedFault is used if nil.
+	Err error
+
+	// ReturnCode, when not Ok, makes the failing builtin function or call on another VM return
+	// a VMOutput with this return code and the message of Err, instead of returning Err.
+	ReturnCode vmcommon.ReturnCode
+
+	// Matched counts the calls that matched the selectors.
+	Matched uint64
+
+	// Triggered counts the calls that were made to fail.
+	Triggered uint64
+}
+
+// FailNthStorageRead creates a rule failing the nth storage read of the given key from the given account.
+func FailNthStorageRead(address []byte, key []byte, nth uint64) *FaultRule {
+	return &FaultRule{
+		Point:   FaultGetStorageData,
+		Address: address,
+		Key:     key,
+		Nth:     nth,
+	}
+}
+
+// FailBuiltinFunction creates a rule making all calls to the given builtin function return err.
+func FailBuiltinFunction(function string, err error) *FaultRule {
+	return &FaultRule{
+		Point:    FaultProcessBuiltInFunction,
+		Function: function,
+		Err:      err,
+	}
+}
+
+// FailBuiltinFunctionWithUserError creates a rule making all calls to the given builtin function
+// return a VMOutput with a user error and the given message, as the builtin functions rejecting a call.
+func FailBuiltinFunctionWithUserError(function string, message string) *FaultRule {
+	return &FaultRule{
+		Point:      FaultProcessBuiltInFunction,
+		Function:   function,
+		Err:        errors.New(message),
+		ReturnCode: vmcommon.UserError,
+	}
+}
+
+func (rule *FaultRule) matches(point FaultPoint, address []byte, key []byte, function string) bool {
+	if rule.Point != point {
+		return false
+	}
+	if len(rule.Address) > 0 && !bytes.Equal(rule.Address, address) {
+		return false
+	}
+	if len(rule.Key) > 0 && !bytes.Equal(rule.Key, key) {
+		return false
+	}
+	if len(rule.Function) > 0 && rule.Function != function {
+		return false
+	}
+
+	return true
+}
+
+func (rule *FaultRule) error() error {
+	if rule.Err == nil {
+		return ErrInjectedFault
+	}
+	return rule.Err
+}
+
+func (rule *FaultRule) output() *vmcommon.VMOutput {
+	return &vmcommon.VMOutput{
+		ReturnCode:    rule.ReturnCode,
+		ReturnMessage: rule.error().Error(),
+		GasRemaining:  0,
+		GasRefund:     big.NewInt(0),
+	}
+}
+
+// FaultRuleCounters holds the counters of a rule, saved by FaultInjector.SaveCounters.
+type FaultRuleCounters struct {
+	Matched   uint64
+	Triggered uint64
+}
+
+// FaultInjector holds named fault rules and decides which blockchain hook calls must fail.
+type FaultInjector struct {
+	mutex     sync.Mutex
+	ruleNames []string
+	rules     map[string]*FaultRule
+}
+
+// NewFaultInjector creates a FaultInjector without any rules.
+func NewFaultInjector() *FaultInjector {
+	return &FaultInjector{
+		ruleNames: make([]string, 0),
+		rules:     make(map[string]*FaultRule),
+	}
+}
+
+// SetRule adds a rule or replaces the rule with the same name.
+func (fi *FaultInjector) SetRule(name string, rule *FaultRule) {
+	fi.mutex.Lock()
+	defer fi.mutex.Unlock()
+
+	_, exists := fi.rules[name]
+	if !exists {
+		fi.ruleNames = append(fi.ruleNames, name)
+	}
+	fi.rules[name] = rule
+}
+
+// RemoveRule removes the rule with the given name, if any.
+func (fi *FaultInjector) RemoveRule(name string) {
+	fi.mutex.Lock()
+	defer fi.mutex.Unlock()
+
+	_, exists := fi.rules[name]
+	if !exists {
+		return
+	}
+
+	delete(fi.rules, name)
+	for i, ruleName := range fi.ruleNames {
+		if ruleName == name {
+			fi.ruleNames = append(fi.ruleNames[:i], fi.ruleNames[i+1:]...)
+			break
+		}
+	}
+}
+
+// GetRule returns the rule with the given name, or nil if not found.
+func (fi *FaultInjector) GetRule(name string) *FaultRule {
+	fi.mutex.Lock()
+	defer fi.mutex.Unlock()
+
+	return fi.rules[name]
+}
+
+// Clear removes all rules.
+func (fi *FaultInjector) Clear() {
+	fi.mutex.Lock()
+	defer fi.mutex.Unlock()
+
+	fi.ruleNames = make([]string, 0)
+	fi.rules = make(map[string]*FaultRule)
+}
+
+// Check updates the counters of all matching rules and returns the error of the
+// first one that is triggered by the current call, in the order the rules were added.
+func (fi *FaultInjector) Check(point FaultPoint, address []byte, key []byte, function string) error {
+	rule := fi.check(point, address, key, function)
+	if rule == nil {
+		return nil
+	}
+	return rule.error()
+}
+
+// CheckOutput is Check for the calls which produce a VMOutput: if the triggered rule has a return code,
+// the call must return the VMOutput, with all the provided gas consumed, instead of an error.
+func (fi *FaultInjector) CheckOutput(point FaultPoint, address []byte, key []byte, function string) (*vmcommon.VMOutput, error) {
+	rule := fi.check(point, address, key, function)
+	if rule == nil {
+		return nil, nil
+	}
+	if rule.ReturnCode != vmcommon.Ok {
+		return rule.output(), nil
+	}
+	return nil, rule.error()
+}
+
+func (fi *FaultInjector) check(point FaultPoint, address []byte, key []byte, function string) *FaultRule {
+	if fi == nil {
+		return nil
+	}
+
+	fi.mutex.Lock()
+	defer fi.mutex.Unlock()
+
+	var triggeredRule *FaultRule
+	for _, name := range fi.ruleNames {
+		rule := fi.rules[name]
+		if !rule.matches(point, address, key, function) {
+			continue
+		}
+
+		rule.Matched++
+		if rule.Nth != 0 && rule.Matched != rule.Nth {
+			continue
+		}
+
+		rule.Triggered++
+		if triggeredRule == nil {
+			triggeredRule = rule
+		}
+	}
+
+	return triggeredRule
+}
+
+// SaveCounters returns the counters of all rules, by rule name, so that the calls made by
+// a re-run of a transaction can be forgotten with RestoreCounters.
+func (fi *FaultInjector) SaveCounters() map[string]FaultRuleCounters {
+	if fi == nil {
+		return nil
+	}
+
+	fi.mutex.Lock()
+	defer fi.mutex.Unlock()
+
+	counters := make(map[string]FaultRuleCounters, len(fi.rules))
+	for name, rule := range fi.rules {
+		counters[name] = FaultRuleCounters{
+			Matched:   rule.Matched,
+			Triggered: rule.Triggered,
+		}
+	}
+	return counters
+}
+
+// RestoreCounters sets back the counters saved by SaveCounters, for the rules which still exist.
+func (fi *FaultInjector) RestoreCounters(counters map[string]FaultRuleCounters) {
+	if fi == nil {
+		return
+	}
+
+	fi.mutex.Lock()
+	defer fi.mutex.Unlock()
+
+	for name, rule := range fi.rules {
+		ruleCounters := counters[name]
+		rule.Matched = ruleCounters.Matched
+		rule.Triggered = ruleCounters.Triggered
+	}
+}
+
+// DecodeFaultRule decodes a fault rule from its serialized form, as used by scenarios.
+// The fields are nested-encoded, in order: point, address, key, function, nth (u64),
+// an optional error message and an optional return code (u64), for example:
+// "nested:str:GetStorageData|nested:sc:contract|nested:str:counter|nested:str:|u64:2|nested:str:storage failure"
+// or, for a builtin function returning a user error:
+// "nested:str:ProcessBuiltInFunction|nested:str:|nested:str:|nested:str:ESDTNFTTransfer|u64:0|nested:str:insufficient funds|u64:4"
+func DecodeFaultRule(data []byte) (*FaultRule, error) {
+	reader := &faultRuleReader{data: data}
+
+	point := reader.readNested()
+	address := reader.readNested()
+	key := reader.readNested()
+	function := reader.readNested()
+	nth := reader.readU64()
+	if reader.err != nil {
+		return nil, reader.err
+	}
+
+	rule := &FaultRule{
+		Point:    FaultPoint(point),
+		Address:  address,
+		Key:      key,
+		Function: string(function),
+		Nth:      nth,
+	}
+
+	if reader.remaining() > 0 {
+		message := reader.readNested()
+		if reader.err != nil {
+			return nil, reader.err
+		}
+		rule.Err = errors.New(string(message))
+	}
+
+	if reader.remaining() > 0 {
+		returnCode := reader.readU64()
+		if reader.err != nil {
+			return nil, reader.err
+		}
+		rule.ReturnCode = vmcommon.ReturnCode(returnCode)
+	}
+
+	if reader.remaining() > 0 {
+		return nil, fmt.Errorf("%w: %d unexpected trailing bytes", ErrInvalidFaultRule, reader.remaining())
+	}
+
+	switch rule.Point {
+	case FaultGetStorageData, FaultGetESDTToken:
+		if rule.ReturnCode != vmcommon.Ok {
+			return nil, fmt.Errorf("%w: fault point \"%s\" cannot return a VMOutput", ErrInvalidFaultRule, rule.Point)
+		}
+	case FaultProcessBuiltInFunction, FaultExecuteOnOtherVM:
+	default:
+		return nil, fmt.Errorf("%w: unknown fault point \"%s\"", ErrInvalidFaultRule, rule.Point)
+	}
+
+	return rule, nil
+}
+
+type faultRuleReader struct {
+	data   []byte
+	offset int
+	err    error
+}
+
+func (r *faultRuleReader) remaining() int {
+	return len(r.data) - r.offset
+}
+
+func (r *faultRuleReader) readBytes(length int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if r.remaining() < length {
+		r.err = fmt.Errorf("%w: unexpected end of data at offset %d", ErrInvalidFaultRule, r.offset)
+		return nil
+	}
+
+	result := r.data[r.offset : r.offset+length]
+	r.offset += length
+	return result
+}
+
+func (r *faultRuleReader) readNested() []byte {
+	lengthBytes := r.readBytes(4)
+	if r.err != nil {
+		return nil
+	}
+	return r.readBytes(int(binary.BigEndian.Uint32(lengthBytes)))
+}
+
+func (r *faultRuleReader) readU64() uint64 {
+	valueBytes := r.readBytes(8)
+	if r.err != nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(valueBytes)
+}
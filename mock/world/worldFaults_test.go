@@ -0,0 +1,152 @@
+package worldmock
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFaultInjector_FailNthStorageRead(t *testing.T) {
+	t.Parallel()
+
+	world := NewMockWorld()
+	address := []byte("contract________________________")
+	account := world.AcctMap.CreateAccount(address, world)
+	account.Storage["counter"] = []byte{5}
+
+	world.Faults.SetRule("second read", FailNthStorageRead(address, []byte("counter"), 2))
+
+	value, _, err := world.GetStorageData(address, []byte("counter"))
+	require.Nil(t, err)
+	require.Equal(t, []byte{5}, value)
+
+	_, _, err = world.GetStorageData(address, []byte("other"))
+	require.Nil(t, err)
+
+	_, _, err = world.GetStorageData(address, []byte("counter"))
+	require.Equal(t, ErrInjectedFault, err)
+
+	_, _, err = world.GetStorageData(address, []byte("counter"))
+	require.Nil(t, err)
+
+	rule := world.Faults.GetRule("second read")
+	require.Equal(t, uint64(3), rule.Matched)
+	require.Equal(t, uint64(1), rule.Triggered)
+}
+
+func TestFaultInjector_FailBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	world := NewMockWorld()
+	world.BuiltinFuncs = &BuiltinFunctionsWrapper{World: world}
+	userErr := errors.New("user error")
+	world.Faults.SetRule("nft transfer", FailBuiltinFunction("ESDTNFTTransfer", userErr))
+
+	_, err := world.ProcessBuiltInFunction(&vmcommon.ContractCallInput{
+		VMInput:  vmcommon.VMInput{CallerAddr: []byte("caller")},
+		Function: "ESDTNFTTransfer",
+	})
+	require.Equal(t, userErr, err)
+
+	world.Faults.RemoveRule("nft transfer")
+	require.Nil(t, world.Faults.GetRule("nft transfer"))
+}
+
+func TestFaultInjector_BuiltinFunctionUserError(t *testing.T) {
+	t.Parallel()
+
+	world := NewMockWorld()
+	world.BuiltinFuncs = &BuiltinFunctionsWrapper{World: world}
+	world.Faults.SetRule("nft transfer", FailBuiltinFunctionWithUserError("ESDTNFTTransfer", "insufficient funds"))
+
+	vmOutput, err := world.ProcessBuiltInFunction(&vmcommon.ContractCallInput{
+		VMInput:  vmcommon.VMInput{CallerAddr: []byte("caller"), GasProvided: 1000},
+		Function: "ESDTNFTTransfer",
+	})
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.UserError, vmOutput.ReturnCode)
+	require.Equal(t, "insufficient funds", vmOutput.ReturnMessage)
+	require.Equal(t, uint64(0), vmOutput.GasRemaining)
+}
+
+func TestFaultInjector_SaveAndRestoreCounters(t *testing.T) {
+	t.Parallel()
+
+	world := NewMockWorld()
+	address := []byte("contract________________________")
+	world.AcctMap.CreateAccount(address, world)
+	world.Faults.SetRule("second read", FailNthStorageRead(address, []byte("counter"), 2))
+
+	_, _, err := world.GetStorageData(address, []byte("counter"))
+	require.Nil(t, err)
+	counters := world.Faults.SaveCounters()
+
+	// a re-run consumes the nth read, which is triggered again once the counters are restored
+	_, _, err = world.GetStorageData(address, []byte("counter"))
+	require.Equal(t, ErrInjectedFault, err)
+	world.Faults.RestoreCounters(counters)
+
+	rule := world.Faults.GetRule("second read")
+	require.Equal(t, uint64(1), rule.Matched)
+	require.Equal(t, uint64(0), rule.Triggered)
+	_, _, err = world.GetStorageData(address, []byte("counter"))
+	require.Equal(t, ErrInjectedFault, err)
+}
+
+func TestDecodeFaultRule(t *testing.T) {
+	t.Parallel()
+
+	nested := func(data string) []byte {
+		result := make([]byte, 4, 4+len(data))
+		binary.BigEndian.PutUint32(result, uint32(len(data)))
+		return append(result, data...)
+	}
+	nth := make([]byte, 8)
+	binary.BigEndian.PutUint64(nth, 2)
+
+	data := append([]byte{}, nested("GetESDTToken")...)
+	data = append(data, nested("address")...)
+	data = append(data, nested("TOKEN-123456")...)
+	data = append(data, nested("")...)
+	data = append(data, nth...)
+
+	rule, err := DecodeFaultRule(data)
+	require.Nil(t, err)
+	require.Equal(t, FaultGetESDTToken, rule.Point)
+	require.Equal(t, []byte("address"), rule.Address)
+	require.Equal(t, []byte("TOKEN-123456"), rule.Key)
+	require.Equal(t, "", rule.Function)
+	require.Equal(t, uint64(2), rule.Nth)
+	require.Nil(t, rule.Err)
+
+	rule, err = DecodeFaultRule(append(data, nested("token failure")...))
+	require.Nil(t, err)
+	require.Equal(t, "token failure", rule.Err.Error())
+	require.Equal(t, vmcommon.Ok, rule.ReturnCode)
+
+	userError := make([]byte, 8)
+	binary.BigEndian.PutUint64(userError, uint64(vmcommon.UserError))
+	_, err = DecodeFaultRule(append(append(data, nested("token failure")...), userError...))
+	require.True(t, errors.Is(err, ErrInvalidFaultRule))
+
+	builtinData := append([]byte{}, nested("ProcessBuiltInFunction")...)
+	builtinData = append(builtinData, nested("")...)
+	builtinData = append(builtinData, nested("")...)
+	builtinData = append(builtinData, nested("ESDTNFTTransfer")...)
+	builtinData = append(builtinData, make([]byte, 8)...)
+	builtinData = append(builtinData, nested("insufficient funds")...)
+	rule, err = DecodeFaultRule(append(builtinData, userError...))
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.UserError, rule.ReturnCode)
+	require.Equal(t, "insufficient funds", rule.Err.Error())
+
+	_, err = DecodeFaultRule(data[:10])
+	require.True(t, errors.Is(err, ErrInvalidFaultRule))
+
+	data = append(nested("Unknown"), data[len(nested("GetESDTToken")):]...)
+	_, err = DecodeFaultRule(data)
+	require.True(t, errors.Is(err, ErrInvalidFaultRule))
+}
@@ -70,6 +70,11 @@ func (b *MockWorld) UpdateAccountFromOutputAccount(modAcct *vmcommon.OutputAccou
 	if acct == nil {
 		acct = b.AcctMap.CreateAccount(modAcct.Address, b)
 		acct.OwnerAddress = modAcct.CodeDeployerAddress
+		if IsSystemSCAddress(modAcct.Address) {
+			// the calls to the system SCs are routed to the system VM only if their accounts are smart contracts
+			acct.IsSmartContract = true
+			acct.ShardID = b.SelfShardID
+		}
 		b.AcctMap.PutAccount(acct)
 	}
 	acct.Exists = true
@@ -94,7 +99,7 @@ func (b *MockWorld) UpdateAccountFromOutputAccount(modAcct *vmcommon.OutputAccou
 	}
 
 	for _, stu := range modAcct.StorageUpdates {
-		acct.Storage[string(stu.Offset)] = stu.Data
+		acct.SetStorageValue(string(stu.Offset), stu.Data)
 	}
 }
 
@@ -113,3 +118,76 @@ func (b *MockWorld) CommitChanges() error {
 func (b *MockWorld) RollbackChanges() error {
 	return b.AccountsAdapter.RevertToSnapshot(0)
 }
+
+// WorldState is a copy of the state of the MockWorld changed by running transactions.
+type WorldState struct {
+	accounts                   AccountMap
+	previousBlockInfo          *BlockInfo
+	currentBlockInfo           *BlockInfo
+	blockhashes                [][]byte
+	blockProduction            *BlockProducer
+	txGuardians                map[string][]byte
+	lastCreatedContractAddress []byte
+	faultCounters              map[string]FaultRuleCounters
+}
+
+// SaveState copies the accounts, the block info, the block production, the transaction guardians
+// and the counters of the fault rules, so that the world can be brought back to them with RestoreState.
+func (b *MockWorld) SaveState() *WorldState {
+	state := &WorldState{
+		accounts:                   b.AcctMap.Clone(),
+		previousBlockInfo:          b.PreviousBlockInfo.clone(),
+		currentBlockInfo:           b.CurrentBlockInfo.clone(),
+		blockhashes:                append([][]byte(nil), b.Blockhashes...),
+		txGuardians:                cloneTxGuardians(b.TxGuardians),
+		lastCreatedContractAddress: b.LastCreatedContractAddress,
+		faultCounters:              b.Faults.SaveCounters(),
+	}
+	if b.BlockProduction != nil {
+		blockProduction := *b.BlockProduction
+		state.blockProduction = &blockProduction
+	}
+
+	return state
+}
+
+// RestoreState brings the world back to a state saved by SaveState. The same state can be restored more than once.
+func (b *MockWorld) RestoreState(state *WorldState) {
+	b.AcctMap = state.accounts.Clone()
+	b.PreviousBlockInfo = state.previousBlockInfo.clone()
+	b.CurrentBlockInfo = state.currentBlockInfo.clone()
+	b.Blockhashes = append([][]byte(nil), state.blockhashes...)
+	b.TxGuardians = cloneTxGuardians(state.txGuardians)
+	b.LastCreatedContractAddress = state.lastCreatedContractAddress
+	b.Faults.RestoreCounters(state.faultCounters)
+	b.BlockProduction = nil
+	if state.blockProduction != nil {
+		blockProduction := *state.blockProduction
+		b.BlockProduction = &blockProduction
+	}
+}
+
+func (bi *BlockInfo) clone() *BlockInfo {
+	if bi == nil {
+		return nil
+	}
+
+	clone := *bi
+	if bi.RandomSeed != nil {
+		randomSeed := *bi.RandomSeed
+		clone.RandomSeed = &randomSeed
+	}
+	return &clone
+}
+
+func cloneTxGuardians(txGuardians map[string][]byte) map[string][]byte {
+	if txGuardians == nil {
+		return nil
+	}
+
+	clone := make(map[string][]byte, len(txGuardians))
+	for sender, guardian := range txGuardians {
+		clone[sender] = guardian
+	}
+	return clone
+}
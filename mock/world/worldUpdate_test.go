@@ -0,0 +1,53 @@
+package worldmock
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockWorld_SaveAndRestoreState(t *testing.T) {
+	t.Parallel()
+
+	world := NewMockWorld()
+	address := []byte("account_________________________")
+	world.AcctMap.PutAccount(&Account{
+		Address: address,
+		Balance: big.NewInt(100),
+		Storage: map[string][]byte{"key": []byte("value")},
+	})
+	err := world.EnableBlockProduction(BlockProductionConfig{TxsPerBlock: 2, RoundsPerBlock: 1, RoundDuration: 6})
+	require.Nil(t, err)
+	world.TransactionExecuted()
+	world.SetTxGuardian(address, []byte("guardian"))
+	world.Faults.SetRule("rule", FailBuiltinFunction("ESDTTransfer", nil))
+
+	state := world.SaveState()
+
+	for i := 0; i < 2; i++ {
+		account := world.AcctMap.GetAccount(address)
+		account.Balance.SetInt64(0)
+		account.Storage["key"] = []byte("changed")
+		world.TransactionExecuted()
+		world.SetTxGuardian(address, nil)
+		world.LastCreatedContractAddress = []byte("contract")
+		_, _ = world.Faults.CheckOutput(FaultProcessBuiltInFunction, address, nil, "ESDTTransfer")
+		require.Equal(t, uint64(1), world.CurrentNonce())
+
+		world.RestoreState(state)
+
+		account = world.AcctMap.GetAccount(address)
+		require.Equal(t, big.NewInt(100), account.Balance)
+		require.Equal(t, []byte("value"), account.Storage["key"])
+		require.Equal(t, uint64(0), world.CurrentNonce())
+		require.Nil(t, world.CurrentBlockInfo)
+		require.Equal(t, []byte("guardian"), world.GetTxGuardian(address))
+		require.Nil(t, world.LastCreatedContractAddress)
+		require.Equal(t, FaultRuleCounters{}, world.Faults.SaveCounters()["rule"])
+
+		world.TransactionExecuted()
+		require.Equal(t, uint64(1), world.CurrentNonce())
+		world.RestoreState(state)
+	}
+}
@@ -0,0 +1,23 @@
+package scenarioexec
+
+import (
+	worldmock "github.com/multiversx/mx-chain-vm-go/mock/world"
+	"github.com/multiversx/mx-chain-vm-go/vmhost/contexts"
+)
+
+// InspectAsyncContexts decodes all the async contexts persisted in the storage of the world accounts
+// and flags the orphaned ones.
+func (ae *VMTestExecutor) InspectAsyncContexts() []*contexts.PersistedAsyncContext {
+	return InspectWorldAsyncContexts(ae.World)
+}
+
+// InspectWorldAsyncContexts decodes all the async contexts persisted in the storage of the
+// accounts of a MockWorld and flags the orphaned ones.
+func InspectWorldAsyncContexts(world *worldmock.MockWorld) []*contexts.PersistedAsyncContext {
+	storages := make(map[string]map[string][]byte, len(world.AcctMap))
+	for address, account := range world.AcctMap {
+		storages[address] = account.Storage
+	}
+
+	return contexts.InspectAsyncContexts(storages)
+}
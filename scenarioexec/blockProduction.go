@@ -0,0 +1,67 @@
+package scenarioexec
+
+import (
+	"fmt"
+	"math/big"
+
+	mj "github.com/multiversx/mx-chain-scenario-go/model"
+	worldmock "github.com/multiversx/mx-chain-vm-go/mock/world"
+)
+
+// the storage keys of the block producer account
+const (
+	blockProducerTxsPerBlock             = "txsPerBlock"
+	blockProducerRoundsPerBlock          = "roundsPerBlock"
+	blockProducerRoundDuration           = "roundDuration"
+	blockProducerRoundsPerEpoch          = "roundsPerEpoch"
+	blockProducerProduceBlocks           = "produceBlocks"
+	blockProducerProduceBlockAtTimestamp = "produceBlockAtTimestamp"
+)
+
+// setBlockProduction configures the block production of the world from the storage of the reserved
+// block producer account. The settings left out keep their default values, or their previous values
+// when the account is an update. The "produceBlocks" and "produceBlockAtTimestamp" keys move the chain
+// forward right away, after the block info of the step was set.
+func (ae *VMTestExecutor) setBlockProduction(scenAccount *mj.Account) error {
+	config := worldmock.DefaultBlockProductionConfig()
+	if scenAccount.Update && ae.World.BlockProduction != nil {
+		config = ae.World.BlockProduction.Config
+	}
+
+	settings := map[string]*uint64{
+		blockProducerTxsPerBlock:    &config.TxsPerBlock,
+		blockProducerRoundsPerBlock: &config.RoundsPerBlock,
+		blockProducerRoundDuration:  &config.RoundDuration,
+		blockProducerRoundsPerEpoch: &config.RoundsPerEpoch,
+	}
+	numBlocks := uint64(0)
+	timestamp := uint64(0)
+	settings[blockProducerProduceBlocks] = &numBlocks
+	settings[blockProducerProduceBlockAtTimestamp] = &timestamp
+
+	for _, stkvp := range scenAccount.Storage {
+		key := string(stkvp.Key.Value)
+		setting, found := settings[key]
+		if !found {
+			return fmt.Errorf("block producer: unknown setting \"%s\"", key)
+		}
+
+		value := big.NewInt(0).SetBytes(stkvp.Value.Value)
+		if !value.IsUint64() {
+			return fmt.Errorf("block producer: setting \"%s\" is too large", key)
+		}
+		*setting = value.Uint64()
+	}
+
+	err := ae.World.EnableBlockProduction(config)
+	if err != nil {
+		return fmt.Errorf("block producer: %w", err)
+	}
+
+	ae.World.ProduceBlocks(numBlocks)
+	if timestamp > 0 {
+		ae.World.ProduceBlockAtTimestamp(timestamp)
+	}
+
+	return nil
+}
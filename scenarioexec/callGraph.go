@@ -0,0 +1,52 @@
+package scenarioexec
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+)
+
+var unsafeFileNameCharacters = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// TakeLastCallGraph returns the call graph of the last contract execution and forgets it.
+// It returns nil if CallGraphDir is not set, or if no contract was executed since the previous call.
+func (ae *VMTestExecutor) TakeLastCallGraph() *vmhost.CallGraph {
+	if ae.vmHost == nil {
+		return nil
+	}
+	return ae.vmHost.CallGraphRecorder().TakeLastCallGraph()
+}
+
+// saveCallGraph writes the call graph of the last contract execution in CallGraphDir,
+// as <scenario>.<tx id>.dot and <scenario>.<tx id>.json.
+func (ae *VMTestExecutor) saveCallGraph(txIdent string) error {
+	callGraph := ae.TakeLastCallGraph()
+	if callGraph == nil {
+		return nil
+	}
+
+	scenarioName := unnamedScenario
+	if len(ae.scenarioNames) > 0 && len(ae.scenarioNames[0]) > 0 {
+		scenarioName = ae.scenarioNames[0]
+	}
+	baseName := unsafeFileNameCharacters.ReplaceAllString(scenarioName+"."+txIdent, "_")
+	basePath := filepath.Join(ae.CallGraphDir, baseName)
+
+	err := os.MkdirAll(ae.CallGraphDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(basePath+".dot", []byte(callGraph.ToDOT()), 0644)
+	if err != nil {
+		return err
+	}
+
+	jsonData, err := callGraph.ToJSON()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(basePath+".json", append(jsonData, '\n'), 0644)
+}
@@ -2,6 +2,7 @@ package scenarioexec
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/multiversx/mx-chain-core-go/core"
 	logger "github.com/multiversx/mx-chain-logger-go"
@@ -30,11 +31,37 @@ type VMTestExecutor struct {
 	World              *worldhook.MockWorld
 	vm                 vmi.VMExecutionHandler
 	OverrideVMExecutor executor.ExecutorAbstractFactory
+	GasSweep           *GasSweepOptions
+	GasReportWriter    io.Writer
+	EstimateGas        bool
+	GasSnapshot        *GasSnapshot
+	UpdateExpectations bool
+	CallGraphDir       string
+	DebugPrintWriter   io.Writer
+	OpcodeTraceWriter  io.Writer
+	systemSCs          bool
 	vmHost             vmhost.VMHost
 	checkGas           bool
 	scenarioTraceGas   []bool
+	scenarioNames      []string
+	scenarioPaths      []string
 	fileResolver       fr.FileResolver
 	exprReconstructor  er.ExprReconstructor
+
+	// CheckESDTGlobalSettings makes checkState compare the global settings of the tokens (pause, limited transfer,
+	// burn for all), kept in the storage of the system account, which is otherwise left out like all protected keys
+	CheckESDTGlobalSettings bool
+
+	flagOverrides       map[string]bool
+	flagsBeforeScenario *worldhook.EnableEpochsHandlerStub
+	flagMatrixRun       *FlagMatrixRun
+
+	initialGasSchedule           config.GasScheduleMap
+	gasScheduleActivations       []*GasScheduleActivation
+	appliedGasScheduleActivation *GasScheduleActivation
+	currentGasSchedule           config.GasScheduleMap
+	gasScheduleChanged           bool
+	numGasScheduleChanges        uint64
 }
 
 var _ mc.TestExecutor = (*VMTestExecutor)(nil)
@@ -54,6 +81,13 @@ func NewVMTestExecutor() (*VMTestExecutor, error) {
 	}, nil
 }
 
+// EnableSystemSCs installs the Go stand-ins of the system SCs in the world, so that the scenarios can call them.
+// Without them, the calls to the system SC addresses run the code deployed there by the scenarios.
+func (ae *VMTestExecutor) EnableSystemSCs() {
+	ae.World.RegisterSystemSCs()
+	ae.systemSCs = true
+}
+
 // InitVM will initialize the VM and the builtin function container.
 // Does nothing if the VM is already initialized.
 func (ae *VMTestExecutor) InitVM(scenGasSchedule mj.GasSchedule) error {
@@ -88,6 +122,10 @@ func (ae *VMTestExecutor) InitVM(scenGasSchedule mj.GasSchedule) error {
 			EnableEpochsHandler:      ae.World.EnableEpochsHandler,
 			WasmerSIGSEGVPassthrough: false,
 			Hasher:                   worldhook.DefaultHasher,
+			RecordCallGraph:          len(ae.CallGraphDir) > 0,
+			EnableDebugHooks:         ae.DebugPrintWriter != nil,
+			DebugPrintWriter:         ae.DebugPrintWriter,
+			OpcodeTraceWriter:        ae.OpcodeTraceWriter,
 		})
 	if err != nil {
 		return err
@@ -95,6 +133,7 @@ func (ae *VMTestExecutor) InitVM(scenGasSchedule mj.GasSchedule) error {
 
 	ae.vm = vm
 	ae.vmHost = vm
+	ae.initialGasSchedule = gasSchedule
 	return nil
 }
 
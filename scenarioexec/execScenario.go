@@ -2,6 +2,7 @@ package scenarioexec
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	mc "github.com/multiversx/mx-chain-scenario-go/controller"
@@ -18,6 +19,8 @@ func (ae *VMTestExecutor) Reset() {
 		ae.vmHost.Reset()
 	}
 	ae.World.Clear()
+	ae.restoreEnableEpochsFlags()
+	ae.resetGasSchedule()
 }
 
 // Close will simply close the VM
@@ -31,6 +34,12 @@ func (ae *VMTestExecutor) Close() {
 func (ae *VMTestExecutor) RunScenario(scenario *mj.Scenario, fileResolver fr.FileResolver) error {
 	ae.fileResolver = fileResolver
 	ae.checkGas = scenario.CheckGas
+	ae.scenarioNames = append(ae.scenarioNames, scenario.Name)
+	ae.scenarioPaths = append(ae.scenarioPaths, scenarioPath(fileResolver))
+	defer func() {
+		ae.scenarioNames = ae.scenarioNames[:len(ae.scenarioNames)-1]
+		ae.scenarioPaths = ae.scenarioPaths[:len(ae.scenarioPaths)-1]
+	}()
 	resetGasTracesIfNewTest(ae, scenario)
 
 	err := ae.InitVM(scenario.GasSchedule)
@@ -91,7 +100,12 @@ func (ae *VMTestExecutor) ExecuteExternalStep(step *mj.ExternalStepsStep) error
 	extAbsPth := ae.fileResolver.ResolveAbsolutePath(step.Path)
 	setExternalStepGasTracing(ae, step)
 
-	err := externalStepsRunner.RunSingleJSONScenario(extAbsPth, mc.DefaultRunScenarioOptions())
+	var err error
+	if ae.UpdateExpectations {
+		err = ae.runAndUpdateScenarioFile(extAbsPth, clonedFileResolver)
+	} else {
+		err = externalStepsRunner.RunSingleJSONScenario(extAbsPth, mc.DefaultRunScenarioOptions())
+	}
 	if err != nil {
 		return err
 	}
@@ -107,7 +121,21 @@ func (ae *VMTestExecutor) ExecuteSetStateStep(step *mj.SetStateStep) error {
 		log.Trace("SetStateStep", "comment", step.Comment)
 	}
 
+	settingsAfterBlockInfo := make(map[*settingsAccount]*mj.Account)
 	for _, scenAccount := range step.Accounts {
+		settings := findSettingsAccount(scenAccount.Address.Value)
+		if settings != nil && settings.afterBlockInfo {
+			settingsAfterBlockInfo[settings] = scenAccount
+			continue
+		}
+		if settings != nil {
+			err := settings.apply(ae, scenAccount)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
 		if scenAccount.Update {
 			err := ae.UpdateAccount(scenAccount)
 			if err != nil {
@@ -126,7 +154,22 @@ func (ae *VMTestExecutor) ExecuteSetStateStep(step *mj.SetStateStep) error {
 	// replace block info
 	ae.World.PreviousBlockInfo = convertBlockInfo(step.PreviousBlockInfo, ae.World.PreviousBlockInfo)
 	ae.World.CurrentBlockInfo = convertBlockInfo(step.CurrentBlockInfo, ae.World.CurrentBlockInfo)
-	ae.World.Blockhashes = step.BlockHashes.ToValues()
+	if ae.World.BlockProduction == nil || !step.BlockHashes.IsUnspecified() {
+		// the produced block hashes are kept, unless the step replaces them
+		ae.World.Blockhashes = step.BlockHashes.ToValues()
+	}
+
+	for _, settings := range settingsAccounts {
+		scenAccount, ok := settingsAfterBlockInfo[settings]
+		if !ok {
+			continue
+		}
+
+		err := settings.apply(ae, scenAccount)
+		if err != nil {
+			return err
+		}
+	}
 
 	// append NewAddressMocks
 	err := validateNewAddressMocks(step.NewAddressMocks)
@@ -148,15 +191,54 @@ func (ae *VMTestExecutor) ExecuteTxStep(step *mj.TxStep) (*vmi.VMOutput, error)
 
 	if step.DisplayLogs {
 		vmhost.SetLoggingForTests()
+		defer vmhost.DisableLoggingForTests()
+	}
+
+	err := ae.applyScheduledGasSchedule()
+	if err != nil {
+		return nil, err
 	}
 
+	if ae.GasSweep != nil && (step.Tx.Type == mj.ScCall || step.Tx.Type == mj.ScDeploy) {
+		report, err := ae.SweepTxGas(step, *ae.GasSweep)
+		if err != nil {
+			return nil, err
+		}
+		ae.writeGasReport(report.String())
+	}
+
+	if ae.EstimateGas && (step.Tx.Type == mj.ScCall || step.Tx.Type == mj.ScDeploy) {
+		estimate, err := ae.EstimateGasForTx(step)
+		if err != nil {
+			ae.writeGasReport(fmt.Sprintf("tx %s: gasLimit %d, estimate not available: %s\n", step.TxIdent, step.Tx.GasLimit.Value, err.Error()))
+		} else {
+			ae.writeGasReport(fmt.Sprintf("tx %s: gasLimit %d, estimated %s\n", step.TxIdent, step.Tx.GasLimit.Value, estimate.String()))
+		}
+	}
+
+	_ = ae.TakeLastCallGraph()
 	output, err := ae.executeTx(step.TxIdent, step.Tx)
 	if err != nil {
 		return nil, err
 	}
+	ae.World.TransactionExecuted()
 
-	if step.DisplayLogs {
-		vmhost.DisableLoggingForTests()
+	if len(ae.CallGraphDir) > 0 {
+		err = ae.saveCallGraph(step.TxIdent)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if step.Tx.Type == mj.ScCall || step.Tx.Type == mj.ScDeploy {
+		ae.recordGasSnapshot(step.TxIdent, step.Tx.GasLimit.Value-output.GasRemaining)
+	}
+
+	ae.recordFlagMatrixStep(step.TxIdent, output)
+
+	if step.ExpectedResult != nil && ae.UpdateExpectations {
+		ae.updateTxExpectations(step.ExpectedResult, output)
+		return output, nil
 	}
 
 	// check results
@@ -202,7 +284,7 @@ func (ae *VMTestExecutor) UpdateAccount(scenAccount *mj.Account) error {
 	}
 
 	for k, v := range worldAccount.Storage {
-		existingAccount.Storage[k] = v
+		existingAccount.SetStorageValue(k, v)
 	}
 	if !scenAccount.Nonce.Unspecified {
 		existingAccount.Nonce = worldAccount.Nonce
@@ -0,0 +1,34 @@
+package scenarioexec
+
+import (
+	"fmt"
+
+	mj "github.com/multiversx/mx-chain-scenario-go/model"
+	worldmock "github.com/multiversx/mx-chain-vm-go/mock/world"
+)
+
+// setFaultRules configures the fault injector of the world from the storage of
+// the reserved fault injector account. Each storage key names a rule and its
+// value holds the encoded rule. An empty value removes the rule.
+func (ae *VMTestExecutor) setFaultRules(scenAccount *mj.Account) error {
+	if !scenAccount.Update {
+		ae.World.Faults.Clear()
+	}
+
+	for _, stkvp := range scenAccount.Storage {
+		ruleName := string(stkvp.Key.Value)
+		if len(stkvp.Value.Value) == 0 {
+			ae.World.Faults.RemoveRule(ruleName)
+			continue
+		}
+
+		rule, err := worldmock.DecodeFaultRule(stkvp.Value.Value)
+		if err != nil {
+			return fmt.Errorf("fault rule \"%s\": %w", ruleName, err)
+		}
+
+		ae.World.Faults.SetRule(ruleName, rule)
+	}
+
+	return nil
+}
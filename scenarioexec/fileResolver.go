@@ -0,0 +1,46 @@
+package scenarioexec
+
+import (
+	fr "github.com/multiversx/mx-chain-scenario-go/fileresolver"
+)
+
+// ScenarioFileResolver is a default file resolver that also remembers the path of the scenario file it resolves for.
+type ScenarioFileResolver struct {
+	*fr.DefaultFileResolver
+	scenarioPath string
+}
+
+// NewScenarioFileResolver creates a new ScenarioFileResolver.
+func NewScenarioFileResolver() *ScenarioFileResolver {
+	return &ScenarioFileResolver{
+		DefaultFileResolver: fr.NewDefaultFileResolver(),
+	}
+}
+
+// Clone creates new instance of the same type.
+func (sfr *ScenarioFileResolver) Clone() fr.FileResolver {
+	return &ScenarioFileResolver{
+		DefaultFileResolver: sfr.DefaultFileResolver.Clone().(*fr.DefaultFileResolver),
+		scenarioPath:        sfr.scenarioPath,
+	}
+}
+
+// SetContext sets directory where the test runs, to help resolve relative paths.
+func (sfr *ScenarioFileResolver) SetContext(contextPath string) {
+	sfr.DefaultFileResolver.SetContext(contextPath)
+	sfr.scenarioPath = contextPath
+}
+
+// ScenarioPath yields the path of the scenario file, as last passed to SetContext.
+func (sfr *ScenarioFileResolver) ScenarioPath() string {
+	return sfr.scenarioPath
+}
+
+// scenarioPath yields the path of the scenario file being run, if the file resolver knows it.
+func scenarioPath(fileResolver fr.FileResolver) string {
+	pathResolver, ok := fileResolver.(interface{ ScenarioPath() string })
+	if !ok {
+		return ""
+	}
+	return pathResolver.ScenarioPath()
+}
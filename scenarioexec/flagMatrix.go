@@ -0,0 +1,376 @@
+package scenarioexec
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	mc "github.com/multiversx/mx-chain-scenario-go/controller"
+	fr "github.com/multiversx/mx-chain-scenario-go/fileresolver"
+	mjparse "github.com/multiversx/mx-chain-scenario-go/json/parse"
+	mj "github.com/multiversx/mx-chain-scenario-go/model"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	worldmock "github.com/multiversx/mx-chain-vm-go/mock/world"
+)
+
+// ErrFlagMatrixMismatch signals that a scenario behaves differently under some flag sets.
+var ErrFlagMatrixMismatch = errors.New("flag matrix mismatch")
+
+// maxFlagMatrixFlags limits the number of flags combined by the flag matrix, since each flag doubles the runs
+const maxFlagMatrixFlags = 8
+
+// FlagSet names a state of the epoch flags under which a scenario is run.
+type FlagSet struct {
+	Name  string
+	Flags map[string]bool
+}
+
+// FlagMatrixStep holds the result of a transaction step, rendered so that it can be compared between runs.
+type FlagMatrixStep struct {
+	StepID string
+	Result string
+}
+
+// FlagMatrixRun holds the outcome of a scenario run under a flag set.
+type FlagMatrixRun struct {
+	FlagSet *FlagSet
+	Steps   []*FlagMatrixStep
+	Err     error
+}
+
+// FlagMatrixReport holds the runs of a scenario under all the flag sets; the first run is the baseline.
+type FlagMatrixReport struct {
+	Scenario string
+	Runs     []*FlagMatrixRun
+}
+
+// FlagMatrixDiff describes a behaviour of a run which differs from the baseline run.
+type FlagMatrixDiff struct {
+	Scenario string
+	FlagSet  string
+	StepID   string
+	Baseline string
+	Actual   string
+}
+
+// String renders the diff in a human-readable form.
+func (diff *FlagMatrixDiff) String() string {
+	return fmt.Sprintf("%s [%s] / %s: %s -> %s", diff.Scenario, diff.FlagSet, diff.StepID, diff.Baseline, diff.Actual)
+}
+
+func isEnableEpochsAccount(scenAccount *mj.Account) bool {
+	return bytes.Equal(scenAccount.Address.Value, worldmock.EnableEpochsAddress)
+}
+
+func (ae *VMTestExecutor) enableEpochsStub() (*worldmock.EnableEpochsHandlerStub, error) {
+	stub, ok := ae.World.EnableEpochsHandler.(*worldmock.EnableEpochsHandlerStub)
+	if !ok {
+		return nil, errors.New("the world does not use an EnableEpochsHandlerStub")
+	}
+
+	return stub, nil
+}
+
+// SetFlagOverrides sets the given epoch flags, which the scenarios can no longer change.
+func (ae *VMTestExecutor) SetFlagOverrides(flags map[string]bool) error {
+	stub, err := ae.enableEpochsStub()
+	if err != nil {
+		return err
+	}
+
+	for name, enabled := range flags {
+		err = stub.SetFlag(name, enabled)
+		if err != nil {
+			return err
+		}
+	}
+
+	ae.flagOverrides = flags
+	return nil
+}
+
+// setEnableEpochsFlags sets the epoch flags from the storage of the reserved enable epochs account.
+// Each storage key is the name of a flag the scenario depends on, and a non-zero value enables it.
+// The flags set by SetFlagOverrides are left unchanged.
+func (ae *VMTestExecutor) setEnableEpochsFlags(scenAccount *mj.Account) error {
+	stub, err := ae.enableEpochsStub()
+	if err != nil {
+		return err
+	}
+
+	if ae.flagsBeforeScenario == nil {
+		flagsBackup := *stub
+		ae.flagsBeforeScenario = &flagsBackup
+	}
+
+	for _, stkvp := range scenAccount.Storage {
+		name := string(stkvp.Key.Value)
+		_, err = stub.IsFlagSet(name)
+		if err != nil {
+			return err
+		}
+
+		_, isOverridden := ae.flagOverrides[name]
+		if isOverridden {
+			continue
+		}
+
+		enabled := big.NewInt(0).SetBytes(stkvp.Value.Value).Sign() != 0
+		_ = stub.SetFlag(name, enabled)
+	}
+
+	return nil
+}
+
+// restoreEnableEpochsFlags reverts the flags changed by the scenarios, so that they do not leak into the next one
+func (ae *VMTestExecutor) restoreEnableEpochsFlags() {
+	if ae.flagsBeforeScenario == nil {
+		return
+	}
+
+	stub, err := ae.enableEpochsStub()
+	if err == nil {
+		*stub = *ae.flagsBeforeScenario
+	}
+	ae.flagsBeforeScenario = nil
+}
+
+func (ae *VMTestExecutor) recordFlagMatrixStep(stepID string, output *vmcommon.VMOutput) {
+	if ae.flagMatrixRun == nil {
+		return
+	}
+
+	returnData := make([]string, 0, len(output.ReturnData))
+	for _, data := range output.ReturnData {
+		returnData = append(returnData, fmt.Sprintf("0x%x", data))
+	}
+
+	ae.flagMatrixRun.Steps = append(ae.flagMatrixRun.Steps, &FlagMatrixStep{
+		StepID: stepID,
+		Result: fmt.Sprintf("status %d, message \"%s\", out [%s], gas remaining %d, %d logs",
+			output.ReturnCode, output.ReturnMessage, strings.Join(returnData, ", "), output.GasRemaining, len(output.Logs)),
+	})
+}
+
+// DeclaredFlags returns the sorted names of the flags declared by the enable epochs accounts of the scenario,
+// including those of its external steps.
+func DeclaredFlags(scenarioPath string) ([]string, error) {
+	declared := make(map[string]struct{})
+	err := collectDeclaredFlags(scenarioPath, mc.NewDefaultFileResolver(), declared)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(declared))
+	for name := range declared {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func collectDeclaredFlags(scenarioPath string, fileResolver fr.FileResolver, declared map[string]struct{}) error {
+	parser := mjparse.NewParser(fileResolver)
+	scenario, err := mc.ParseScenariosScenario(parser, scenarioPath)
+	if err != nil {
+		return err
+	}
+
+	for _, generalStep := range scenario.Steps {
+		switch step := generalStep.(type) {
+		case *mj.SetStateStep:
+			for _, scenAccount := range step.Accounts {
+				if !isEnableEpochsAccount(scenAccount) {
+					continue
+				}
+				for _, stkvp := range scenAccount.Storage {
+					declared[string(stkvp.Key.Value)] = struct{}{}
+				}
+			}
+		case *mj.ExternalStepsStep:
+			resolver := parser.ExprInterpreter.FileResolver
+			err = collectDeclaredFlags(resolver.ResolveAbsolutePath(step.Path), resolver.Clone(), declared)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// MatrixFlagSets returns all the combinations of the given flags being active or not,
+// starting with the one where all of them are active.
+func MatrixFlagSets(flagNames []string) ([]*FlagSet, error) {
+	if len(flagNames) > maxFlagMatrixFlags {
+		return nil, fmt.Errorf("too many flags for the flag matrix: %d, at most %d are allowed", len(flagNames), maxFlagMatrixFlags)
+	}
+
+	numCombinations := 1 << len(flagNames)
+	flagSets := make([]*FlagSet, 0, numCombinations)
+	for combination := 0; combination < numCombinations; combination++ {
+		flagSet := &FlagSet{Flags: make(map[string]bool)}
+		settings := make([]string, 0, len(flagNames))
+		for i, name := range flagNames {
+			enabled := combination&(1<<i) == 0
+			flagSet.Flags[name] = enabled
+			settings = append(settings, fmt.Sprintf("%s=%s", name, onOff(enabled)))
+		}
+		flagSet.Name = strings.Join(settings, ", ")
+		flagSets = append(flagSets, flagSet)
+	}
+
+	return flagSets, nil
+}
+
+// ReleaseFlagSets returns the state of the flags after each of the given protocol releases.
+func ReleaseFlagSets(releaseNames []string) ([]*FlagSet, error) {
+	flagSets := make([]*FlagSet, 0, len(releaseNames))
+	for _, name := range releaseNames {
+		flags, err := worldmock.ReleaseFlags(name)
+		if err != nil {
+			return nil, err
+		}
+		flagSets = append(flagSets, &FlagSet{Name: name, Flags: flags})
+	}
+
+	return flagSets, nil
+}
+
+// RunFlagMatrix runs the scenario once for each flag set, each time with a new executor, recording the results
+// of the transaction steps. The runs do not stop at the first failure of the scenario, which is recorded instead.
+func RunFlagMatrix(
+	scenarioPath string,
+	flagSets []*FlagSet,
+	newExecutor func() (*VMTestExecutor, error),
+	options *mc.RunScenarioOptions,
+) (*FlagMatrixReport, error) {
+	report := &FlagMatrixReport{
+		Scenario: scenarioPath,
+		Runs:     make([]*FlagMatrixRun, 0, len(flagSets)),
+	}
+
+	for _, flagSet := range flagSets {
+		executor, err := newExecutor()
+		if err != nil {
+			return nil, err
+		}
+
+		err = executor.SetFlagOverrides(flagSet.Flags)
+		if err != nil {
+			return nil, err
+		}
+
+		run := &FlagMatrixRun{FlagSet: flagSet}
+		executor.flagMatrixRun = run
+		runner := mc.NewScenarioController(executor, mc.NewDefaultFileResolver())
+		run.Err = runner.RunSingleJSONScenario(scenarioPath, options)
+		executor.Close()
+
+		report.Runs = append(report.Runs, run)
+	}
+
+	return report, nil
+}
+
+// Compare checks the runs against the baseline run, step by step.
+func (report *FlagMatrixReport) Compare() []*FlagMatrixDiff {
+	diffs := make([]*FlagMatrixDiff, 0)
+	if len(report.Runs) == 0 {
+		return diffs
+	}
+
+	baseline := report.Runs[0]
+	for _, run := range report.Runs[1:] {
+		numSteps := len(baseline.Steps)
+		if len(run.Steps) > numSteps {
+			numSteps = len(run.Steps)
+		}
+
+		for i := 0; i < numSteps; i++ {
+			baselineStep := stepAt(baseline.Steps, i)
+			step := stepAt(run.Steps, i)
+			if baselineStep.StepID == step.StepID && baselineStep.Result == step.Result {
+				continue
+			}
+
+			stepID := step.StepID
+			if len(stepID) == 0 {
+				stepID = baselineStep.StepID
+			}
+			diffs = append(diffs, &FlagMatrixDiff{
+				Scenario: report.Scenario,
+				FlagSet:  run.FlagSet.Name,
+				StepID:   stepID,
+				Baseline: baselineStep.Result,
+				Actual:   step.Result,
+			})
+		}
+
+		baselineErr := errorString(baseline.Err)
+		runErr := errorString(run.Err)
+		if baselineErr != runErr {
+			diffs = append(diffs, &FlagMatrixDiff{
+				Scenario: report.Scenario,
+				FlagSet:  run.FlagSet.Name,
+				StepID:   "outcome",
+				Baseline: baselineErr,
+				Actual:   runErr,
+			})
+		}
+	}
+
+	return diffs
+}
+
+// String renders the outcome of each run.
+func (report *FlagMatrixReport) String() string {
+	sb := &strings.Builder{}
+	for _, run := range report.Runs {
+		_, _ = fmt.Fprintf(sb, "%s [%s]: %s\n", report.Scenario, run.FlagSet.Name, errorString(run.Err))
+	}
+
+	return sb.String()
+}
+
+// FlagMatrixDiffsError wraps the diffs into an ErrFlagMatrixMismatch error, or returns nil if there are none.
+func FlagMatrixDiffsError(diffs []*FlagMatrixDiff) error {
+	if len(diffs) == 0 {
+		return nil
+	}
+
+	lines := make([]string, 0, len(diffs))
+	for _, diff := range diffs {
+		lines = append(lines, "  "+diff.String())
+	}
+
+	return fmt.Errorf("%w, %d behaviours differ from the baseline:\n%s", ErrFlagMatrixMismatch, len(diffs), strings.Join(lines, "\n"))
+}
+
+func stepAt(steps []*FlagMatrixStep, index int) *FlagMatrixStep {
+	if index >= len(steps) {
+		return &FlagMatrixStep{Result: "step not run"}
+	}
+
+	return steps[index]
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return "SUCCESS"
+	}
+
+	return "ERROR: " + err.Error()
+}
+
+func onOff(enabled bool) string {
+	if enabled {
+		return "on"
+	}
+
+	return "off"
+}
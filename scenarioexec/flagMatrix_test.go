@@ -0,0 +1,117 @@
+package scenarioexec
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatrixFlagSets(t *testing.T) {
+	flagSets, err := MatrixFlagSets([]string{"RefactorContext", "ScToScLogEvent"})
+	require.Nil(t, err)
+	require.Len(t, flagSets, 4)
+
+	require.Equal(t, "RefactorContext=on, ScToScLogEvent=on", flagSets[0].Name)
+	require.Equal(t, map[string]bool{"RefactorContext": true, "ScToScLogEvent": true}, flagSets[0].Flags)
+	require.Equal(t, "RefactorContext=off, ScToScLogEvent=on", flagSets[1].Name)
+	require.Equal(t, "RefactorContext=on, ScToScLogEvent=off", flagSets[2].Name)
+	require.Equal(t, map[string]bool{"RefactorContext": false, "ScToScLogEvent": false}, flagSets[3].Flags)
+
+	flagSets, err = MatrixFlagSets(nil)
+	require.Nil(t, err)
+	require.Len(t, flagSets, 1)
+	require.Empty(t, flagSets[0].Flags)
+
+	_, err = MatrixFlagSets([]string{"a", "b", "c", "d", "e", "f", "g", "h", "i"})
+	require.NotNil(t, err)
+}
+
+func TestFlagMatrixReport_Compare(t *testing.T) {
+	steps := func(results ...string) []*FlagMatrixStep {
+		matrixSteps := make([]*FlagMatrixStep, 0, len(results))
+		for i, result := range results {
+			matrixSteps = append(matrixSteps, &FlagMatrixStep{StepID: string(rune('1' + i)), Result: result})
+		}
+		return matrixSteps
+	}
+	report := &FlagMatrixReport{
+		Scenario: "test.scen.json",
+		Runs: []*FlagMatrixRun{
+			{FlagSet: &FlagSet{Name: "baseline"}, Steps: steps("ok", "ok")},
+			{FlagSet: &FlagSet{Name: "same"}, Steps: steps("ok", "ok")},
+			{FlagSet: &FlagSet{Name: "changed"}, Steps: steps("ok", "failed")},
+			{FlagSet: &FlagSet{Name: "stopped"}, Steps: steps("ok"), Err: errors.New("check failed")},
+		},
+	}
+
+	diffs := report.Compare()
+	require.Len(t, diffs, 3)
+	require.Equal(t, &FlagMatrixDiff{Scenario: "test.scen.json", FlagSet: "changed", StepID: "2", Baseline: "ok", Actual: "failed"}, diffs[0])
+	require.Equal(t, &FlagMatrixDiff{Scenario: "test.scen.json", FlagSet: "stopped", StepID: "2", Baseline: "ok", Actual: "step not run"}, diffs[1])
+	require.Equal(t, &FlagMatrixDiff{Scenario: "test.scen.json", FlagSet: "stopped", StepID: "outcome", Baseline: "SUCCESS", Actual: "ERROR: check failed"}, diffs[2])
+
+	err := FlagMatrixDiffsError(diffs)
+	require.True(t, errors.Is(err, ErrFlagMatrixMismatch))
+	require.Nil(t, FlagMatrixDiffsError(nil))
+	require.Empty(t, (&FlagMatrixReport{}).Compare())
+}
+
+func TestDeclaredFlags(t *testing.T) {
+	dir := t.TempDir()
+	writeScenario := func(name string, contents string) string {
+		path := filepath.Join(dir, name)
+		require.Nil(t, os.WriteFile(path, []byte(contents), 0644))
+		return path
+	}
+
+	writeScenario("external.steps.json", `{
+		"steps": [
+			{
+				"step": "setState",
+				"accounts": {
+					"address:enable-epochs": {
+						"storage": {
+							"str:RefactorContext": "1"
+						}
+					}
+				}
+			}
+		]
+	}`)
+	scenarioPath := writeScenario("flags.scen.json", `{
+		"steps": [
+			{
+				"step": "externalSteps",
+				"path": "external.steps.json"
+			},
+			{
+				"step": "setState",
+				"accounts": {
+					"address:enable-epochs": {
+						"storage": {
+							"str:ScToScLogEvent": "0",
+							"str:RefactorContext": "0"
+						}
+					},
+					"address:owner": {
+						"nonce": "0",
+						"balance": "0",
+						"storage": {
+							"str:NotAFlag": "1"
+						}
+					}
+				}
+			}
+		]
+	}`)
+
+	names, err := DeclaredFlags(scenarioPath)
+	require.Nil(t, err)
+	require.Equal(t, []string{"RefactorContext", "ScToScLogEvent"}, names)
+
+	_, err = DeclaredFlags(filepath.Join(dir, "missing.scen.json"))
+	require.NotNil(t, err)
+}
@@ -0,0 +1,92 @@
+package scenarioexec
+
+import (
+	"errors"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	mj "github.com/multiversx/mx-chain-scenario-go/model"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	worldmock "github.com/multiversx/mx-chain-vm-go/mock/world"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+)
+
+// ErrVMNotInitialized signals that the VM was used before being initialized.
+var ErrVMNotInitialized = errors.New("VM not initialized")
+
+// ErrGasEstimateNotAvailable signals a gas estimate for a transaction which is neither a deployment nor a call
+var ErrGasEstimateNotAvailable = errors.New("gas estimate only available for deployments and calls")
+
+// EstimateGasForCall finds the minimal gas limit for which the given call succeeds, by bisection
+// below input.GasProvided. The world is left untouched.
+func (ae *VMTestExecutor) EstimateGasForCall(input *vmcommon.ContractCallInput) (*vmhost.GasEstimate, error) {
+	if check.IfNil(ae.vmHost) {
+		return nil, ErrVMNotInitialized
+	}
+
+	return ae.vmHost.EstimateGasForCall(input)
+}
+
+// EstimateGasForCreate finds the minimal gas limit for which the given deployment succeeds, by bisection
+// below input.GasProvided. The world is left untouched.
+func (ae *VMTestExecutor) EstimateGasForCreate(input *vmcommon.ContractCreateInput) (*vmhost.GasEstimate, error) {
+	if check.IfNil(ae.vmHost) {
+		return nil, ErrVMNotInitialized
+	}
+
+	return ae.vmHost.EstimateGasForCreate(input)
+}
+
+// EstimateGasForTx finds the minimal gas limit for which the transaction of the given step succeeds,
+// by bisection below its declared gas limit. The gas payment and the direct ESDT transfers of the
+// transaction are done once, in a copy of the world, then the contract execution is estimated by the VM.
+// The direct ESDT transfers cost the same with any gas limit, so their gas is added to the estimate.
+// The world is left untouched.
+func (ae *VMTestExecutor) EstimateGasForTx(step *mj.TxStep) (*vmhost.GasEstimate, error) {
+	if check.IfNil(ae.vmHost) {
+		return nil, ErrVMNotInitialized
+	}
+
+	var estimate *vmhost.GasEstimate
+	var err error
+	ae.withWorldCopy(func(_ worldmock.AccountMap) {
+		estimate, err = ae.estimateGasForTxInWorldCopy(step.TxIdent, step.Tx)
+	})
+	return estimate, err
+}
+
+func (ae *VMTestExecutor) estimateGasForTxInWorldCopy(txIndex string, tx *mj.Transaction) (*vmhost.GasEstimate, error) {
+	err := ae.World.UpdateWorldStateBefore(tx.From.Value, tx.GasLimit.Value, tx.GasPrice.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	gasForExecution := tx.GasLimit.Value
+	if tx.ESDTValue != nil {
+		gasForExecution, err = ae.directESDTTransferFromTx(tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var estimate *vmhost.GasEstimate
+	switch tx.Type {
+	case mj.ScDeploy:
+		estimate, err = ae.vmHost.EstimateGasForCreate(scCreateInput(txIndex, tx, gasForExecution))
+	case mj.ScCall:
+		input, errInput := ae.scCallInput(txIndex, tx, gasForExecution)
+		if errInput != nil {
+			return nil, errInput
+		}
+		estimate, err = ae.vmHost.EstimateGasForCall(input)
+	default:
+		return nil, ErrGasEstimateNotAvailable
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	gasForTransfers := tx.GasLimit.Value - gasForExecution
+	estimate.GasLimit += gasForTransfers
+	estimate.GasUsed += gasForTransfers
+	return estimate, nil
+}
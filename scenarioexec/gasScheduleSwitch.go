@@ -0,0 +1,237 @@
+package scenarioexec
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	mj "github.com/multiversx/mx-chain-scenario-go/model"
+	"github.com/multiversx/mx-chain-vm-go/config"
+)
+
+// GasScheduleAddress is the reserved address used by scenarios to schedule gas schedule changes.
+// Its storage is interpreted as settings instead of being saved as an account.
+// It is the value of the scenario expression "address:gas-schedule".
+var GasScheduleAddress = []byte("gas-schedule____________________")
+
+// gasScheduleSwitch is the storage key of the gas schedule account which changes the gas schedule right away
+const gasScheduleSwitch = "switch"
+
+// GasScheduleActivation changes the gas schedule once the world reaches an epoch.
+type GasScheduleActivation struct {
+	Epoch       uint32
+	Name        string
+	GasSchedule config.GasScheduleMap
+}
+
+// setGasSchedules configures the gas schedule changes from the storage of the reserved gas schedule account.
+// Each storage key is an epoch, and its value names the gas schedule activated in that epoch, as in the
+// gasSchedule field of the scenarios; an empty value removes the activation. The "switch" key changes the
+// gas schedule right away, until the next activation epoch is reached.
+func (ae *VMTestExecutor) setGasSchedules(scenAccount *mj.Account) error {
+	if !scenAccount.Update {
+		ae.gasScheduleActivations = nil
+	}
+
+	switchTo := ""
+	for _, stkvp := range scenAccount.Storage {
+		name := string(stkvp.Value.Value)
+		if string(stkvp.Key.Value) == gasScheduleSwitch {
+			switchTo = name
+			continue
+		}
+
+		epoch := big.NewInt(0).SetBytes(stkvp.Key.Value)
+		if !epoch.IsUint64() || epoch.Uint64() > math.MaxUint32 {
+			return fmt.Errorf("gas schedule: key %s is neither \"%s\" nor an epoch", stkvp.Key.Original, gasScheduleSwitch)
+		}
+		if len(name) == 0 {
+			ae.RemoveGasScheduleActivation(uint32(epoch.Uint64()))
+			continue
+		}
+
+		err := ae.AddGasScheduleActivation(uint32(epoch.Uint64()), name)
+		if err != nil {
+			return fmt.Errorf("gas schedule: %w", err)
+		}
+	}
+
+	if len(switchTo) > 0 {
+		err := ae.SwitchGasSchedule(switchTo)
+		if err != nil {
+			return fmt.Errorf("gas schedule: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// AddGasScheduleActivation schedules the named gas schedule for the given epoch, replacing the one
+// scheduled before for the same epoch. The gas schedule changes before the first transaction run in
+// that epoch or later. The activations are removed by Reset.
+func (ae *VMTestExecutor) AddGasScheduleActivation(epoch uint32, name string) error {
+	gasSchedule, err := ae.gasScheduleByName(name)
+	if err != nil {
+		return err
+	}
+
+	ae.RemoveGasScheduleActivation(epoch)
+	ae.gasScheduleActivations = append(ae.gasScheduleActivations, &GasScheduleActivation{
+		Epoch:       epoch,
+		Name:        name,
+		GasSchedule: gasSchedule,
+	})
+	sort.Slice(ae.gasScheduleActivations, func(i, j int) bool {
+		return ae.gasScheduleActivations[i].Epoch < ae.gasScheduleActivations[j].Epoch
+	})
+
+	return nil
+}
+
+// RemoveGasScheduleActivation removes the gas schedule scheduled for the given epoch, if any
+func (ae *VMTestExecutor) RemoveGasScheduleActivation(epoch uint32) {
+	for i, activation := range ae.gasScheduleActivations {
+		if activation.Epoch == epoch {
+			ae.gasScheduleActivations = append(ae.gasScheduleActivations[:i], ae.gasScheduleActivations[i+1:]...)
+			return
+		}
+	}
+}
+
+// SwitchGasSchedule changes the gas schedule right away. It stays in use until the world reaches
+// the epoch of the next gas schedule activation.
+func (ae *VMTestExecutor) SwitchGasSchedule(name string) error {
+	gasSchedule, err := ae.gasScheduleByName(name)
+	if err != nil {
+		return err
+	}
+
+	err = ae.changeGasSchedule(gasSchedule)
+	if err != nil {
+		return err
+	}
+
+	ae.appliedGasScheduleActivation = ae.gasScheduleActivationForEpoch(ae.World.CurrentEpoch())
+	return nil
+}
+
+// applyScheduledGasSchedule changes the gas schedule when the world reached the epoch of a new activation.
+// When the world goes back before the first activation, the initial gas schedule is restored.
+func (ae *VMTestExecutor) applyScheduledGasSchedule() error {
+	activation := ae.gasScheduleActivationForEpoch(ae.World.CurrentEpoch())
+	if activation == ae.appliedGasScheduleActivation {
+		return nil
+	}
+	if activation == nil {
+		err := ae.changeGasSchedule(ae.initialGasSchedule)
+		if err != nil {
+			return err
+		}
+
+		log.Trace("gas schedule restored", "epoch", ae.World.CurrentEpoch())
+		ae.appliedGasScheduleActivation = nil
+		ae.currentGasSchedule = nil
+		return nil
+	}
+
+	err := ae.changeGasSchedule(activation.GasSchedule)
+	if err != nil {
+		return err
+	}
+
+	log.Trace("gas schedule changed", "name", activation.Name, "epoch", activation.Epoch)
+	ae.appliedGasScheduleActivation = activation
+	return nil
+}
+
+// gasScheduleActivationForEpoch returns the last activation reached by the given epoch, or nil
+func (ae *VMTestExecutor) gasScheduleActivationForEpoch(epoch uint32) *GasScheduleActivation {
+	var reached *GasScheduleActivation
+	for _, activation := range ae.gasScheduleActivations {
+		if activation.Epoch > epoch {
+			break
+		}
+		reached = activation
+	}
+
+	return reached
+}
+
+func (ae *VMTestExecutor) changeGasSchedule(gasSchedule config.GasScheduleMap) error {
+	if check.IfNil(ae.vmHost) {
+		return ErrVMNotInitialized
+	}
+
+	ae.vmHost.GasScheduleChange(gasSchedule)
+	ae.World.BuiltinFuncs.GasScheduleChange(gasSchedule)
+	ae.currentGasSchedule = gasSchedule
+	ae.gasScheduleChanged = true
+	ae.numGasScheduleChanges++
+	return nil
+}
+
+// gasScheduleState is the gas schedule in use and the activations applied, as saved before reruns
+type gasScheduleState struct {
+	activations           []*GasScheduleActivation
+	appliedActivation     *GasScheduleActivation
+	currentGasSchedule    config.GasScheduleMap
+	gasScheduleChanged    bool
+	numGasScheduleChanges uint64
+}
+
+func (ae *VMTestExecutor) saveGasScheduleState() *gasScheduleState {
+	return &gasScheduleState{
+		activations:           append([]*GasScheduleActivation(nil), ae.gasScheduleActivations...),
+		appliedActivation:     ae.appliedGasScheduleActivation,
+		currentGasSchedule:    ae.currentGasSchedule,
+		gasScheduleChanged:    ae.gasScheduleChanged,
+		numGasScheduleChanges: ae.numGasScheduleChanges,
+	}
+}
+
+// restoreGasScheduleState brings back the saved activations, and the saved gas schedule if it was changed since
+func (ae *VMTestExecutor) restoreGasScheduleState(state *gasScheduleState) {
+	if ae.numGasScheduleChanges != state.numGasScheduleChanges {
+		gasSchedule := state.currentGasSchedule
+		if gasSchedule == nil {
+			gasSchedule = ae.initialGasSchedule
+		}
+		_ = ae.changeGasSchedule(gasSchedule)
+	}
+
+	ae.gasScheduleActivations = state.activations
+	ae.appliedGasScheduleActivation = state.appliedActivation
+	ae.currentGasSchedule = state.currentGasSchedule
+	ae.gasScheduleChanged = state.gasScheduleChanged
+	ae.numGasScheduleChanges = state.numGasScheduleChanges
+}
+
+// resetGasSchedule removes the gas schedule activations and restores the gas schedule the VM was initialized with
+func (ae *VMTestExecutor) resetGasSchedule() {
+	ae.gasScheduleActivations = nil
+	ae.appliedGasScheduleActivation = nil
+	if !ae.gasScheduleChanged {
+		return
+	}
+
+	_ = ae.changeGasSchedule(ae.initialGasSchedule)
+	ae.currentGasSchedule = nil
+	ae.gasScheduleChanged = false
+}
+
+func (ae *VMTestExecutor) gasScheduleByName(name string) (config.GasScheduleMap, error) {
+	switch name {
+	case "default":
+		return ae.gasScheduleMapFromScenarios(mj.GasScheduleDefault)
+	case "dummy":
+		return ae.gasScheduleMapFromScenarios(mj.GasScheduleDummy)
+	case "v3":
+		return ae.gasScheduleMapFromScenarios(mj.GasScheduleV3)
+	case "v4":
+		return ae.gasScheduleMapFromScenarios(mj.GasScheduleV4)
+	default:
+		return nil, fmt.Errorf("invalid gasSchedule: %s", name)
+	}
+}
@@ -0,0 +1,101 @@
+package scenarioexec
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-go/config"
+	contextmock "github.com/multiversx/mx-chain-vm-go/mock/context"
+	worldmock "github.com/multiversx/mx-chain-vm-go/mock/world"
+	"github.com/stretchr/testify/require"
+)
+
+func newGasScheduleExecutor(t *testing.T) (*VMTestExecutor, *[]config.GasScheduleMap) {
+	initialGasSchedule := config.MakeGasMapForTests()
+	world := worldmock.NewMockWorld()
+	require.Nil(t, world.InitBuiltinFunctions(initialGasSchedule))
+
+	changes := make([]config.GasScheduleMap, 0)
+	executor := &VMTestExecutor{
+		World: world,
+		vmHost: &contextmock.VMHostStub{
+			GasScheduleChangeCalled: func(newGasSchedule config.GasScheduleMap) {
+				changes = append(changes, newGasSchedule)
+			},
+		},
+		initialGasSchedule: initialGasSchedule,
+	}
+	return executor, &changes
+}
+
+func setEpoch(executor *VMTestExecutor, epoch uint32) {
+	executor.World.CurrentBlockInfo = &worldmock.BlockInfo{BlockEpoch: epoch}
+}
+
+func TestGasSchedule_ActivationsFollowTheEpoch(t *testing.T) {
+	t.Parallel()
+
+	executor, changes := newGasScheduleExecutor(t)
+	require.Nil(t, executor.AddGasScheduleActivation(5, "v3"))
+	require.Nil(t, executor.AddGasScheduleActivation(10, "v4"))
+
+	setEpoch(executor, 2)
+	require.Nil(t, executor.applyScheduledGasSchedule())
+	require.Empty(t, *changes)
+
+	setEpoch(executor, 5)
+	require.Nil(t, executor.applyScheduledGasSchedule())
+	require.Len(t, *changes, 1)
+	require.Equal(t, "v3", executor.appliedGasScheduleActivation.Name)
+
+	setEpoch(executor, 7)
+	require.Nil(t, executor.applyScheduledGasSchedule())
+	require.Len(t, *changes, 1)
+
+	setEpoch(executor, 12)
+	require.Nil(t, executor.applyScheduledGasSchedule())
+	require.Len(t, *changes, 2)
+	require.Equal(t, "v4", executor.appliedGasScheduleActivation.Name)
+}
+
+func TestGasSchedule_EpochBeforeFirstActivationRestoresInitialSchedule(t *testing.T) {
+	t.Parallel()
+
+	executor, changes := newGasScheduleExecutor(t)
+	require.Nil(t, executor.AddGasScheduleActivation(5, "v3"))
+
+	setEpoch(executor, 6)
+	require.Nil(t, executor.applyScheduledGasSchedule())
+	require.Len(t, *changes, 1)
+	require.NotNil(t, executor.currentGasSchedule)
+
+	setEpoch(executor, 3)
+	require.Nil(t, executor.applyScheduledGasSchedule())
+	require.Len(t, *changes, 2)
+	require.Equal(t, executor.initialGasSchedule, (*changes)[1])
+	require.Nil(t, executor.appliedGasScheduleActivation)
+	require.Nil(t, executor.currentGasSchedule)
+
+	// staying before the first activation does not change the gas schedule again
+	setEpoch(executor, 4)
+	require.Nil(t, executor.applyScheduledGasSchedule())
+	require.Len(t, *changes, 2)
+}
+
+func TestGasSchedule_SwitchStaysUntilNextActivation(t *testing.T) {
+	t.Parallel()
+
+	executor, changes := newGasScheduleExecutor(t)
+	require.Nil(t, executor.AddGasScheduleActivation(5, "v4"))
+
+	setEpoch(executor, 1)
+	require.Nil(t, executor.SwitchGasSchedule("v3"))
+	require.Len(t, *changes, 1)
+
+	require.Nil(t, executor.applyScheduledGasSchedule())
+	require.Len(t, *changes, 1)
+
+	setEpoch(executor, 5)
+	require.Nil(t, executor.applyScheduledGasSchedule())
+	require.Len(t, *changes, 2)
+	require.Equal(t, "v4", executor.appliedGasScheduleActivation.Name)
+}
@@ -0,0 +1,249 @@
+package scenarioexec
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ErrGasSnapshotMismatch signals that the gas used by some steps differs from the gas snapshot.
+var ErrGasSnapshotMismatch = errors.New("gas snapshot mismatch")
+
+const unnamedScenario = "<unnamed>"
+
+// GasSnapshot holds the gas used by each transaction step, grouped by scenario and step id.
+// Scenarios are keyed by their file path, relative to BaseDir when it is set,
+// or by their name when the file path is not known.
+type GasSnapshot struct {
+	Scenarios map[string]map[string]uint64
+	BaseDir   string
+}
+
+// NewGasSnapshot creates an empty GasSnapshot.
+func NewGasSnapshot() *GasSnapshot {
+	return &GasSnapshot{
+		Scenarios: make(map[string]map[string]uint64),
+	}
+}
+
+// LoadGasSnapshot reads a gas snapshot file. A missing file yields an empty snapshot.
+func LoadGasSnapshot(path string) (*GasSnapshot, error) {
+	snapshot := NewGasSnapshot()
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return snapshot, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, &snapshot.Scenarios)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas snapshot file %s: %w", path, err)
+	}
+
+	return snapshot, nil
+}
+
+// Save writes the gas snapshot to a file, with sorted keys, so that it diffs well.
+func (gs *GasSnapshot) Save(path string) error {
+	data, err := json.MarshalIndent(gs.Scenarios, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Record adds the gas used by a step. Repeated step ids in the same scenario get a "#n" suffix.
+func (gs *GasSnapshot) Record(scenarioKey string, stepID string, gasUsed uint64) {
+	if len(scenarioKey) == 0 {
+		scenarioKey = unnamedScenario
+	}
+
+	steps, found := gs.Scenarios[scenarioKey]
+	if !found {
+		steps = make(map[string]uint64)
+		gs.Scenarios[scenarioKey] = steps
+	}
+
+	key := stepID
+	for i := 2; ; i++ {
+		_, exists := steps[key]
+		if !exists {
+			break
+		}
+		key = fmt.Sprintf("%s#%d", stepID, i)
+	}
+	steps[key] = gasUsed
+}
+
+// Merge replaces the scenarios of the current snapshot with the ones found in the other snapshot.
+func (gs *GasSnapshot) Merge(other *GasSnapshot) {
+	for scenarioName, steps := range other.Scenarios {
+		gs.Scenarios[scenarioName] = steps
+	}
+}
+
+// GasSnapshotDiff describes a step whose gas differs from the gas snapshot.
+type GasSnapshotDiff struct {
+	Scenario string
+	StepID   string
+	Expected uint64
+	Actual   uint64
+	IsNew    bool
+	IsGone   bool
+}
+
+// String renders the diff in a human-readable form.
+func (diff *GasSnapshotDiff) String() string {
+	switch {
+	case diff.IsNew:
+		return fmt.Sprintf("%s / %s: new step, gas used %d", diff.Scenario, diff.StepID, diff.Actual)
+	case diff.IsGone:
+		return fmt.Sprintf("%s / %s: step no longer run, gas used was %d", diff.Scenario, diff.StepID, diff.Expected)
+	case diff.Expected == 0:
+		return fmt.Sprintf("%s / %s: gas used %d -> %d (%+d)",
+			diff.Scenario, diff.StepID, diff.Expected, diff.Actual, int64(diff.Actual-diff.Expected))
+	default:
+		change := float64(int64(diff.Actual-diff.Expected)) * 100 / float64(diff.Expected)
+		return fmt.Sprintf("%s / %s: gas used %d -> %d (%+d, %+.2f%%)",
+			diff.Scenario, diff.StepID, diff.Expected, diff.Actual, int64(diff.Actual-diff.Expected), change)
+	}
+}
+
+// Compare checks the actual gas used against the snapshot, only for the scenarios that were actually run.
+// Changes up to tolerancePercent of the expected value are accepted.
+func (gs *GasSnapshot) Compare(actual *GasSnapshot, tolerancePercent float64) []*GasSnapshotDiff {
+	diffs := make([]*GasSnapshotDiff, 0)
+
+	for _, scenarioName := range sortedScenarioNames(actual.Scenarios) {
+		actualSteps := actual.Scenarios[scenarioName]
+		expectedSteps := gs.Scenarios[scenarioName]
+
+		for _, stepID := range sortedStepIDs(actualSteps) {
+			actualGas := actualSteps[stepID]
+			expectedGas, found := expectedSteps[stepID]
+			if !found {
+				diffs = append(diffs, &GasSnapshotDiff{
+					Scenario: scenarioName,
+					StepID:   stepID,
+					Actual:   actualGas,
+					IsNew:    true,
+				})
+				continue
+			}
+
+			if !isWithinTolerance(expectedGas, actualGas, tolerancePercent) {
+				diffs = append(diffs, &GasSnapshotDiff{
+					Scenario: scenarioName,
+					StepID:   stepID,
+					Expected: expectedGas,
+					Actual:   actualGas,
+				})
+			}
+		}
+
+		for _, stepID := range sortedStepIDs(expectedSteps) {
+			_, found := actualSteps[stepID]
+			if !found {
+				diffs = append(diffs, &GasSnapshotDiff{
+					Scenario: scenarioName,
+					StepID:   stepID,
+					Expected: expectedSteps[stepID],
+					IsGone:   true,
+				})
+			}
+		}
+	}
+
+	return diffs
+}
+
+// GasSnapshotDiffsError wraps the diffs into an ErrGasSnapshotMismatch error, or returns nil if there are none.
+func GasSnapshotDiffsError(diffs []*GasSnapshotDiff) error {
+	if len(diffs) == 0 {
+		return nil
+	}
+
+	lines := make([]string, 0, len(diffs))
+	for _, diff := range diffs {
+		lines = append(lines, "  "+diff.String())
+	}
+
+	return fmt.Errorf("%w, %d steps differ:\n%s", ErrGasSnapshotMismatch, len(diffs), strings.Join(lines, "\n"))
+}
+
+func isWithinTolerance(expected uint64, actual uint64, tolerancePercent float64) bool {
+	if expected == actual {
+		return true
+	}
+
+	difference := actual - expected
+	if actual < expected {
+		difference = expected - actual
+	}
+
+	return float64(difference) <= float64(expected)*tolerancePercent/100
+}
+
+func sortedScenarioNames(scenarios map[string]map[string]uint64) []string {
+	names := make([]string, 0, len(scenarios))
+	for name := range scenarios {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedStepIDs(steps map[string]uint64) []string {
+	stepIDs := make([]string, 0, len(steps))
+	for stepID := range steps {
+		stepIDs = append(stepIDs, stepID)
+	}
+	sort.Strings(stepIDs)
+	return stepIDs
+}
+
+func (ae *VMTestExecutor) recordGasSnapshot(stepID string, gasUsed uint64) {
+	if ae.GasSnapshot == nil {
+		return
+	}
+
+	key := ""
+	if len(ae.scenarioNames) > 0 {
+		key = ae.scenarioNames[0]
+	}
+	if len(ae.scenarioPaths) > 0 && len(ae.scenarioPaths[0]) > 0 {
+		key = ae.GasSnapshot.relativeKey(ae.scenarioPaths[0])
+	}
+	ae.GasSnapshot.Record(key, stepID, gasUsed)
+}
+
+// relativeKey turns a scenario file path into a key relative to the snapshot directory,
+// so that snapshots do not depend on where the tests are run from.
+func (gs *GasSnapshot) relativeKey(scenarioPath string) string {
+	if len(gs.BaseDir) == 0 {
+		return filepath.ToSlash(scenarioPath)
+	}
+
+	absBaseDir, err := filepath.Abs(gs.BaseDir)
+	if err != nil {
+		return filepath.ToSlash(scenarioPath)
+	}
+	absScenarioPath, err := filepath.Abs(scenarioPath)
+	if err != nil {
+		return filepath.ToSlash(scenarioPath)
+	}
+	relativePath, err := filepath.Rel(absBaseDir, absScenarioPath)
+	if err != nil {
+		return filepath.ToSlash(scenarioPath)
+	}
+
+	return filepath.ToSlash(relativePath)
+}
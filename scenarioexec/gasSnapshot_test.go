@@ -0,0 +1,102 @@
+package scenarioexec
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasSnapshot_IsWithinTolerance(t *testing.T) {
+	require.True(t, isWithinTolerance(100, 100, 0))
+	require.True(t, isWithinTolerance(0, 0, 0))
+	require.False(t, isWithinTolerance(100, 101, 0))
+	require.True(t, isWithinTolerance(100, 101, 1))
+	require.True(t, isWithinTolerance(100, 99, 1))
+	require.False(t, isWithinTolerance(100, 102, 1))
+	require.False(t, isWithinTolerance(100, 98, 1))
+	require.False(t, isWithinTolerance(0, 1, 50))
+}
+
+func TestGasSnapshot_Compare(t *testing.T) {
+	expected := NewGasSnapshot()
+	expected.Record("a.scen.json", "tx-1", 100)
+	expected.Record("a.scen.json", "tx-2", 200)
+	expected.Record("a.scen.json", "tx-gone", 300)
+	expected.Record("not-run.scen.json", "tx-1", 400)
+
+	actual := NewGasSnapshot()
+	actual.Record("a.scen.json", "tx-1", 101)
+	actual.Record("a.scen.json", "tx-2", 250)
+	actual.Record("a.scen.json", "tx-new", 50)
+
+	diffs := expected.Compare(actual, 1)
+	require.Equal(t, []*GasSnapshotDiff{
+		{Scenario: "a.scen.json", StepID: "tx-2", Expected: 200, Actual: 250},
+		{Scenario: "a.scen.json", StepID: "tx-new", Actual: 50, IsNew: true},
+		{Scenario: "a.scen.json", StepID: "tx-gone", Expected: 300, IsGone: true},
+	}, diffs)
+
+	require.Len(t, expected.Compare(actual, 25), 2)
+	require.Nil(t, GasSnapshotDiffsError(expected.Compare(expected, 0)))
+	require.ErrorIs(t, GasSnapshotDiffsError(diffs), ErrGasSnapshotMismatch)
+}
+
+func TestGasSnapshot_RecordRepeatedSteps(t *testing.T) {
+	snapshot := NewGasSnapshot()
+	snapshot.Record("", "tx", 1)
+	snapshot.Record("", "tx", 2)
+	snapshot.Record("", "tx", 3)
+
+	require.Equal(t, map[string]uint64{"tx": 1, "tx#2": 2, "tx#3": 3}, snapshot.Scenarios[unnamedScenario])
+}
+
+func TestGasSnapshotDiff_String(t *testing.T) {
+	diff := &GasSnapshotDiff{Scenario: "s", StepID: "tx", Expected: 200, Actual: 250}
+	require.Equal(t, "s / tx: gas used 200 -> 250 (+50, +25.00%)", diff.String())
+
+	diff = &GasSnapshotDiff{Scenario: "s", StepID: "tx", Expected: 0, Actual: 250}
+	require.Equal(t, "s / tx: gas used 0 -> 250 (+250)", diff.String())
+
+	diff = &GasSnapshotDiff{Scenario: "s", StepID: "tx", Actual: 250, IsNew: true}
+	require.Equal(t, "s / tx: new step, gas used 250", diff.String())
+
+	diff = &GasSnapshotDiff{Scenario: "s", StepID: "tx", Expected: 200, IsGone: true}
+	require.Equal(t, "s / tx: step no longer run, gas used was 200", diff.String())
+}
+
+func TestGasSnapshot_KeyedByScenarioPath(t *testing.T) {
+	snapshotDir := t.TempDir()
+	executor := &VMTestExecutor{GasSnapshot: NewGasSnapshot()}
+	executor.GasSnapshot.BaseDir = snapshotDir
+
+	executor.scenarioNames = []string{""}
+	executor.scenarioPaths = []string{filepath.Join(snapshotDir, "first", "test.scen.json")}
+	executor.recordGasSnapshot("tx", 10)
+
+	executor.scenarioPaths = []string{filepath.Join(snapshotDir, "second", "test.scen.json")}
+	executor.recordGasSnapshot("tx", 20)
+
+	executor.scenarioPaths = []string{""}
+	executor.recordGasSnapshot("tx", 30)
+
+	require.Equal(t, map[string]map[string]uint64{
+		"first/test.scen.json":  {"tx": 10},
+		"second/test.scen.json": {"tx": 20},
+		unnamedScenario:         {"tx": 30},
+	}, executor.GasSnapshot.Scenarios)
+}
+
+func TestScenarioFileResolver_ScenarioPath(t *testing.T) {
+	resolver := NewScenarioFileResolver()
+	require.Equal(t, "", scenarioPath(resolver))
+
+	resolver.SetContext("dir/test.scen.json")
+	require.Equal(t, "dir/test.scen.json", scenarioPath(resolver))
+	require.Equal(t, filepath.Join("dir", "contract.wasm"), resolver.ResolveAbsolutePath("contract.wasm"))
+
+	cloned := resolver.Clone()
+	require.Equal(t, "dir/test.scen.json", scenarioPath(cloned))
+	cloned.SetContext("other/ext.scen.json")
+	require.Equal(t, "dir/test.scen.json", scenarioPath(resolver))
+}
@@ -0,0 +1,367 @@
+package scenarioexec
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strings"
+
+	er "github.com/multiversx/mx-chain-scenario-go/expression/reconstructor"
+	mj "github.com/multiversx/mx-chain-scenario-go/model"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	worldmock "github.com/multiversx/mx-chain-vm-go/mock/world"
+	"github.com/multiversx/mx-chain-vm-go/vmhost/contexts"
+)
+
+var reconstructor = er.ExprReconstructor{}
+
+// GasSweepOptions configures the gas limits used when sweeping a transaction.
+type GasSweepOptions struct {
+	// MinGasLimit is the first gas limit tried.
+	MinGasLimit uint64
+
+	// MaxGasLimit is the last gas limit tried. The gas limit of the transaction is used if 0.
+	MaxGasLimit uint64
+
+	// Step is the distance between two consecutive gas limits. Defaults to 1.
+	Step uint64
+
+	// Bisect only samples the gas limits needed to locate the behaviour changes,
+	// assuming that the behaviour is the same between two samples which behave the same.
+	Bisect bool
+}
+
+// GasSweepRun holds the outcome of a single execution of the swept transaction.
+type GasSweepRun struct {
+	GasLimit      uint64
+	ReturnCode    vmcommon.ReturnCode
+	ReturnMessage string
+	GasRemaining  uint64
+	Err           error
+	Violations    []string
+}
+
+// Behaviour describes the observable outcome of the run, used to detect behaviour changes.
+func (run *GasSweepRun) Behaviour() string {
+	if run.Err != nil {
+		return fmt.Sprintf("error: %s", run.Err.Error())
+	}
+	return fmt.Sprintf("%s: %s", run.ReturnCode.String(), run.ReturnMessage)
+}
+
+// GasSweepChange marks a gas limit at which the behaviour of the transaction differs from
+// the one observed at the previous gas limit that was tried.
+type GasSweepChange struct {
+	GasLimit          uint64
+	PreviousGasLimit  uint64
+	Behaviour         string
+	PreviousBehaviour string
+}
+
+// GasSweepReport holds all the runs of a gas sweep, in increasing gas limit order.
+type GasSweepReport struct {
+	TxIdent string
+	Runs    []*GasSweepRun
+	Changes []*GasSweepChange
+}
+
+// NumViolations returns the total number of invariant violations found during the sweep.
+func (report *GasSweepReport) NumViolations() int {
+	numViolations := 0
+	for _, run := range report.Runs {
+		numViolations += len(run.Violations)
+	}
+	return numViolations
+}
+
+// String renders the report in a human-readable form.
+func (report *GasSweepReport) String() string {
+	sb := &strings.Builder{}
+	_, _ = fmt.Fprintf(sb, "gas sweep of tx %s: %d runs, %d behaviour changes, %d invariant violations\n",
+		report.TxIdent, len(report.Runs), len(report.Changes), report.NumViolations())
+
+	if len(report.Runs) > 0 {
+		_, _ = fmt.Fprintf(sb, "  gas %d: %s\n", report.Runs[0].GasLimit, report.Runs[0].Behaviour())
+	}
+	for _, change := range report.Changes {
+		_, _ = fmt.Fprintf(sb, "  gas %d (after %d): %s\n", change.GasLimit, change.PreviousGasLimit, change.Behaviour)
+	}
+	for _, run := range report.Runs {
+		for _, violation := range run.Violations {
+			_, _ = fmt.Fprintf(sb, "  gas %d: invariant violated: %s\n", run.GasLimit, violation)
+		}
+	}
+
+	return sb.String()
+}
+
+// SweepTxGas re-runs the transaction of the given step with increasing gas limits and checks
+// state-consistency invariants after each run. The world is restored after each run, so the
+// state is left untouched by the sweep.
+func (ae *VMTestExecutor) SweepTxGas(step *mj.TxStep, options GasSweepOptions) (*GasSweepReport, error) {
+	if step.Tx.Type != mj.ScCall && step.Tx.Type != mj.ScDeploy {
+		return nil, fmt.Errorf("gas sweep of tx %s: only scCall and scDeploy transactions can be swept", step.TxIdent)
+	}
+
+	maxGasLimit := options.MaxGasLimit
+	if maxGasLimit == 0 {
+		maxGasLimit = step.Tx.GasLimit.Value
+	}
+	if options.MinGasLimit > maxGasLimit {
+		return nil, fmt.Errorf("gas sweep of tx %s: min gas limit %d is above max gas limit %d",
+			step.TxIdent, options.MinGasLimit, maxGasLimit)
+	}
+	stepSize := options.Step
+	if stepSize == 0 {
+		stepSize = 1
+	}
+
+	sweeper := &gasSweeper{
+		executor: ae,
+		step:     step,
+		runs:     make(map[uint64]*GasSweepRun),
+	}
+
+	var gasLimits []uint64
+	for gasLimit := options.MinGasLimit; gasLimit <= maxGasLimit && gasLimit >= options.MinGasLimit; gasLimit += stepSize {
+		gasLimits = append(gasLimits, gasLimit)
+	}
+	if gasLimits[len(gasLimits)-1] != maxGasLimit {
+		gasLimits = append(gasLimits, maxGasLimit)
+	}
+
+	if options.Bisect {
+		sweeper.bisect(gasLimits)
+	} else {
+		for _, gasLimit := range gasLimits {
+			sweeper.run(gasLimit)
+		}
+	}
+
+	return sweeper.report(gasLimits), nil
+}
+
+// writeGasReport writes a report of the gas tools to the GasReportWriter, if any
+func (ae *VMTestExecutor) writeGasReport(report string) {
+	if ae.GasReportWriter == nil {
+		return
+	}
+
+	_, _ = io.WriteString(ae.GasReportWriter, report)
+}
+
+type gasSweeper struct {
+	executor *VMTestExecutor
+	step     *mj.TxStep
+	runs     map[uint64]*GasSweepRun
+}
+
+func (sweeper *gasSweeper) bisect(gasLimits []uint64) {
+	first := sweeper.run(gasLimits[0])
+	last := sweeper.run(gasLimits[len(gasLimits)-1])
+	sweeper.bisectInterval(gasLimits, first, last)
+}
+
+func (sweeper *gasSweeper) bisectInterval(gasLimits []uint64, first *GasSweepRun, last *GasSweepRun) {
+	if len(gasLimits) <= 2 || first.Behaviour() == last.Behaviour() {
+		return
+	}
+
+	middleIndex := len(gasLimits) / 2
+	middle := sweeper.run(gasLimits[middleIndex])
+	sweeper.bisectInterval(gasLimits[:middleIndex+1], first, middle)
+	sweeper.bisectInterval(gasLimits[middleIndex:], middle, last)
+}
+
+func (sweeper *gasSweeper) run(gasLimit uint64) *GasSweepRun {
+	existingRun, found := sweeper.runs[gasLimit]
+	if found {
+		return existingRun
+	}
+
+	tx := *sweeper.step.Tx
+	tx.GasLimit = mj.JSONUint64{Value: gasLimit, Original: fmt.Sprintf("%d", gasLimit)}
+
+	run := &GasSweepRun{
+		GasLimit: gasLimit,
+	}
+	sweeper.executor.withWorldCopy(func(accountsBefore worldmock.AccountMap) {
+		output, err := sweeper.executor.executeTx(sweeper.step.TxIdent, &tx)
+		accounts := &gasSweepAccounts{
+			beforeTx: accountsBefore,
+			afterTx:  sweeper.executor.World.AcctMap,
+		}
+
+		run.Err = err
+		if output != nil {
+			run.ReturnCode = output.ReturnCode
+			run.ReturnMessage = output.ReturnMessage
+			run.GasRemaining = output.GasRemaining
+		}
+		run.Violations = checkGasSweepInvariants(sweeper.executor.World, accounts, &tx, output, err)
+	})
+
+	sweeper.runs[gasLimit] = run
+	return run
+}
+
+func (sweeper *gasSweeper) report(gasLimits []uint64) *GasSweepReport {
+	report := &GasSweepReport{
+		TxIdent: sweeper.step.TxIdent,
+		Runs:    make([]*GasSweepRun, 0, len(sweeper.runs)),
+		Changes: make([]*GasSweepChange, 0),
+	}
+
+	var previous *GasSweepRun
+	for _, gasLimit := range gasLimits {
+		run, found := sweeper.runs[gasLimit]
+		if !found {
+			continue
+		}
+
+		report.Runs = append(report.Runs, run)
+		if previous != nil && previous.Behaviour() != run.Behaviour() {
+			report.Changes = append(report.Changes, &GasSweepChange{
+				GasLimit:          run.GasLimit,
+				PreviousGasLimit:  previous.GasLimit,
+				Behaviour:         run.Behaviour(),
+				PreviousBehaviour: previous.Behaviour(),
+			})
+		}
+		previous = run
+	}
+
+	return report
+}
+
+// gasSweepAccounts holds the accounts before and after a successful run, compared by its invariants
+type gasSweepAccounts struct {
+	beforeTx worldmock.AccountMap
+	afterTx  worldmock.AccountMap
+}
+
+func checkGasSweepInvariants(
+	world *worldmock.MockWorld,
+	accounts *gasSweepAccounts,
+	tx *mj.Transaction,
+	output *vmcommon.VMOutput,
+	executionErr error,
+) []string {
+	succeeded := executionErr == nil && output != nil && output.ReturnCode == vmcommon.Ok
+	if !succeeded {
+		return checkFailedExecutionOutput(output)
+	}
+
+	violations := make([]string, 0)
+	gasPaid := big.NewInt(0).Mul(
+		big.NewInt(0).SetUint64(tx.GasLimit.Value),
+		big.NewInt(0).SetUint64(tx.GasPrice.Value))
+	violations = append(violations, checkBalancesConserved(accounts.afterTx, accounts.beforeTx, gasPaid)...)
+
+	if !hasCrossShardTransfers(world, output) {
+		violations = append(violations, checkAsyncContextsCleanedUp(accounts.afterTx, accounts.beforeTx)...)
+	}
+
+	return violations
+}
+
+// checkFailedExecutionOutput checks that a failed execution returns no changes. The outputs of the failed
+// executions are not applied to the world, so any change they carry is a partial execution leaking out of the VM.
+func checkFailedExecutionOutput(output *vmcommon.VMOutput) []string {
+	violations := make([]string, 0)
+	if output == nil {
+		return violations
+	}
+
+	addresses := make([]string, 0, len(output.OutputAccounts))
+	for address := range output.OutputAccounts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	for _, address := range addresses {
+		outputAccount := output.OutputAccounts[address]
+		reconstructedAddress := reconstructor.Reconstruct([]byte(address), er.AddressHint)
+		if len(outputAccount.StorageUpdates) > 0 {
+			violations = append(violations, fmt.Sprintf(
+				"failed execution returned %d storage updates for address %s",
+				len(outputAccount.StorageUpdates), reconstructedAddress))
+		}
+		if len(outputAccount.OutputTransfers) > 0 {
+			violations = append(violations, fmt.Sprintf(
+				"failed execution returned %d transfers from address %s",
+				len(outputAccount.OutputTransfers), reconstructedAddress))
+		}
+		if outputAccount.BalanceDelta != nil && outputAccount.BalanceDelta.Sign() != 0 {
+			violations = append(violations, fmt.Sprintf(
+				"failed execution returned a balance change of %d for address %s",
+				outputAccount.BalanceDelta, reconstructedAddress))
+		}
+	}
+
+	return violations
+}
+
+func checkBalancesConserved(
+	accountsAfter worldmock.AccountMap,
+	accountsBefore worldmock.AccountMap,
+	expectedDifference *big.Int,
+) []string {
+	difference := big.NewInt(0).Sub(sumOfBalances(accountsBefore), sumOfBalances(accountsAfter))
+	if difference.Cmp(expectedDifference) != 0 {
+		return []string{fmt.Sprintf(
+			"balances not conserved: total balance decreased by %d, expected %d",
+			difference, expectedDifference)}
+	}
+
+	return nil
+}
+
+func checkAsyncContextsCleanedUp(
+	accountsAfter worldmock.AccountMap,
+	accountsBefore worldmock.AccountMap,
+) []string {
+	violations := make([]string, 0)
+	for address, accountAfter := range accountsAfter {
+		accountBefore := accountsBefore[address]
+		for key := range accountAfter.Storage {
+			if !bytes.HasPrefix([]byte(key), contexts.AsyncContextStoragePrefix) {
+				continue
+			}
+			if accountBefore != nil && accountBefore.Storage[key] != nil {
+				continue
+			}
+			violations = append(violations, fmt.Sprintf(
+				"async context left in storage of address %s under key %s",
+				reconstructor.Reconstruct([]byte(address), er.AddressHint), reconstructor.Reconstruct([]byte(key), er.NoHint)))
+		}
+	}
+
+	return violations
+}
+
+func hasCrossShardTransfers(world *worldmock.MockWorld, output *vmcommon.VMOutput) bool {
+	if output == nil {
+		return false
+	}
+
+	for _, outputAccount := range output.OutputAccounts {
+		if len(outputAccount.OutputTransfers) > 0 && world.GetShardOfAddress(outputAccount.Address) != world.SelfShardID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sumOfBalances(accounts worldmock.AccountMap) *big.Int {
+	sum := big.NewInt(0)
+	for _, account := range accounts {
+		if account.Balance != nil {
+			sum.Add(sum, account.Balance)
+		}
+	}
+	return sum
+}
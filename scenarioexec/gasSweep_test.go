@@ -0,0 +1,121 @@
+package scenarioexec
+
+import (
+	"math/big"
+	"testing"
+
+	mj "github.com/multiversx/mx-chain-scenario-go/model"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	worldmock "github.com/multiversx/mx-chain-vm-go/mock/world"
+	"github.com/multiversx/mx-chain-vm-go/vmhost/contexts"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	sweepSender   = []byte("sender__________________________")
+	sweepContract = []byte("contract________________________")
+)
+
+func makeSweepAccounts(senderBalance int64, contractStorage map[string][]byte) worldmock.AccountMap {
+	accounts := worldmock.NewAccountMap()
+	accounts.PutAccount(&worldmock.Account{
+		Address: sweepSender,
+		Balance: big.NewInt(senderBalance),
+		Storage: make(map[string][]byte),
+	})
+	accounts.PutAccount(&worldmock.Account{
+		Address: sweepContract,
+		Balance: big.NewInt(0),
+		Storage: contractStorage,
+	})
+	return accounts
+}
+
+func makeSweepTx(gasLimit uint64, gasPrice uint64) *mj.Transaction {
+	return &mj.Transaction{
+		GasLimit: mj.JSONUint64{Value: gasLimit},
+		GasPrice: mj.JSONUint64{Value: gasPrice},
+	}
+}
+
+func TestGasSweep_FailedExecutionInvariants(t *testing.T) {
+	t.Parallel()
+
+	world := worldmock.NewMockWorld()
+	accounts := &gasSweepAccounts{
+		beforeTx: makeSweepAccounts(1000, map[string][]byte{}),
+		afterTx:  makeSweepAccounts(900, map[string][]byte{}),
+	}
+
+	failedOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.OutOfGas}
+	violations := checkGasSweepInvariants(world, accounts, makeSweepTx(100, 1), failedOutput, nil)
+	require.Empty(t, violations)
+
+	failedOutput.OutputAccounts = map[string]*vmcommon.OutputAccount{
+		string(sweepContract): {
+			Address:        sweepContract,
+			BalanceDelta:   big.NewInt(-10),
+			StorageUpdates: map[string]*vmcommon.StorageUpdate{"a": {Offset: []byte("a"), Data: []byte{1}}},
+		},
+		string(sweepSender): {
+			Address:         sweepSender,
+			BalanceDelta:    big.NewInt(0),
+			OutputTransfers: []vmcommon.OutputTransfer{{Value: big.NewInt(10)}},
+		},
+	}
+	violations = checkGasSweepInvariants(world, accounts, makeSweepTx(100, 1), failedOutput, nil)
+	require.Len(t, violations, 3)
+	require.Contains(t, violations[0], "failed execution returned 1 storage updates for address")
+	require.Contains(t, violations[1], "failed execution returned a balance change of -10 for address")
+	require.Contains(t, violations[2], "failed execution returned 1 transfers from address")
+}
+
+func TestGasSweep_SuccessfulExecutionInvariants(t *testing.T) {
+	t.Parallel()
+
+	world := worldmock.NewMockWorld()
+	okOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}
+	beforeTx := makeSweepAccounts(1000, map[string][]byte{})
+
+	violations := checkGasSweepInvariants(world, &gasSweepAccounts{
+		beforeTx: beforeTx,
+		afterTx:  makeSweepAccounts(900, map[string][]byte{"a": {1}}),
+	}, makeSweepTx(50, 2), okOutput, nil)
+	require.Empty(t, violations)
+
+	asyncContextKey := string(append(append([]byte{}, contexts.AsyncContextStoragePrefix...), 1))
+	violations = checkGasSweepInvariants(world, &gasSweepAccounts{
+		beforeTx: beforeTx,
+		afterTx:  makeSweepAccounts(900, map[string][]byte{asyncContextKey: {1}}),
+	}, makeSweepTx(100, 2), okOutput, nil)
+	require.Len(t, violations, 2)
+	require.Equal(t, "balances not conserved: total balance decreased by 100, expected 200", violations[0])
+	require.Contains(t, violations[1], "async context left in storage")
+}
+
+func TestGasSweep_Report(t *testing.T) {
+	t.Parallel()
+
+	sweeper := &gasSweeper{
+		step: &mj.TxStep{TxIdent: "tx"},
+		runs: map[uint64]*GasSweepRun{
+			10: {GasLimit: 10, ReturnCode: vmcommon.OutOfGas, ReturnMessage: "not enough gas"},
+			20: {GasLimit: 20, ReturnCode: vmcommon.OutOfGas, ReturnMessage: "not enough gas"},
+			30: {GasLimit: 30, ReturnCode: vmcommon.Ok, Violations: []string{"violation"}},
+		},
+	}
+
+	report := sweeper.report([]uint64{10, 15, 20, 30})
+	require.Len(t, report.Runs, 3)
+	require.Equal(t, []*GasSweepChange{{
+		GasLimit:          30,
+		PreviousGasLimit:  20,
+		Behaviour:         "ok: ",
+		PreviousBehaviour: "out of gas: not enough gas",
+	}}, report.Changes)
+	require.Equal(t, 1, report.NumViolations())
+	require.Equal(t, "gas sweep of tx tx: 3 runs, 1 behaviour changes, 1 invariant violations\n"+
+		"  gas 10: out of gas: not enough gas\n"+
+		"  gas 30 (after 20): ok: \n"+
+		"  gas 30: invariant violated: violation\n", report.String())
+}
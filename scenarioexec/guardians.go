@@ -0,0 +1,119 @@
+package scenarioexec
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	mj "github.com/multiversx/mx-chain-scenario-go/model"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	worldmock "github.com/multiversx/mx-chain-vm-go/mock/world"
+)
+
+// guardiansActivationEpochs is the storage key of the guardians account holding the guardian activation delay
+const guardiansActivationEpochs = "activationEpochs"
+
+// addressLength is the length of the account addresses used as keys by the guardian accounts
+const addressLength = 32
+
+// setGuardians sets the guardians of the accounts from the storage of the reserved guardians account.
+// Each storage key is the address of a guarded account, and its value is the address of its guardian,
+// optionally followed by the guardian service UID. The guardian is active right away and the account
+// is marked as guarded. An empty value removes the guardian and unguards the account. The
+// "activationEpochs" key sets the delay after which the guardians set by SetGuardian calls become active.
+func (ae *VMTestExecutor) setGuardians(scenAccount *mj.Account) error {
+	for _, stkvp := range scenAccount.Storage {
+		key := stkvp.Key.Value
+		value := stkvp.Value.Value
+
+		if string(key) == guardiansActivationEpochs {
+			handler, ok := ae.World.GuardedAccountHandler.(*worldmock.GuardianHandler)
+			if !ok {
+				return errors.New("guardians: the world does not use the default guardian handler")
+			}
+			activationEpochs := big.NewInt(0).SetBytes(value)
+			if !activationEpochs.IsUint64() || activationEpochs.Uint64() > math.MaxUint32 {
+				return fmt.Errorf("guardians: setting \"%s\" is too large", guardiansActivationEpochs)
+			}
+			handler.ActivationEpochs = uint32(activationEpochs.Uint64())
+			continue
+		}
+
+		if len(key) != addressLength {
+			return fmt.Errorf("guardians: key %s is neither a setting nor an address", stkvp.Key.Original)
+		}
+		if len(value) > 0 && len(value) < addressLength {
+			return fmt.Errorf("guardians: invalid guardian for %s", stkvp.Key.Original)
+		}
+
+		var guardian, serviceUID []byte
+		if len(value) > 0 {
+			guardian = value[:addressLength]
+			serviceUID = value[addressLength:]
+		}
+		err := ae.World.SetActiveGuardian(key, guardian, serviceUID)
+		if err != nil {
+			return fmt.Errorf("guardians: account %s: %w", stkvp.Key.Original, err)
+		}
+	}
+
+	return nil
+}
+
+// setTxGuardians configures the co-signatures of the transactions from the storage of the reserved tx guardian
+// account. Each storage key is the address of a sender and its value is the address of the guardian co-signing
+// all the following transactions of that sender. An empty value removes the co-signature.
+func (ae *VMTestExecutor) setTxGuardians(scenAccount *mj.Account) error {
+	if !scenAccount.Update {
+		ae.World.TxGuardians = nil
+	}
+
+	for _, stkvp := range scenAccount.Storage {
+		if len(stkvp.Key.Value) != addressLength {
+			return fmt.Errorf("tx guardian: key %s is not an address", stkvp.Key.Original)
+		}
+		ae.World.SetTxGuardian(stkvp.Key.Value, stkvp.Value.Value)
+	}
+
+	return nil
+}
+
+// checkTxGuardian imitates the protocol checks of the guardian co-signature: the transactions of a guarded
+// account must be co-signed by its active guardian, except for SetGuardian, which may change the guardian
+// after the activation delay, while the transactions of the other accounts must not be co-signed.
+// It returns the output rejecting the transaction, or nil if the transaction can be executed.
+func (ae *VMTestExecutor) checkTxGuardian(tx *mj.Transaction) *vmcommon.VMOutput {
+	if !tx.Type.HasSender() {
+		return nil
+	}
+
+	txGuardian := ae.World.GetTxGuardian(tx.From.Value)
+	sender := ae.World.AcctMap.GetAccount(tx.From.Value)
+	if sender == nil || !ae.World.IsGuarded(tx.From.Value) {
+		if len(txGuardian) > 0 {
+			return userErrorResult("guarded transaction not expected")
+		}
+		return nil
+	}
+
+	if len(txGuardian) == 0 {
+		isSetGuardian := tx.Type == mj.ScCall && tx.Function == core.BuiltInFunctionSetGuardian
+		if isSetGuardian {
+			return nil
+		}
+		return userErrorResult("transaction not co-signed by the guardian of the guarded account")
+	}
+
+	activeGuardian, err := ae.World.GuardedAccountHandler.GetActiveGuardian(sender)
+	if err != nil {
+		return userErrorResult(err.Error())
+	}
+	if !bytes.Equal(activeGuardian, txGuardian) {
+		return userErrorResult(worldmock.ErrGuardianMismatch.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package scenarioexec
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	er "github.com/multiversx/mx-chain-scenario-go/expression/reconstructor"
+	mj "github.com/multiversx/mx-chain-scenario-go/model"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-go/config"
+	worldmock "github.com/multiversx/mx-chain-vm-go/mock/world"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	guardedUser = []byte("guarded_user____________________")
+	guardian    = []byte("guardian________________________")
+)
+
+func newGuardiansExecutor(t *testing.T) *VMTestExecutor {
+	world := worldmock.NewMockWorld()
+	require.Nil(t, world.InitBuiltinFunctions(config.MakeGasMapForTests()))
+	world.AcctMap.PutAccount(&worldmock.Account{
+		Address: guardedUser,
+		Balance: big.NewInt(1000),
+		Storage: make(map[string][]byte),
+	})
+
+	return &VMTestExecutor{
+		World:             world,
+		exprReconstructor: er.ExprReconstructor{},
+	}
+}
+
+func newUserAccountCall(function string, arguments ...[]byte) *mj.Transaction {
+	tx := &mj.Transaction{
+		Type:      mj.ScCall,
+		From:      mj.JSONBytesFromString{Value: guardedUser},
+		To:        mj.JSONBytesFromString{Value: guardedUser},
+		Function:  function,
+		EGLDValue: mj.JSONBigInt{Value: big.NewInt(0)},
+		GasLimit:  mj.JSONUint64{Value: 1_000_000},
+	}
+	for _, argument := range arguments {
+		tx.Arguments = append(tx.Arguments, mj.JSONBytesFromTree{Value: argument})
+	}
+	return tx
+}
+
+func TestScCall_BuiltinFunctionOnUserAccount(t *testing.T) {
+	executor := newGuardiansExecutor(t)
+
+	// SetGuardian is called on the account of the sender, which has no code
+	output, err := executor.executeTx("1", newUserAccountCall(core.BuiltInFunctionSetGuardian, guardian, []byte("uid")))
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, output.ReturnCode, output.ReturnMessage)
+	require.NotEmpty(t, executor.World.AcctMap.GetAccount(guardedUser).Storage[string(worldmock.GuardiansKey)])
+
+	_, err = executor.executeTx("2", newUserAccountCall("notBuiltin"))
+	require.ErrorContains(t, err, "is not a smart contract")
+
+	// a failing builtin call ends the step with an error, like any other call on an account without code
+	_, err = executor.executeTx("3", newUserAccountCall(core.BuiltInFunctionSetGuardian))
+	require.ErrorContains(t, err, "builtin function SetGuardian failed")
+	require.Equal(t, uint64(1), executor.World.AcctMap.GetAccount(guardedUser).Nonce)
+}
+
+func TestExecuteTx_RejectedByGuardianChecks(t *testing.T) {
+	executor := newGuardiansExecutor(t)
+	require.Nil(t, executor.World.SetActiveGuardian(guardedUser, guardian, nil))
+
+	output, err := executor.executeTx("1", newUserAccountCall(core.BuiltInFunctionGuardAccount))
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.UserError, output.ReturnCode)
+	require.Equal(t, "transaction not co-signed by the guardian of the guarded account", output.ReturnMessage)
+	require.Equal(t, uint64(0), executor.World.AcctMap.GetAccount(guardedUser).Nonce)
+
+	executor.World.SetTxGuardian(guardedUser, []byte("other_guardian__________________"))
+	output, err = executor.executeTx("2", newUserAccountCall(core.BuiltInFunctionGuardAccount))
+	require.Nil(t, err)
+	require.Equal(t, worldmock.ErrGuardianMismatch.Error(), output.ReturnMessage)
+	require.Equal(t, uint64(0), executor.World.AcctMap.GetAccount(guardedUser).Nonce)
+}
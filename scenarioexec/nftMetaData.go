@@ -0,0 +1,247 @@
+package scenarioexec
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	er "github.com/multiversx/mx-chain-scenario-go/expression/reconstructor"
+	mj "github.com/multiversx/mx-chain-scenario-go/model"
+	worldmock "github.com/multiversx/mx-chain-vm-go/mock/world"
+)
+
+// NFTMetaDataAddress is the reserved address used by scenarios to set and check the token fields which are not
+// part of the ESDT syntax: the dynamic token types and the versions of the NFT metadata fields.
+// Its storage is interpreted as token fields instead of being saved as an account.
+// It is the value of the scenario expression "address:nft-metadata".
+var NFTMetaDataAddress = []byte("nft-metadata____________________")
+
+// the keys of the NFT metadata account are "type:<token identifier>", whose value is the token type,
+// and "version:<token identifier>:<nonce>:<field>", whose value is the round of the last change of the field
+const (
+	nftMetaDataTypeKey      = "type"
+	nftMetaDataVersionKey   = "version"
+	nftMetaDataKeySeparator = ":"
+)
+
+// nftMetaDataKey is a parsed key of the NFT metadata account; the field is empty for the token type
+type nftMetaDataKey struct {
+	tokenIdentifier []byte
+	nonce           uint64
+	field           string
+}
+
+// nftMetaDataCheck is an expectation of the NFT metadata account in a check state step
+type nftMetaDataCheck struct {
+	key      *nftMetaDataKey
+	original string
+	expected mj.JSONCheckBytes
+	checked  bool
+}
+
+func isNFTMetaDataAccount(address []byte) bool {
+	return bytes.Equal(address, NFTMetaDataAddress)
+}
+
+func parseNFTMetaDataKey(key []byte) (*nftMetaDataKey, error) {
+	parts := strings.Split(string(key), nftMetaDataKeySeparator)
+	switch {
+	case len(parts) == 2 && parts[0] == nftMetaDataTypeKey:
+		return &nftMetaDataKey{tokenIdentifier: []byte(parts[1])}, nil
+	case len(parts) == 4 && parts[0] == nftMetaDataVersionKey:
+		nonce, err := strconv.ParseUint(parts[2], 10, 64)
+		if err != nil || nonce == 0 {
+			return nil, fmt.Errorf("nft metadata: invalid nonce in key \"%s\"", key)
+		}
+		if versionField(&worldmock.ESDTMetaDataVersion{}, parts[3]) == nil {
+			return nil, fmt.Errorf("nft metadata: unknown metadata field in key \"%s\"", key)
+		}
+		return &nftMetaDataKey{
+			tokenIdentifier: []byte(parts[1]),
+			nonce:           nonce,
+			field:           parts[3],
+		}, nil
+	default:
+		return nil, fmt.Errorf("nft metadata: key \"%s\" is neither \"type:<token>\" nor \"version:<token>:<nonce>:<field>\"", key)
+	}
+}
+
+// versionField returns the version of the metadata field with the given name, or nil for unknown fields
+func versionField(version *worldmock.ESDTMetaDataVersion, field string) *uint64 {
+	switch field {
+	case "name":
+		return &version.Name
+	case "creator":
+		return &version.Creator
+	case "royalties":
+		return &version.Royalties
+	case "hash":
+		return &version.Hash
+	case "uris":
+		return &version.URIs
+	case "attributes":
+		return &version.Attributes
+	default:
+		return nil
+	}
+}
+
+// setNFTMetaData sets the token types and the metadata versions from the storage of the reserved NFT metadata account
+func (ae *VMTestExecutor) setNFTMetaData(scenAccount *mj.Account) error {
+	for _, stkvp := range scenAccount.Storage {
+		key, err := parseNFTMetaDataKey(stkvp.Key.Value)
+		if err != nil {
+			return err
+		}
+
+		if len(key.field) == 0 {
+			err = ae.World.SetESDTTokenType(key.tokenIdentifier, string(stkvp.Value.Value))
+			if err != nil {
+				return fmt.Errorf("nft metadata: %w", err)
+			}
+			continue
+		}
+
+		value := big.NewInt(0).SetBytes(stkvp.Value.Value)
+		if !value.IsUint64() {
+			return fmt.Errorf("nft metadata: invalid version of key \"%s\"", stkvp.Key.Original)
+		}
+
+		version, err := ae.World.GetESDTMetaDataVersion(key.tokenIdentifier, key.nonce)
+		if err != nil {
+			return err
+		}
+		*versionField(&version, key.field) = value.Uint64()
+		err = ae.World.SetESDTMetaDataVersion(key.tokenIdentifier, key.nonce, version)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// nftMetaDataValue returns the current value of a key of the NFT metadata account
+func (ae *VMTestExecutor) nftMetaDataValue(key *nftMetaDataKey) ([]byte, error) {
+	if len(key.field) == 0 {
+		return []byte(ae.World.GetESDTTokenType(key.tokenIdentifier)), nil
+	}
+
+	version, err := ae.World.GetESDTMetaDataVersion(key.tokenIdentifier, key.nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return big.NewInt(0).SetUint64(*versionField(&version, key.field)).Bytes(), nil
+}
+
+// getNFTMetaDataChecks returns the expectations of the NFT metadata account, if the check state step has one
+func getNFTMetaDataChecks(checkAccounts *mj.CheckAccounts) ([]*nftMetaDataCheck, error) {
+	expectedAcct := mj.FindCheckAccount(checkAccounts.Accounts, NFTMetaDataAddress)
+	if expectedAcct == nil {
+		return nil, nil
+	}
+
+	checks := make([]*nftMetaDataCheck, 0, len(expectedAcct.CheckStorage))
+	for _, stkvp := range expectedAcct.CheckStorage {
+		key, err := parseNFTMetaDataKey(stkvp.Key.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		checks = append(checks, &nftMetaDataCheck{
+			key:      key,
+			original: stkvp.Key.Original,
+			expected: stkvp.CheckValue,
+		})
+	}
+
+	return checks, nil
+}
+
+// checkNFTMetaDataVersions checks the expected metadata versions of a token instance found in an account
+func (ae *VMTestExecutor) checkNFTMetaDataVersions(tokenName string, nonce uint64, checks []*nftMetaDataCheck) []error {
+	var errors []error
+	for _, check := range checks {
+		if len(check.key.field) == 0 || check.key.nonce != nonce || string(check.key.tokenIdentifier) != tokenName {
+			continue
+		}
+
+		check.checked = true
+		err := ae.checkNFTMetaDataValue(check)
+		if err != nil {
+			errors = append(errors, fmt.Errorf("for token: %s, nonce: %d: %w", tokenName, nonce, err))
+		}
+	}
+
+	return errors
+}
+
+// checkRemainingNFTMetaData checks the token types and the metadata versions of the instances not found in the checked accounts
+func (ae *VMTestExecutor) checkRemainingNFTMetaData(baseErrMsg string, checks []*nftMetaDataCheck) error {
+	var errors []error
+	for _, check := range checks {
+		if check.checked {
+			continue
+		}
+
+		err := ae.checkNFTMetaDataValue(check)
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	errorString := makeErrorString(errors)
+	if len(errorString) > 0 {
+		return fmt.Errorf("%s NFT metadata mismatch:%s", baseErrMsg, errorString)
+	}
+
+	return nil
+}
+
+func (ae *VMTestExecutor) checkNFTMetaDataValue(check *nftMetaDataCheck) error {
+	have, err := ae.nftMetaDataValue(check.key)
+	if err != nil {
+		return err
+	}
+	if check.expected.Check(have) {
+		return nil
+	}
+
+	if len(check.key.field) == 0 {
+		return fmt.Errorf("bad token type for %s. Want: %s. Have: \"%s\"",
+			check.key.tokenIdentifier,
+			objectStringOrDefault(check.expected.Original),
+			ae.exprReconstructor.Reconstruct(have, er.StrHint))
+	}
+
+	return fmt.Errorf("bad %s version. Want: %s. Have: \"%s\"",
+		check.key.field,
+		objectStringOrDefault(check.expected.Original),
+		ae.exprReconstructor.ReconstructFromBigInt(big.NewInt(0).SetBytes(have)))
+}
+
+// updateNFTMetaDataAccount overwrites the expectations of the NFT metadata account with the current values
+func (ae *VMTestExecutor) updateNFTMetaDataAccount(expectedAcct *mj.CheckAccount) error {
+	for _, stkvp := range expectedAcct.CheckStorage {
+		key, err := parseNFTMetaDataKey(stkvp.Key.Value)
+		if err != nil {
+			return err
+		}
+
+		have, err := ae.nftMetaDataValue(key)
+		if err != nil {
+			return err
+		}
+
+		hint := er.NoHint
+		if len(key.field) == 0 {
+			hint = er.StrHint
+		}
+		stkvp.CheckValue = ae.updatedCheckBytes(stkvp.CheckValue, have, hint)
+	}
+
+	return nil
+}
@@ -1,6 +1,34 @@
 package scenarioexec
 
-import "github.com/multiversx/mx-chain-core-go/core"
+import (
+	"bytes"
+	"strings"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	worldmock "github.com/multiversx/mx-chain-vm-go/mock/world"
+)
 
 // RewardKey is the storage key where the protocol writes when sending out rewards.
 const RewardKey = core.ProtectedKeyPrefix + "reward"
+
+func isSystemAccount(address []byte) bool {
+	return bytes.Equal(address, vmcommon.SystemAccountAddress)
+}
+
+// isProtocolAccount returns true for the accounts which the protocol creates and changes on its own,
+// and which the scenarios do not have to list in checkState; the system SCs are such accounts only when enabled
+func (ae *VMTestExecutor) isProtocolAccount(address []byte) bool {
+	return isSystemAccount(address) || (ae.systemSCs && worldmock.IsSystemSCAddress(address))
+}
+
+// isVisibleStorageKey returns false for the protected keys, which the scenarios neither set nor check,
+// except for the ESDT global settings (pause, limited transfer, burn for all) kept in the system account,
+// when CheckESDTGlobalSettings is set.
+func (ae *VMTestExecutor) isVisibleStorageKey(address []byte, key string) bool {
+	if !strings.HasPrefix(key, core.ProtectedKeyPrefix) {
+		return true
+	}
+
+	return ae.CheckESDTGlobalSettings && isSystemAccount(address) && worldmock.IsESDTGlobalSettingsKey([]byte(key))
+}
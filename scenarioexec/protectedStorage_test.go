@@ -0,0 +1,24 @@
+package scenarioexec
+
+import (
+	"testing"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	worldmock "github.com/multiversx/mx-chain-vm-go/mock/world"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsVisibleStorageKey_ESDTGlobalSettings(t *testing.T) {
+	settingsKey := string(worldmock.MakeESDTGlobalSettingsKey([]byte("TOKEN-abcdef")))
+	metadataKey := "ELRONDesdtNFT-abcdef\x2d"
+
+	executor := &VMTestExecutor{}
+	require.True(t, executor.isVisibleStorageKey(vmcommon.SystemAccountAddress, "key"))
+	require.False(t, executor.isVisibleStorageKey(vmcommon.SystemAccountAddress, settingsKey))
+	require.False(t, executor.isVisibleStorageKey(vmcommon.SystemAccountAddress, metadataKey))
+
+	executor.CheckESDTGlobalSettings = true
+	require.True(t, executor.isVisibleStorageKey(vmcommon.SystemAccountAddress, settingsKey))
+	require.False(t, executor.isVisibleStorageKey(vmcommon.SystemAccountAddress, metadataKey))
+	require.False(t, executor.isVisibleStorageKey(guardedUser, settingsKey))
+}
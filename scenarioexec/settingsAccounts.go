@@ -0,0 +1,43 @@
+package scenarioexec
+
+import (
+	"bytes"
+
+	mj "github.com/multiversx/mx-chain-scenario-go/model"
+	worldmock "github.com/multiversx/mx-chain-vm-go/mock/world"
+)
+
+// settingsAccount is a reserved address through which scenarios configure the executor and the world.
+// The storage a set state step gives to such an address is read as settings instead of being saved as an account.
+// The settings marked afterBlockInfo are applied once the step has set the block info, in the order of
+// settingsAccounts, so that they see the block of the step; the others in the order of the accounts in the step.
+type settingsAccount struct {
+	address        []byte
+	apply          func(ae *VMTestExecutor, scenAccount *mj.Account) error
+	afterBlockInfo bool
+}
+
+// settingsAccounts holds all the settings accounts; a new one only needs an entry here.
+var settingsAccounts = []*settingsAccount{
+	{address: worldmock.FaultInjectorAddress, apply: (*VMTestExecutor).setFaultRules},
+	// the produced blocks start from the block info of the step
+	{address: worldmock.BlockProducerAddress, apply: (*VMTestExecutor).setBlockProduction, afterBlockInfo: true},
+	// a forced gas schedule switch lasts until the next activation after the epoch of the step
+	{address: GasScheduleAddress, apply: (*VMTestExecutor).setGasSchedules, afterBlockInfo: true},
+	// the guardians are activated in the epoch of the step, on the accounts it sets
+	{address: worldmock.GuardiansAddress, apply: (*VMTestExecutor).setGuardians, afterBlockInfo: true},
+	{address: worldmock.TxGuardianAddress, apply: (*VMTestExecutor).setTxGuardians},
+	{address: worldmock.EnableEpochsAddress, apply: (*VMTestExecutor).setEnableEpochsFlags},
+	{address: NFTMetaDataAddress, apply: (*VMTestExecutor).setNFTMetaData},
+}
+
+// findSettingsAccount returns the settings account with the given address, or nil
+func findSettingsAccount(address []byte) *settingsAccount {
+	for _, account := range settingsAccounts {
+		if bytes.Equal(address, account.address) {
+			return account
+		}
+	}
+
+	return nil
+}
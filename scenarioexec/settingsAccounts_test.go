@@ -0,0 +1,23 @@
+package scenarioexec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettingsAccounts_Find(t *testing.T) {
+	t.Parallel()
+
+	for i, account := range settingsAccounts {
+		require.Len(t, account.address, addressLength)
+		require.NotNil(t, account.apply)
+		for _, other := range settingsAccounts[:i] {
+			require.NotEqual(t, other.address, account.address)
+		}
+
+		require.Equal(t, account, findSettingsAccount(account.address))
+	}
+
+	require.Nil(t, findSettingsAccount([]byte("regular_account_________________")))
+}
@@ -4,15 +4,13 @@ import (
 	"bytes"
 	"fmt"
 	"math/big"
-	"strings"
 
-	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/data/esdt"
 	"github.com/multiversx/mx-chain-scenario-go/esdtconvert"
 	er "github.com/multiversx/mx-chain-scenario-go/expression/reconstructor"
 	mj "github.com/multiversx/mx-chain-scenario-go/model"
 	oj "github.com/multiversx/mx-chain-scenario-go/orderedjson"
-	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
 	worldmock "github.com/multiversx/mx-chain-vm-go/mock/world"
 )
 
@@ -22,6 +20,10 @@ func (ae *VMTestExecutor) ExecuteCheckStateStep(step *mj.CheckStateStep) error {
 		log.Trace("CheckStateStep", "comment", step.Comment)
 	}
 
+	if ae.UpdateExpectations {
+		return ae.updateCheckAccounts(step.CheckAccounts)
+	}
+
 	baseErrMsg := checkStateBaseErrorMsg(step)
 	return ae.checkAccounts(baseErrMsg, step.CheckAccounts)
 }
@@ -34,10 +36,15 @@ func checkStateBaseErrorMsg(step *mj.CheckStateStep) string {
 }
 
 func (ae *VMTestExecutor) checkAccounts(baseErrMsg string, checkAccounts *mj.CheckAccounts) error {
+	nftMetaDataChecks, err := getNFTMetaDataChecks(checkAccounts)
+	if err != nil {
+		return err
+	}
+
 	if !checkAccounts.MoreAccountsAllowed {
 		for worldAcctAddr := range ae.World.AcctMap {
 			postAcctMatch := mj.FindCheckAccount(checkAccounts.Accounts, []byte(worldAcctAddr))
-			if postAcctMatch == nil && !bytes.Equal([]byte(worldAcctAddr), vmcommon.SystemAccountAddress) {
+			if postAcctMatch == nil && !ae.isProtocolAccount([]byte(worldAcctAddr)) {
 				return fmt.Errorf("%s unexpected account address: %s",
 					baseErrMsg,
 					ae.exprReconstructor.Reconstruct(
@@ -48,6 +55,10 @@ func (ae *VMTestExecutor) checkAccounts(baseErrMsg string, checkAccounts *mj.Che
 	}
 
 	for _, expectedAcct := range checkAccounts.Accounts {
+		if isNFTMetaDataAccount(expectedAcct.Address.Value) {
+			continue
+		}
+
 		matchingAcct, isMatch := ae.World.AcctMap[string(expectedAcct.Address.Value)]
 		if !isMatch {
 			return fmt.Errorf("%s account %s expected but not found after running test",
@@ -119,18 +130,18 @@ func (ae *VMTestExecutor) checkAccounts(baseErrMsg string, checkAccounts *mj.Che
 				matchingAcct.AsyncCallData)
 		}
 
-		err := ae.checkAccountStorage(baseErrMsg, expectedAcct, matchingAcct)
+		err = ae.checkAccountStorage(baseErrMsg, expectedAcct, matchingAcct)
 		if err != nil {
 			return err
 		}
 
-		err = ae.checkAccountESDT(baseErrMsg, expectedAcct, matchingAcct)
+		err = ae.checkAccountESDT(baseErrMsg, expectedAcct, matchingAcct, nftMetaDataChecks)
 		if err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return ae.checkRemainingNFTMetaData(baseErrMsg, nftMetaDataChecks)
 }
 
 func (ae *VMTestExecutor) checkAccountStorage(baseErrMsg string, expectedAcct *mj.CheckAccount, matchingAcct *worldmock.Account) error {
@@ -152,8 +163,8 @@ func (ae *VMTestExecutor) checkAccountStorage(baseErrMsg string, expectedAcct *m
 	}
 	storageError := ""
 	for k := range allKeys {
-		// ignore all reserved keys
-		if strings.HasPrefix(k, core.ProtectedKeyPrefix) {
+		// ignore all reserved keys, except the token settings in the system account
+		if !ae.isVisibleStorageKey(matchingAcct.Address, k) {
 			continue
 		}
 
@@ -186,16 +197,17 @@ func (ae *VMTestExecutor) checkAccountStorage(baseErrMsg string, expectedAcct *m
 	return nil
 }
 
-func (ae *VMTestExecutor) checkAccountESDT(baseErrMsg string, expectedAcct *mj.CheckAccount, matchingAcct *worldmock.Account) error {
+func (ae *VMTestExecutor) checkAccountESDT(
+	baseErrMsg string,
+	expectedAcct *mj.CheckAccount,
+	matchingAcct *worldmock.Account,
+	nftMetaDataChecks []*nftMetaDataCheck,
+) error {
 	if expectedAcct.IgnoreESDT {
 		return nil
 	}
 
-	systemAccStorage := make(map[string][]byte)
-	systemAcc, exists := ae.World.AcctMap[string(vmcommon.SystemAccountAddress)]
-	if exists {
-		systemAccStorage = systemAcc.Storage
-	}
+	systemAccStorage := ae.World.AcctMap.GetSystemAccountTokenMetadata()
 
 	accountAddress := expectedAcct.Address.Original
 	expectedTokens := getExpectedTokens(expectedAcct)
@@ -234,7 +246,7 @@ func (ae *VMTestExecutor) checkAccountESDT(baseErrMsg string, expectedAcct *mj.C
 			}
 		}
 
-		errs = append(errs, ae.checkTokenState(accountAddress, tokenName, expectedToken, accountToken)...)
+		errs = append(errs, ae.checkTokenState(accountAddress, tokenName, expectedToken, accountToken, nftMetaDataChecks)...)
 	}
 
 	errorString := makeErrorString(errs)
@@ -260,11 +272,12 @@ func (ae *VMTestExecutor) checkTokenState(
 	tokenName string,
 	expectedToken *mj.CheckESDTData,
 	accountToken *esdtconvert.MockESDTData,
+	nftMetaDataChecks []*nftMetaDataCheck,
 ) []error {
 
 	var errors []error
 
-	errors = append(errors, ae.checkTokenInstances(accountAddress, tokenName, expectedToken, accountToken)...)
+	errors = append(errors, ae.checkTokenInstances(accountAddress, tokenName, expectedToken, accountToken, nftMetaDataChecks)...)
 
 	if !expectedToken.LastNonce.Check(accountToken.LastNonce) {
 		errors = append(errors, fmt.Errorf("bad account ESDT last nonce. Account: %s. Token: %s. Want: \"%s\". Have: %d",
@@ -276,14 +289,35 @@ func (ae *VMTestExecutor) checkTokenState(
 
 	errors = append(errors, checkTokenRoles(accountAddress, tokenName, expectedToken, accountToken)...)
 
+	isFrozen := isTokenFrozen(accountToken)
+	if len(expectedToken.Frozen.Original) > 0 && !expectedToken.Frozen.CheckBool(isFrozen) {
+		errors = append(errors, fmt.Errorf("bad account ESDT frozen flag. Account: %s. Token: %s. Want: \"%s\". Have: %t",
+			accountAddress,
+			tokenName,
+			expectedToken.Frozen.Original,
+			isFrozen))
+	}
+
 	return errors
 }
 
+// isTokenFrozen returns true if the account has the token frozen, which is saved in the properties of each instance
+func isTokenFrozen(accountToken *esdtconvert.MockESDTData) bool {
+	for _, instance := range accountToken.Instances {
+		if builtInFunctions.ESDTUserMetadataFromBytes(instance.Properties).Frozen {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (ae *VMTestExecutor) checkTokenInstances(
 	_ string,
 	tokenName string,
 	expectedToken *mj.CheckESDTData,
 	accountToken *esdtconvert.MockESDTData,
+	nftMetaDataChecks []*nftMetaDataCheck,
 ) []error {
 
 	var errors []error
@@ -385,6 +419,9 @@ func (ae *VMTestExecutor) checkTokenInstances(
 					er.StrHint)))
 		}
 
+		if accountInstance.Value.Sign() > 0 {
+			errors = append(errors, ae.checkNFTMetaDataVersions(tokenName, nonce, nftMetaDataChecks)...)
+		}
 	}
 
 	return errors
@@ -3,6 +3,7 @@ package scenarioexec
 import (
 	"fmt"
 	"math/big"
+	"strings"
 
 	er "github.com/multiversx/mx-chain-scenario-go/expression/reconstructor"
 	mjwrite "github.com/multiversx/mx-chain-scenario-go/json/write"
@@ -11,6 +12,8 @@ import (
 	vmi "github.com/multiversx/mx-chain-vm-common-go"
 )
 
+const internalVMErrorsIdentifier = "internalVMErrors"
+
 func (ae *VMTestExecutor) checkTxResults(
 	txIndex string,
 	blResult *mj.TransactionResult,
@@ -19,13 +22,14 @@ func (ae *VMTestExecutor) checkTxResults(
 ) error {
 
 	if !blResult.Status.Check(big.NewInt(int64(output.ReturnCode))) {
-		return fmt.Errorf("result code mismatch. Tx '%s'. Want: %s. Have: %d (%s). Message: %s",
-			txIndex, blResult.Status.Original, int(output.ReturnCode), output.ReturnCode.String(), output.ReturnMessage)
+		return fmt.Errorf("result code mismatch. Tx '%s'. Want: %s. Have: %d (%s). Message: %s%s%s",
+			txIndex, blResult.Status.Original, int(output.ReturnCode), output.ReturnCode.String(), output.ReturnMessage,
+			internalVMErrorsPretty(output), ae.errorRecordsPretty())
 	}
 
 	if !blResult.Message.Check([]byte(output.ReturnMessage)) {
-		return fmt.Errorf("result message mismatch. Tx '%s'. Want: %s. Have: %s",
-			txIndex, blResult.Message.Original, output.ReturnMessage)
+		return fmt.Errorf("result message mismatch. Tx '%s'. Want: %s. Have: %s%s%s",
+			txIndex, blResult.Message.Original, output.ReturnMessage, internalVMErrorsPretty(output), ae.errorRecordsPretty())
 	}
 
 	// check result
@@ -55,6 +59,40 @@ func (ae *VMTestExecutor) checkTxResults(
 	return ae.checkTxLogs(txIndex, blResult.Logs, output.Logs)
 }
 
+// internalVMErrorsPretty renders the errors logged by the VM for a failed execution,
+// along with the contract function that was running when they occurred.
+func internalVMErrorsPretty(output *vmi.VMOutput) string {
+	var sb strings.Builder
+	for _, logEntry := range output.Logs {
+		if string(logEntry.Identifier) != internalVMErrorsIdentifier {
+			continue
+		}
+		for _, data := range logEntry.Data {
+			sb.WriteString("\nVM errors:")
+			sb.Write(data)
+		}
+	}
+	return sb.String()
+}
+
+// errorRecordsPretty renders the errors of the last execution, each with the call in which it happened
+func (ae *VMTestExecutor) errorRecordsPretty() string {
+	vmHost := ae.getVMHost()
+	if vmHost == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, record := range vmHost.Runtime().GetErrorRecords() {
+		sb.WriteString("\n  ")
+		sb.WriteString(record.String())
+	}
+	if sb.Len() == 0 {
+		return ""
+	}
+	return "\nVM error records:" + sb.String()
+}
+
 func (ae *VMTestExecutor) checkTxLogs(
 	txIndex string,
 	expectedLogs mj.LogList,
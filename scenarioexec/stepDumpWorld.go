@@ -3,15 +3,12 @@ package scenarioexec
 import (
 	"fmt"
 	"sort"
-	"strings"
 
-	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-scenario-go/esdtconvert"
 	er "github.com/multiversx/mx-chain-scenario-go/expression/reconstructor"
 	mjwrite "github.com/multiversx/mx-chain-scenario-go/json/write"
 	mj "github.com/multiversx/mx-chain-scenario-go/model"
 	oj "github.com/multiversx/mx-chain-scenario-go/orderedjson"
-	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 	worldmock "github.com/multiversx/mx-chain-vm-go/mock/world"
 )
 
@@ -27,7 +24,7 @@ func (ae *VMTestExecutor) convertMockAccountToScenarioFormat(account *worldmock.
 	var storageKvps []*mj.StorageKeyValuePair
 	for _, storageKey := range storageKeys {
 		storageValue := account.Storage[storageKey]
-		includeKey := includeProtectedStorage || !strings.HasPrefix(storageKey, core.ProtectedKeyPrefix)
+		includeKey := includeProtectedStorage || ae.isVisibleStorageKey(account.Address, storageKey)
 		if includeKey && len(storageValue) > 0 {
 			storageKvps = append(storageKvps, &mj.StorageKeyValuePair{
 				Key: mj.JSONBytesFromString{
@@ -42,11 +39,7 @@ func (ae *VMTestExecutor) convertMockAccountToScenarioFormat(account *worldmock.
 		}
 	}
 
-	systemAccStorage := make(map[string][]byte)
-	systemAcc, exists := ae.World.AcctMap[string(vmcommon.SystemAccountAddress)]
-	if exists {
-		systemAccStorage = systemAcc.Storage
-	}
+	systemAccStorage := ae.World.AcctMap.GetSystemAccountTokenMetadata()
 	tokenData, err := esdtconvert.GetFullMockESDTData(account.Storage, systemAccStorage)
 	if err != nil {
 		return nil, err
@@ -134,7 +127,8 @@ func (ae *VMTestExecutor) convertMockAccountToScenarioFormat(account *worldmock.
 				Value:    esdtObj.LastNonce,
 				Original: ae.exprReconstructor.ReconstructFromUint64(esdtObj.LastNonce),
 			},
-			Roles: scenRoles,
+			Roles:  scenRoles,
+			Frozen: ae.frozenToScenarioFormat(isTokenFrozen(esdtObj)),
 		})
 	}
 
@@ -160,6 +154,17 @@ func (ae *VMTestExecutor) convertMockAccountToScenarioFormat(account *worldmock.
 	}, nil
 }
 
+func (ae *VMTestExecutor) frozenToScenarioFormat(isFrozen bool) mj.JSONUint64 {
+	if !isFrozen {
+		return mj.JSONUint64{}
+	}
+
+	return mj.JSONUint64{
+		Value:    1,
+		Original: ae.exprReconstructor.ReconstructFromUint64(1),
+	}
+}
+
 // DumpWorld prints the state of the MockWorld to stdout.
 func (ae *VMTestExecutor) DumpWorld() error {
 	fmt.Print("world state dump:\n")
@@ -13,6 +13,12 @@ import (
 )
 
 func (ae *VMTestExecutor) executeTx(txIndex string, tx *mj.Transaction) (*vmcommon.VMOutput, error) {
+	rejectedOutput := ae.checkTxGuardian(tx)
+	if rejectedOutput != nil {
+		// the protocol does not execute the transactions failing the guardian checks, so the world is left as it is
+		return rejectedOutput, nil
+	}
+
 	ae.World.CreateStateBackup()
 
 	var err error
@@ -176,6 +182,13 @@ func (ae *VMTestExecutor) validatorRewardOutput(tx *mj.Transaction) (*vmcommon.V
 	}, nil
 }
 
+func userErrorResult(message string) *vmcommon.VMOutput {
+	output := outOfFundsResult()
+	output.ReturnCode = vmcommon.UserError
+	output.ReturnMessage = message
+	return output
+}
+
 func outOfFundsResult() *vmcommon.VMOutput {
 	return &vmcommon.VMOutput{
 		ReturnData:      make([][]byte, 0),
@@ -191,6 +204,10 @@ func outOfFundsResult() *vmcommon.VMOutput {
 }
 
 func (ae *VMTestExecutor) scCreate(txIndex string, tx *mj.Transaction, gasLimit uint64) (*vmcommon.VMOutput, error) {
+	return ae.vm.RunSmartContractCreate(scCreateInput(txIndex, tx, gasLimit))
+}
+
+func scCreateInput(txIndex string, tx *mj.Transaction, gasLimit uint64) *vmcommon.ContractCreateInput {
 	txHash := generateTxHash(txIndex)
 	vmInput := vmcommon.VMInput{
 		CallerAddr:     tx.From.Value,
@@ -203,20 +220,37 @@ func (ae *VMTestExecutor) scCreate(txIndex string, tx *mj.Transaction, gasLimit
 		ESDTTransfers:  make([]*vmcommon.ESDTTransfer, 0),
 	}
 	addESDTToVMInput(tx.ESDTValue, &vmInput)
-	input := &vmcommon.ContractCreateInput{
+	return &vmcommon.ContractCreateInput{
 		ContractCode: tx.Code.Value,
 		VMInput:      vmInput,
 	}
-
-	return ae.vm.RunSmartContractCreate(input)
 }
 
+// scCall runs a call on a contract. The builtin functions can also be called on the accounts without code,
+// such as SetGuardian on the account of the sender; the protocol processes those calls without the VM,
+// so they go straight to the builtin functions. As before, the calls of other functions on those accounts
+// are rejected, and so are the failing builtin calls: both end the step with an error.
 func (ae *VMTestExecutor) scCall(txIndex string, tx *mj.Transaction, gasLimit uint64) (*vmcommon.VMOutput, error) {
+	input, err := ae.scCallInput(txIndex, tx, gasLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	recipient := ae.World.AcctMap.GetAccount(tx.To.Value)
+	if len(recipient.Code) == 0 {
+		return ae.builtinCall(input)
+	}
+
+	return ae.vm.RunSmartContractCall(input)
+}
+
+func (ae *VMTestExecutor) scCallInput(txIndex string, tx *mj.Transaction, gasLimit uint64) (*vmcommon.ContractCallInput, error) {
 	recipient := ae.World.AcctMap.GetAccount(tx.To.Value)
 	if recipient == nil {
 		return nil, fmt.Errorf("tx recipient (address: %s) does not exist", hex.EncodeToString(tx.To.Value))
 	}
-	if len(recipient.Code) == 0 {
+	isBuiltinCall := ae.isBuiltinFunction(tx.Function)
+	if len(recipient.Code) == 0 && !isBuiltinCall {
 		return nil, fmt.Errorf("tx recipient (address: %s) is not a smart contract", hex.EncodeToString(tx.To.Value))
 	}
 	txHash := generateTxHash(txIndex)
@@ -229,15 +263,40 @@ func (ae *VMTestExecutor) scCall(txIndex string, tx *mj.Transaction, gasLimit ui
 		OriginalTxHash: txHash,
 		CurrentTxHash:  txHash,
 		ESDTTransfers:  make([]*vmcommon.ESDTTransfer, 0),
+		TxGuardian:     ae.World.GetTxGuardian(tx.From.Value),
 	}
 	addESDTToVMInput(tx.ESDTValue, &vmInput)
-	input := &vmcommon.ContractCallInput{
+	return &vmcommon.ContractCallInput{
 		RecipientAddr: tx.To.Value,
 		Function:      tx.Function,
 		VMInput:       vmInput,
+	}, nil
+}
+
+func (ae *VMTestExecutor) isBuiltinFunction(function string) bool {
+	if ae.World.BuiltinFuncs == nil {
+		return false
 	}
 
-	return ae.vm.RunSmartContractCall(input)
+	_, err := ae.World.BuiltinFuncs.Container.Get(function)
+	return err == nil
+}
+
+func (ae *VMTestExecutor) builtinCall(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	output, err := ae.World.BuiltinFuncs.ProcessBuiltInFunction(input)
+	if err != nil {
+		return nil, fmt.Errorf("builtin function %s failed on account %s: %w",
+			input.Function, hex.EncodeToString(input.RecipientAddr), err)
+	}
+
+	if output.GasRefund == nil {
+		output.GasRefund = big.NewInt(0)
+	}
+	if output.OutputAccounts == nil {
+		output.OutputAccounts = make(map[string]*vmcommon.OutputAccount)
+	}
+
+	return output, nil
 }
 
 func (ae *VMTestExecutor) directESDTTransferFromTx(tx *mj.Transaction) (uint64, error) {
@@ -0,0 +1,403 @@
+package scenarioexec
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	mc "github.com/multiversx/mx-chain-scenario-go/controller"
+	"github.com/multiversx/mx-chain-scenario-go/esdtconvert"
+	er "github.com/multiversx/mx-chain-scenario-go/expression/reconstructor"
+	fr "github.com/multiversx/mx-chain-scenario-go/fileresolver"
+	mjparse "github.com/multiversx/mx-chain-scenario-go/json/parse"
+	mj "github.com/multiversx/mx-chain-scenario-go/model"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	worldmock "github.com/multiversx/mx-chain-vm-go/mock/world"
+)
+
+// In update expectations mode, the expectations of the tx steps and check state steps are
+// overwritten with the actual values instead of being checked. Only the values that do not
+// match are replaced, so the original formatting is kept wherever possible, and "*" is never replaced.
+// Expected accounts and tokens that no longer exist cannot be expressed by an update, so they cause an error.
+
+func (ae *VMTestExecutor) updateTxExpectations(blResult *mj.TransactionResult, output *vmcommon.VMOutput) {
+	blResult.Status = ae.updatedCheckBigInt(blResult.Status, big.NewInt(int64(output.ReturnCode)))
+	blResult.Message = ae.updatedCheckBytes(blResult.Message, []byte(output.ReturnMessage), er.StrHint)
+	blResult.Out = ae.updatedCheckValueList(blResult.Out, output.ReturnData, er.NoHint)
+	blResult.Refund = ae.updatedCheckBigInt(blResult.Refund, output.GasRefund)
+
+	// unspecified remaining gas is not checked, so it is not added either
+	if !blResult.Gas.IsUnspecified() {
+		blResult.Gas = ae.updatedCheckUint64(blResult.Gas, output.GasRemaining)
+	}
+
+	if !blResult.Logs.IsStar && !blResult.Logs.IsUnspecified {
+		blResult.Logs.List = ae.updatedLogs(blResult.Logs, output.Logs)
+	}
+}
+
+func (ae *VMTestExecutor) updatedLogs(expectedLogs mj.LogList, actualLogs []*vmcommon.LogEntry) []*mj.LogEntry {
+	updatedLogs := make([]*mj.LogEntry, 0, len(actualLogs))
+	for i, actualLog := range actualLogs {
+		if i >= len(expectedLogs.List) {
+			if expectedLogs.MoreAllowedAtEnd {
+				break
+			}
+			updatedLogs = append(updatedLogs, ae.convertLogToTestFormat(actualLog))
+			continue
+		}
+
+		expectedLog := expectedLogs.List[i]
+		expectedLog.Address = ae.updatedCheckBytes(expectedLog.Address, actualLog.Address, er.AddressHint)
+		expectedLog.Endpoint = ae.updatedCheckBytes(expectedLog.Endpoint, actualLog.Identifier, er.StrHint)
+		expectedLog.Topics = ae.updatedCheckValueList(expectedLog.Topics, actualLog.Topics, er.NoHint)
+		expectedLog.Data = ae.updatedCheckValueList(expectedLog.Data, actualLog.Data, er.NoHint)
+		updatedLogs = append(updatedLogs, expectedLog)
+	}
+
+	return updatedLogs
+}
+
+func (ae *VMTestExecutor) updateCheckAccounts(checkAccounts *mj.CheckAccounts) error {
+	updatedAccounts := make([]*mj.CheckAccount, 0, len(checkAccounts.Accounts))
+	for _, expectedAcct := range checkAccounts.Accounts {
+		if isNFTMetaDataAccount(expectedAcct.Address.Value) {
+			err := ae.updateNFTMetaDataAccount(expectedAcct)
+			if err != nil {
+				return err
+			}
+			updatedAccounts = append(updatedAccounts, expectedAcct)
+			continue
+		}
+
+		matchingAcct, isMatch := ae.World.AcctMap[string(expectedAcct.Address.Value)]
+		if !isMatch {
+			return fmt.Errorf("cannot update expectations, expected account %s does not exist",
+				expectedAcct.Address.Original)
+		}
+
+		err := ae.updateCheckAccount(expectedAcct, matchingAcct)
+		if err != nil {
+			return err
+		}
+		updatedAccounts = append(updatedAccounts, expectedAcct)
+	}
+
+	if !checkAccounts.MoreAccountsAllowed {
+		for _, address := range sortedAccountAddresses(ae.World.AcctMap) {
+			if ae.isProtocolAccount([]byte(address)) ||
+				mj.FindCheckAccount(checkAccounts.Accounts, []byte(address)) != nil {
+				continue
+			}
+
+			newAcct := ae.newCheckAccount(ae.World.AcctMap[address])
+			err := ae.updateCheckAccount(newAcct, ae.World.AcctMap[address])
+			if err != nil {
+				return err
+			}
+			updatedAccounts = append(updatedAccounts, newAcct)
+		}
+	}
+
+	checkAccounts.Accounts = updatedAccounts
+	return nil
+}
+
+func (ae *VMTestExecutor) newCheckAccount(account *worldmock.Account) *mj.CheckAccount {
+	return &mj.CheckAccount{
+		Address: mj.JSONBytesFromString{
+			Value:    account.Address,
+			Original: ae.exprReconstructor.Reconstruct(account.Address, er.AddressHint),
+		},
+		Nonce:           mj.JSONCheckUint64{Value: account.Nonce, Original: ae.exprReconstructor.ReconstructFromUint64(account.Nonce)},
+		Balance:         mj.JSONCheckBigInt{Value: big.NewInt(0).Set(account.Balance), Original: ae.exprReconstructor.ReconstructFromBigInt(account.Balance)},
+		Username:        mj.JSONCheckBytesUnspecified(),
+		ExplicitStorage: true,
+		Code:            mj.JSONCheckBytesStar(),
+		CodeMetadata:    mj.JSONCheckBytesUnspecified(),
+		Owner:           mj.JSONCheckBytesUnspecified(),
+		AsyncCallData:   mj.JSONCheckBytesUnspecified(),
+		DeveloperReward: mj.JSONCheckBigIntUnspecified(),
+	}
+}
+
+func (ae *VMTestExecutor) updateCheckAccount(expectedAcct *mj.CheckAccount, matchingAcct *worldmock.Account) error {
+	expectedAcct.Nonce = ae.updatedCheckUint64(expectedAcct.Nonce, matchingAcct.Nonce)
+	expectedAcct.Balance = ae.updatedCheckBigInt(expectedAcct.Balance, matchingAcct.Balance)
+	expectedAcct.Username = ae.updatedCheckBytes(expectedAcct.Username, matchingAcct.Username, er.StrHint)
+	if !expectedAcct.Owner.IsUnspecified() {
+		expectedAcct.Owner = ae.updatedCheckBytes(expectedAcct.Owner, matchingAcct.OwnerAddress, er.AddressHint)
+	}
+	if !expectedAcct.AsyncCallData.IsUnspecified() {
+		expectedAcct.AsyncCallData = ae.updatedCheckBytes(expectedAcct.AsyncCallData, []byte(matchingAcct.AsyncCallData), er.StrHint)
+	}
+
+	ae.updateCheckAccountStorage(expectedAcct, matchingAcct)
+	return ae.updateCheckAccountESDT(expectedAcct, matchingAcct)
+}
+
+func (ae *VMTestExecutor) updateCheckAccountStorage(expectedAcct *mj.CheckAccount, matchingAcct *worldmock.Account) {
+	if expectedAcct.IgnoreStorage {
+		return
+	}
+
+	updatedStorage := make([]*mj.CheckStorageKeyValuePair, 0, len(expectedAcct.CheckStorage))
+	expectedKeys := make(map[string]bool)
+	for _, stkvp := range expectedAcct.CheckStorage {
+		key := string(stkvp.Key.Value)
+		expectedKeys[key] = true
+
+		have := matchingAcct.StorageValue(key)
+		if len(have) == 0 && !stkvp.CheckValue.IsStar {
+			// the key was cleared
+			continue
+		}
+
+		stkvp.CheckValue = ae.updatedCheckBytes(stkvp.CheckValue, have, er.NoHint)
+		updatedStorage = append(updatedStorage, stkvp)
+	}
+
+	if !expectedAcct.MoreStorageAllowed {
+		for _, key := range sortedStorageKeys(matchingAcct.Storage) {
+			value := matchingAcct.Storage[key]
+			if expectedKeys[key] || len(value) == 0 || !ae.isVisibleStorageKey(matchingAcct.Address, key) {
+				continue
+			}
+
+			updatedStorage = append(updatedStorage, &mj.CheckStorageKeyValuePair{
+				Key: mj.JSONBytesFromString{
+					Value:    []byte(key),
+					Original: ae.exprReconstructor.Reconstruct([]byte(key), er.NoHint),
+				},
+				CheckValue: mj.JSONCheckBytesReconstructed(value, ae.exprReconstructor.Reconstruct(value, er.NoHint)),
+			})
+		}
+	}
+
+	expectedAcct.ExplicitStorage = expectedAcct.ExplicitStorage || len(updatedStorage) > 0
+	expectedAcct.CheckStorage = updatedStorage
+}
+
+func (ae *VMTestExecutor) updateCheckAccountESDT(expectedAcct *mj.CheckAccount, matchingAcct *worldmock.Account) error {
+	if expectedAcct.IgnoreESDT {
+		return nil
+	}
+
+	systemAccStorage := ae.World.AcctMap.GetSystemAccountTokenMetadata()
+
+	accountTokens, err := esdtconvert.GetFullMockESDTData(matchingAcct.Storage, systemAccStorage)
+	if err != nil {
+		return err
+	}
+
+	updatedTokens := make([]*mj.CheckESDTData, 0, len(accountTokens))
+	expectedTokens := getExpectedTokens(expectedAcct)
+	for _, expectedToken := range expectedAcct.CheckESDTData {
+		accountToken, found := accountTokens[string(expectedToken.TokenIdentifier.Value)]
+		if !found {
+			return fmt.Errorf("cannot update expectations, account %s does not hold expected token %s",
+				expectedAcct.Address.Original, expectedToken.TokenIdentifier.Original)
+		}
+
+		ae.updateCheckToken(expectedToken, accountToken)
+		updatedTokens = append(updatedTokens, expectedToken)
+	}
+
+	if !expectedAcct.MoreESDTTokensAllowed {
+		for _, tokenName := range sortedTokenNames(accountTokens) {
+			if expectedTokens[tokenName] != nil {
+				continue
+			}
+
+			newToken := &mj.CheckESDTData{
+				TokenIdentifier: mj.JSONBytesFromString{
+					Value:    []byte(tokenName),
+					Original: ae.exprReconstructor.Reconstruct([]byte(tokenName), er.StrHint),
+				},
+				Instances: make([]*mj.CheckESDTInstance, 0),
+				LastNonce: mj.JSONCheckUint64{Value: 0, Original: ""},
+				Roles:     make([]string, 0),
+			}
+			ae.updateCheckToken(newToken, accountTokens[tokenName])
+			updatedTokens = append(updatedTokens, newToken)
+		}
+	}
+
+	expectedAcct.CheckESDTData = updatedTokens
+	return nil
+}
+
+func (ae *VMTestExecutor) updateCheckToken(expectedToken *mj.CheckESDTData, accountToken *esdtconvert.MockESDTData) {
+	accountInstances := make(map[uint64]*esdt.ESDigitalToken)
+	for _, accountInstance := range accountToken.Instances {
+		accountInstances[accountInstance.TokenMetaData.Nonce] = accountInstance
+	}
+
+	updatedInstances := make([]*mj.CheckESDTInstance, 0, len(accountToken.Instances))
+	expectedNonces := make(map[uint64]bool)
+	for _, expectedInstance := range expectedToken.Instances {
+		nonce := expectedInstance.Nonce.Value
+		expectedNonces[nonce] = true
+
+		accountInstance, found := accountInstances[nonce]
+		if !found || accountInstance.Value.Sign() == 0 {
+			// the instance is no longer held
+			continue
+		}
+
+		ae.updateCheckInstance(expectedInstance, accountInstance)
+		updatedInstances = append(updatedInstances, expectedInstance)
+	}
+
+	for _, accountInstance := range accountToken.Instances {
+		nonce := accountInstance.TokenMetaData.Nonce
+		if expectedNonces[nonce] || accountInstance.Value.Sign() == 0 {
+			continue
+		}
+
+		newInstance := mj.NewCheckESDTInstance()
+		newInstance.Nonce = mj.JSONUint64{Value: nonce, Original: ae.exprReconstructor.ReconstructFromUint64(nonce)}
+		newInstance.Balance = mj.JSONCheckBigInt{
+			Value:    big.NewInt(0).Set(accountInstance.Value),
+			Original: ae.exprReconstructor.ReconstructFromBigInt(accountInstance.Value),
+		}
+		updatedInstances = append(updatedInstances, newInstance)
+	}
+	expectedToken.Instances = updatedInstances
+
+	if len(expectedToken.LastNonce.Original) > 0 || accountToken.LastNonce > 0 {
+		expectedToken.LastNonce = ae.updatedCheckUint64(expectedToken.LastNonce, accountToken.LastNonce)
+	}
+
+	if len(checkTokenRoles("", "", expectedToken, accountToken)) == 0 {
+		return
+	}
+	roles := make([]string, 0, len(accountToken.Roles))
+	for _, role := range accountToken.Roles {
+		roles = append(roles, string(role))
+	}
+	expectedToken.Roles = roles
+}
+
+// updateCheckInstance updates the balance, along with the metadata fields that the expectation specifies.
+func (ae *VMTestExecutor) updateCheckInstance(expectedInstance *mj.CheckESDTInstance, accountInstance *esdt.ESDigitalToken) {
+	expectedInstance.Balance = ae.updatedCheckBigInt(expectedInstance.Balance, accountInstance.Value)
+
+	metaData := accountInstance.TokenMetaData
+	if !expectedInstance.Creator.IsUnspecified() {
+		expectedInstance.Creator = ae.updatedCheckBytes(expectedInstance.Creator, metaData.Creator, er.AddressHint)
+	}
+	if !expectedInstance.Royalties.IsUnspecified() {
+		expectedInstance.Royalties = ae.updatedCheckUint64(expectedInstance.Royalties, uint64(metaData.Royalties))
+	}
+	if !expectedInstance.Hash.IsUnspecified() {
+		expectedInstance.Hash = ae.updatedCheckBytes(expectedInstance.Hash, metaData.Hash, er.NoHint)
+	}
+	if !expectedInstance.Uris.IsUnspecified() {
+		expectedInstance.Uris = ae.updatedCheckValueList(expectedInstance.Uris, metaData.URIs, er.StrHint)
+	}
+	if !expectedInstance.Attributes.IsUnspecified() {
+		expectedInstance.Attributes = ae.updatedCheckBytes(expectedInstance.Attributes, metaData.Attributes, er.StrHint)
+	}
+}
+
+func (ae *VMTestExecutor) updatedCheckBytes(check mj.JSONCheckBytes, actual []byte, hint er.ExprReconstructorHint) mj.JSONCheckBytes {
+	if check.IsStar || check.Check(actual) {
+		return check
+	}
+	return mj.JSONCheckBytesReconstructed(actual, ae.exprReconstructor.Reconstruct(actual, hint))
+}
+
+func (ae *VMTestExecutor) updatedCheckBigInt(check mj.JSONCheckBigInt, actual *big.Int) mj.JSONCheckBigInt {
+	if actual == nil {
+		actual = big.NewInt(0)
+	}
+	if check.IsStar || check.Check(actual) {
+		return check
+	}
+	return mj.JSONCheckBigInt{
+		Value:    big.NewInt(0).Set(actual),
+		Original: ae.exprReconstructor.ReconstructFromBigInt(actual),
+	}
+}
+
+func (ae *VMTestExecutor) updatedCheckUint64(check mj.JSONCheckUint64, actual uint64) mj.JSONCheckUint64 {
+	if check.IsStar || check.Check(actual) {
+		return check
+	}
+	return mj.JSONCheckUint64{
+		Value:    actual,
+		Original: ae.exprReconstructor.ReconstructFromUint64(actual),
+	}
+}
+
+func (ae *VMTestExecutor) updatedCheckValueList(check mj.JSONCheckValueList, actual [][]byte, hint er.ExprReconstructorHint) mj.JSONCheckValueList {
+	if check.IsStar || check.CheckList(actual) {
+		return check
+	}
+
+	values := make([]mj.JSONCheckBytes, len(actual))
+	for i, actualValue := range actual {
+		if len(check.Values) == len(actual) {
+			values[i] = ae.updatedCheckBytes(check.Values[i], actualValue, hint)
+			continue
+		}
+		values[i] = mj.JSONCheckBytesReconstructed(actualValue, ae.exprReconstructor.Reconstruct(actualValue, hint))
+	}
+
+	return mj.JSONCheckValueList{
+		Values: values,
+	}
+}
+
+func sortedAccountAddresses(accounts worldmock.AccountMap) []string {
+	addresses := make([]string, 0, len(accounts))
+	for address := range accounts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	return addresses
+}
+
+func sortedStorageKeys(storage map[string][]byte) []string {
+	keys := make([]string, 0, len(storage))
+	for key := range storage {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedTokenNames(tokens map[string]*esdtconvert.MockESDTData) []string {
+	names := make([]string, 0, len(tokens))
+	for name := range tokens {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// UpdateScenarioExpectations runs the scenario file at the given path and rewrites its expectations,
+// along with the ones of the external steps it references, with the actual results.
+func (ae *VMTestExecutor) UpdateScenarioExpectations(scenarioPath string) error {
+	ae.UpdateExpectations = true
+	ae.Reset()
+
+	return ae.runAndUpdateScenarioFile(scenarioPath, NewScenarioFileResolver())
+}
+
+func (ae *VMTestExecutor) runAndUpdateScenarioFile(scenarioPath string, fileResolver fr.FileResolver) error {
+	parser := mjparse.NewParser(fileResolver)
+	scenario, err := mc.ParseScenariosScenario(parser, scenarioPath)
+	if err != nil {
+		return err
+	}
+
+	err = ae.RunScenario(scenario, parser.ExprInterpreter.FileResolver)
+	if err != nil {
+		return err
+	}
+
+	return mc.WriteScenariosScenario(scenario, scenarioPath)
+}
@@ -0,0 +1,143 @@
+package scenarioexec
+
+import (
+	"math/big"
+	"testing"
+
+	er "github.com/multiversx/mx-chain-scenario-go/expression/reconstructor"
+	mj "github.com/multiversx/mx-chain-scenario-go/model"
+	worldmock "github.com/multiversx/mx-chain-vm-go/mock/world"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	updateOwner = []byte("owner___________________________")
+	updateToken = []byte("TOKEN-123456")
+)
+
+func newUpdateExpectationsExecutor(t *testing.T, roles []string) *VMTestExecutor {
+	world := worldmock.NewMockWorld()
+	account := &worldmock.Account{
+		Address: updateOwner,
+		Nonce:   5,
+		Balance: big.NewInt(1000),
+		Storage: make(map[string][]byte),
+	}
+	require.Nil(t, account.SetTokenBalanceUint64(updateToken, 0, 100))
+	if len(roles) > 0 {
+		require.Nil(t, account.SetTokenRolesAsStrings(updateToken, roles))
+	}
+	world.AcctMap.PutAccount(account)
+
+	return &VMTestExecutor{
+		World:             world,
+		exprReconstructor: er.ExprReconstructor{},
+	}
+}
+
+func newUpdateCheckAccount(token *mj.CheckESDTData) *mj.CheckAccount {
+	checkAccount := &mj.CheckAccount{
+		Address:         mj.JSONBytesFromString{Value: updateOwner, Original: "address:owner"},
+		Nonce:           mj.JSONCheckUint64{Value: 5, Original: "5"},
+		Balance:         mj.JSONCheckBigInt{Value: big.NewInt(1), Original: "1"},
+		Username:        mj.JSONCheckBytesUnspecified(),
+		Code:            mj.JSONCheckBytesStar(),
+		CodeMetadata:    mj.JSONCheckBytesUnspecified(),
+		Owner:           mj.JSONCheckBytesUnspecified(),
+		AsyncCallData:   mj.JSONCheckBytesUnspecified(),
+		DeveloperReward: mj.JSONCheckBigIntUnspecified(),
+		IgnoreStorage:   true,
+	}
+	if token != nil {
+		checkAccount.CheckESDTData = []*mj.CheckESDTData{token}
+	}
+	return checkAccount
+}
+
+func newUpdateCheckToken(balance int64, roles []string) *mj.CheckESDTData {
+	instance := mj.NewCheckESDTInstance()
+	instance.Balance = mj.JSONCheckBigInt{Value: big.NewInt(balance), Original: "1"}
+	return &mj.CheckESDTData{
+		TokenIdentifier: mj.JSONBytesFromString{Value: updateToken, Original: "str:TOKEN-123456"},
+		Instances:       []*mj.CheckESDTInstance{instance},
+		LastNonce:       mj.JSONCheckUint64Unspecified(),
+		Roles:           roles,
+		Frozen:          mj.JSONCheckUint64Unspecified(),
+	}
+}
+
+func TestUpdateExpectations_UpdatesMismatchedValues(t *testing.T) {
+	executor := newUpdateExpectationsExecutor(t, nil)
+	checkToken := newUpdateCheckToken(1, nil)
+	checkAccounts := &mj.CheckAccounts{
+		Accounts: []*mj.CheckAccount{newUpdateCheckAccount(checkToken)},
+	}
+
+	err := executor.updateCheckAccounts(checkAccounts)
+	require.Nil(t, err)
+	require.Len(t, checkAccounts.Accounts, 1)
+
+	checkAccount := checkAccounts.Accounts[0]
+	require.Equal(t, "5", checkAccount.Nonce.Original)
+	require.Equal(t, big.NewInt(1000), checkAccount.Balance.Value)
+	require.True(t, checkAccount.Username.IsUnspecified())
+	require.True(t, checkAccount.Owner.IsUnspecified())
+	require.Len(t, checkAccount.CheckESDTData, 1)
+	require.Equal(t, big.NewInt(100), checkToken.Instances[0].Balance.Value)
+	require.True(t, checkToken.Instances[0].Creator.IsUnspecified())
+	require.Nil(t, checkToken.Roles)
+}
+
+func TestUpdateExpectations_Roles(t *testing.T) {
+	t.Run("matching roles are kept as written", func(t *testing.T) {
+		executor := newUpdateExpectationsExecutor(t, []string{"ESDTRoleLocalMint", "ESDTRoleLocalBurn"})
+		checkToken := newUpdateCheckToken(100, []string{"ESDTRoleLocalBurn", "ESDTRoleLocalMint"})
+		checkAccounts := &mj.CheckAccounts{
+			Accounts: []*mj.CheckAccount{newUpdateCheckAccount(checkToken)},
+		}
+
+		err := executor.updateCheckAccounts(checkAccounts)
+		require.Nil(t, err)
+		require.Equal(t, []string{"ESDTRoleLocalBurn", "ESDTRoleLocalMint"}, checkToken.Roles)
+	})
+	t.Run("mismatched roles are replaced", func(t *testing.T) {
+		executor := newUpdateExpectationsExecutor(t, []string{"ESDTRoleLocalMint"})
+		checkToken := newUpdateCheckToken(100, []string{"ESDTRoleLocalBurn"})
+		checkAccounts := &mj.CheckAccounts{
+			Accounts: []*mj.CheckAccount{newUpdateCheckAccount(checkToken)},
+		}
+
+		err := executor.updateCheckAccounts(checkAccounts)
+		require.Nil(t, err)
+		require.Equal(t, []string{"ESDTRoleLocalMint"}, checkToken.Roles)
+	})
+}
+
+func TestUpdateExpectations_MissingEntries(t *testing.T) {
+	t.Run("missing account", func(t *testing.T) {
+		executor := newUpdateExpectationsExecutor(t, nil)
+		missingAccount := newUpdateCheckAccount(nil)
+		missingAccount.Address = mj.JSONBytesFromString{Value: []byte("missing_________________________"), Original: "address:missing"}
+		checkAccounts := &mj.CheckAccounts{
+			Accounts:            []*mj.CheckAccount{missingAccount},
+			MoreAccountsAllowed: true,
+		}
+
+		err := executor.updateCheckAccounts(checkAccounts)
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "address:missing")
+		require.Len(t, checkAccounts.Accounts, 1)
+	})
+	t.Run("missing token", func(t *testing.T) {
+		executor := newUpdateExpectationsExecutor(t, nil)
+		checkToken := newUpdateCheckToken(100, nil)
+		checkToken.TokenIdentifier = mj.JSONBytesFromString{Value: []byte("OTHER-123456"), Original: "str:OTHER-123456"}
+		checkAccounts := &mj.CheckAccounts{
+			Accounts: []*mj.CheckAccount{newUpdateCheckAccount(checkToken)},
+		}
+
+		err := executor.updateCheckAccounts(checkAccounts)
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "str:OTHER-123456")
+	})
+}
@@ -243,3 +243,20 @@ func resetGasTracesIfNewTest(ae *VMTestExecutor, scenario *mj.Scenario) {
 		scenario.IsNewTest = false
 	}
 }
+
+// withWorldCopy runs the given function against a copy of the world and restores the state
+// of the world and the gas schedule afterwards, so reruns of transactions leave no trace.
+// The original accounts are only provided for comparison.
+func (ae *VMTestExecutor) withWorldCopy(f func(accountsBefore worldmock.AccountMap)) {
+	worldState := ae.World.SaveState()
+	gasScheduleState := ae.saveGasScheduleState()
+	accountsBefore := ae.World.AcctMap
+	defer func() {
+		ae.World.RestoreState(worldState)
+		ae.World.AcctMap = accountsBefore
+		ae.restoreGasScheduleState(gasScheduleState)
+	}()
+
+	ae.World.AcctMap = accountsBefore.Clone()
+	f(accountsBefore)
+}
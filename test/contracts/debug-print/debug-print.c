@@ -0,0 +1,11 @@
+#include "../mxvm/context.h"
+#include "../mxvm/bigInt.h"
+#include "../mxvm/debug.h"
+
+byte message[] = "hello";
+
+void print() {
+    debugPrintBigInt(bigIntNew(42));
+    debugPrintManagedBuffer(mBufferNewFromBytes(message, 5));
+    debugPrintGasLeft();
+}
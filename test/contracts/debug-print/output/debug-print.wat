@@ -0,0 +1,23 @@
+(module
+  (type (;0;) (func (param i64) (result i32)))
+  (type (;1;) (func (param i32)))
+  (type (;2;) (func))
+  (type (;3;) (func (param i32 i32) (result i32)))
+  (import "env" "bigIntNew" (func (;0;) (type 0)))
+  (import "env" "debugPrintBigInt" (func (;1;) (type 1)))
+  (import "env" "mBufferNewFromBytes" (func (;2;) (type 3)))
+  (import "env" "debugPrintManagedBuffer" (func (;3;) (type 1)))
+  (import "env" "debugPrintGasLeft" (func (;4;) (type 2)))
+  (func (;5;) (type 2)
+    i64.const 42
+    call 0
+    call 1
+    i32.const 1024
+    i32.const 5
+    call 2
+    call 3
+    call 4)
+  (memory (;0;) 2)
+  (export "memory" (memory 0))
+  (export "print" (func 5))
+  (data (;0;) (i32.const 1024) "hello"))
@@ -0,0 +1,12 @@
+#ifndef _DEBUG_H_
+#define _DEBUG_H_
+
+#include "types.h"
+
+// only available when the host enables the debug hooks
+void debugPrintManagedBuffer(int mBufferHandle);
+void debugPrintBigInt(bigInt bigIntHandle);
+void debugDumpManagedVector(int managedVecHandle);
+void debugPrintGasLeft();
+
+#endif
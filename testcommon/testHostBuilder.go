@@ -1,6 +1,7 @@
 package testcommon
 
 import (
+	"io"
 	"testing"
 
 	"github.com/multiversx/mx-chain-core-go/core/check"
@@ -23,6 +24,7 @@ type TestHostBuilder struct {
 	tb               testing.TB
 	blockchainHook   vmcommon.BlockchainHook
 	vmHostParameters *vmhost.VMHostParameters
+	executorLogger   executorwrapper.ExecutorLogger
 	host             vmhost.VMHost
 }
 
@@ -96,6 +98,7 @@ func (thb *TestHostBuilder) WithExecutorLogs(executorLogger executorwrapper.Exec
 		executorLogger,
 		thb.vmHostParameters.OverrideVMExecutor)
 
+	thb.executorLogger = executorLogger
 	return thb.WithExecutorFactory(wrapper)
 }
 
@@ -105,6 +108,14 @@ func (thb *TestHostBuilder) WithWasmerSIGSEGVPassthrough(wasmerSIGSEGVPassthroug
 	return thb
 }
 
+// WithDebugHooks allows the contracts to call the debug hooks, which print to the given writer,
+// and to the executor logs, if set. Only the wasmer executor provides them.
+func (thb *TestHostBuilder) WithDebugHooks(writer io.Writer) *TestHostBuilder {
+	thb.vmHostParameters.EnableDebugHooks = true
+	thb.vmHostParameters.DebugPrintWriter = writer
+	return thb
+}
+
 // WithGasSchedule allows tests to use the gas costs. The default is config.MakeGasMapForTests().
 func (thb *TestHostBuilder) WithGasSchedule(gasSchedule config.GasScheduleMap) *TestHostBuilder {
 	thb.vmHostParameters.GasSchedule = gasSchedule
@@ -117,6 +128,20 @@ func (thb *TestHostBuilder) Build() vmhost.VMHost {
 	return thb.host
 }
 
+// BuildQueryHostPool initializes a query host pool of numHosts hosts, all with the configured options.
+func (thb *TestHostBuilder) BuildQueryHostPool(numHosts int) *hostCore.QueryHostPool {
+	thb.initializeGasCosts()
+	if check.IfNil(thb.vmHostParameters.OverrideVMExecutor) {
+		thb.vmHostParameters.OverrideVMExecutor = testexecutor.NewDefaultTestExecutorFactory(thb.tb)
+	}
+	thb.initializeBuiltInFuncContainer()
+
+	pool, err := hostCore.NewQueryHostPool(numHosts, thb.blockchainHook, thb.vmHostParameters)
+	require.Nil(thb.tb, err)
+
+	return pool
+}
+
 func (thb *TestHostBuilder) initializeHost() {
 	thb.initializeGasCosts()
 	if thb.host == nil {
@@ -130,6 +155,12 @@ func (thb *TestHostBuilder) newHost() vmhost.VMHost {
 		thb.vmHostParameters.OverrideVMExecutor = exec
 	}
 
+	if thb.vmHostParameters.EnableDebugHooks && thb.executorLogger != nil {
+		thb.vmHostParameters.DebugPrintWriter = executorwrapper.NewExecutorLogWriter(
+			thb.executorLogger,
+			thb.vmHostParameters.DebugPrintWriter)
+	}
+
 	thb.initializeBuiltInFuncContainer()
 	host, err := hostCore.NewVMHost(
 		thb.blockchainHook,
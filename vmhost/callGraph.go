@@ -0,0 +1,238 @@
+package vmhost
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// CallGraphNodeKind tells how a node of the execution call graph was reached
+type CallGraphNodeKind string
+
+const (
+	// CallGraphTransaction is the root of a call graph, for a contract call
+	CallGraphTransaction CallGraphNodeKind = "transaction"
+
+	// CallGraphDeploy is the root of a call graph, for a contract deployment
+	CallGraphDeploy CallGraphNodeKind = "deploy"
+
+	// CallGraphSyncCall is a call executed through ExecuteOnDestContext
+	CallGraphSyncCall CallGraphNodeKind = "syncCall"
+
+	// CallGraphSameContextCall is a call executed through ExecuteOnSameContext
+	CallGraphSameContextCall CallGraphNodeKind = "sameContextCall"
+
+	// CallGraphBuiltinCall is a builtin function call
+	CallGraphBuiltinCall CallGraphNodeKind = "builtinCall"
+
+	// CallGraphAsyncCall is an async call executed in the same shard
+	CallGraphAsyncCall CallGraphNodeKind = "asyncCall"
+
+	// CallGraphCallback is the callback of an async call
+	CallGraphCallback CallGraphNodeKind = "callback"
+
+	// CallGraphCrossShardCall is an async call sent to another shard; it has no children and no gas used
+	CallGraphCrossShardCall CallGraphNodeKind = "crossShardCall"
+)
+
+// CallGraphNode is a call made during the execution of a transaction
+type CallGraphNode struct {
+	ID            int               `json:"id"`
+	Kind          CallGraphNodeKind `json:"kind"`
+	Caller        string            `json:"caller"`
+	Address       string            `json:"address"`
+	Function      string            `json:"function"`
+	GasProvided   uint64            `json:"gasProvided"`
+	GasUsed       uint64            `json:"gasUsed"`
+	ReturnCode    string            `json:"returnCode"`
+	ReturnMessage string            `json:"returnMessage,omitempty"`
+	Children      []*CallGraphNode  `json:"children,omitempty"`
+}
+
+// CallGraph is the tree of calls actually made during the execution of a transaction
+type CallGraph struct {
+	Root *CallGraphNode `json:"root"`
+}
+
+// ToJSON renders the call graph as indented JSON
+func (graph *CallGraph) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(graph, "", "    ")
+}
+
+// ToDOT renders the call graph in the Graphviz DOT language
+func (graph *CallGraph) ToDOT() string {
+	sb := &strings.Builder{}
+	sb.WriteString("digraph CallGraph {\n")
+	sb.WriteString("\tnode [shape=box];\n")
+	if graph.Root != nil {
+		writeDOTNode(sb, graph.Root)
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+func writeDOTNode(sb *strings.Builder, node *CallGraphNode) {
+	label := fmt.Sprintf("%s\n%s\n%s\ngas provided: %d\ngas used: %d\n%s",
+		node.Kind, shortAddress(node.Address), node.Function, node.GasProvided, node.GasUsed, node.ReturnCode)
+	if len(node.ReturnMessage) > 0 {
+		label += "\n" + node.ReturnMessage
+	}
+
+	color := "black"
+	if node.ReturnCode != vmcommon.Ok.String() && node.Kind != CallGraphCrossShardCall {
+		color = "red"
+	}
+	_, _ = fmt.Fprintf(sb, "\tn%d [label=%q, color=%s];\n", node.ID, label, color)
+
+	for _, child := range node.Children {
+		writeDOTNode(sb, child)
+		_, _ = fmt.Fprintf(sb, "\tn%d -> n%d [label=%q];\n", node.ID, child.ID, child.Kind)
+	}
+}
+
+func shortAddress(address string) string {
+	if len(address) <= 16 {
+		return address
+	}
+	return address[:8] + ".." + address[len(address)-8:]
+}
+
+// CallGraphRecorder builds the call graph of each transaction while it is being executed.
+// All its methods accept a nil receiver, which records nothing.
+type CallGraphRecorder struct {
+	mutex     sync.Mutex
+	stack     []*CallGraphNode
+	nextID    int
+	lastGraph *CallGraph
+}
+
+// NewCallGraphRecorder creates a new CallGraphRecorder
+func NewCallGraphRecorder() *CallGraphRecorder {
+	return &CallGraphRecorder{
+		stack: make([]*CallGraphNode, 0),
+	}
+}
+
+// BeginTransaction discards any unfinished call graph and starts a new one
+func (recorder *CallGraphRecorder) BeginTransaction(kind CallGraphNodeKind, input *vmcommon.VMInput, address []byte, function string) *CallGraphNode {
+	if recorder == nil {
+		return nil
+	}
+
+	recorder.mutex.Lock()
+	recorder.stack = make([]*CallGraphNode, 0)
+	recorder.nextID = 0
+	recorder.mutex.Unlock()
+
+	return recorder.BeginCall(kind, input, address, function)
+}
+
+// BeginCall adds a node as a child of the call currently being executed and makes it current
+func (recorder *CallGraphRecorder) BeginCall(kind CallGraphNodeKind, input *vmcommon.VMInput, address []byte, function string) *CallGraphNode {
+	if recorder == nil {
+		return nil
+	}
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	node := recorder.newNode(kind, input.CallerAddr, address, function, input.GasProvided)
+	recorder.stack = append(recorder.stack, node)
+	return node
+}
+
+// EndCall completes the node with the results of the call. When the root is completed, the
+// call graph becomes available through TakeLastCallGraph.
+func (recorder *CallGraphRecorder) EndCall(node *CallGraphNode, vmOutput *vmcommon.VMOutput, err error) {
+	if recorder == nil || node == nil {
+		return
+	}
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	switch {
+	case vmOutput != nil:
+		node.ReturnCode = vmOutput.ReturnCode.String()
+		node.ReturnMessage = vmOutput.ReturnMessage
+		if node.GasProvided > vmOutput.GasRemaining {
+			node.GasUsed = node.GasProvided - vmOutput.GasRemaining
+		}
+	case err != nil:
+		node.ReturnCode = vmcommon.ExecutionFailed.String()
+		node.ReturnMessage = err.Error()
+		node.GasUsed = node.GasProvided
+	default:
+		node.ReturnCode = vmcommon.Ok.String()
+	}
+	if vmOutput != nil && err != nil && len(node.ReturnMessage) == 0 {
+		node.ReturnMessage = err.Error()
+	}
+
+	for i := len(recorder.stack) - 1; i >= 0; i-- {
+		if recorder.stack[i] != node {
+			continue
+		}
+		recorder.stack = recorder.stack[:i]
+		if i == 0 {
+			recorder.lastGraph = &CallGraph{Root: node}
+		}
+		break
+	}
+}
+
+// RecordCrossShardCall adds a leaf node for an async call sent to another shard
+func (recorder *CallGraphRecorder) RecordCrossShardCall(caller []byte, destination []byte, function string, gasLimit uint64) {
+	if recorder == nil {
+		return
+	}
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	node := recorder.newNode(CallGraphCrossShardCall, caller, destination, function, gasLimit)
+	node.ReturnCode = "pending"
+}
+
+// TakeLastCallGraph returns the call graph of the last completed transaction and forgets it
+func (recorder *CallGraphRecorder) TakeLastCallGraph() *CallGraph {
+	if recorder == nil {
+		return nil
+	}
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	graph := recorder.lastGraph
+	recorder.lastGraph = nil
+	return graph
+}
+
+func (recorder *CallGraphRecorder) newNode(
+	kind CallGraphNodeKind,
+	caller []byte,
+	address []byte,
+	function string,
+	gasProvided uint64,
+) *CallGraphNode {
+	node := &CallGraphNode{
+		ID:          recorder.nextID,
+		Kind:        kind,
+		Caller:      hex.EncodeToString(caller),
+		Address:     hex.EncodeToString(address),
+		Function:    function,
+		GasProvided: gasProvided,
+	}
+	recorder.nextID++
+
+	if len(recorder.stack) > 0 {
+		parent := recorder.stack[len(recorder.stack)-1]
+		parent.Children = append(parent.Children, node)
+	}
+
+	return node
+}
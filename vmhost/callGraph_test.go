@@ -0,0 +1,66 @@
+package vmhost
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallGraphRecorder_NilRecordsNothing(t *testing.T) {
+	t.Parallel()
+
+	var recorder *CallGraphRecorder
+	node := recorder.BeginTransaction(CallGraphTransaction, &vmcommon.VMInput{}, []byte("sc"), "f")
+	require.Nil(t, node)
+	recorder.EndCall(node, &vmcommon.VMOutput{}, nil)
+	recorder.RecordCrossShardCall([]byte("sc"), []byte("other"), "g", 10)
+	require.Nil(t, recorder.TakeLastCallGraph())
+}
+
+func TestCallGraphRecorder_BuildsTree(t *testing.T) {
+	t.Parallel()
+
+	recorder := NewCallGraphRecorder()
+
+	root := recorder.BeginTransaction(CallGraphTransaction, &vmcommon.VMInput{CallerAddr: []byte("user"), GasProvided: 1000}, []byte("parent"), "run")
+	child := recorder.BeginCall(CallGraphSyncCall, &vmcommon.VMInput{CallerAddr: []byte("parent"), GasProvided: 400}, []byte("child"), "work")
+	builtin := recorder.BeginCall(CallGraphBuiltinCall, &vmcommon.VMInput{CallerAddr: []byte("child"), GasProvided: 100}, []byte("child"), "ESDTTransfer")
+	recorder.EndCall(builtin, nil, errors.New("insufficient funds"))
+	recorder.EndCall(child, &vmcommon.VMOutput{ReturnCode: vmcommon.UserError, ReturnMessage: "failed", GasRemaining: 150}, nil)
+	require.Nil(t, recorder.TakeLastCallGraph())
+
+	recorder.RecordCrossShardCall([]byte("parent"), []byte("remote"), "ping", 200)
+	recorder.EndCall(root, &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: 100}, nil)
+
+	graph := recorder.TakeLastCallGraph()
+	require.NotNil(t, graph)
+	require.Nil(t, recorder.TakeLastCallGraph())
+
+	require.Equal(t, uint64(900), graph.Root.GasUsed)
+	require.Len(t, graph.Root.Children, 2)
+
+	childNode := graph.Root.Children[0]
+	require.Equal(t, "work", childNode.Function)
+	require.Equal(t, uint64(250), childNode.GasUsed)
+	require.Equal(t, vmcommon.UserError.String(), childNode.ReturnCode)
+	require.Len(t, childNode.Children, 1)
+	require.Equal(t, "insufficient funds", childNode.Children[0].ReturnMessage)
+	require.Equal(t, uint64(100), childNode.Children[0].GasUsed)
+
+	crossShardNode := graph.Root.Children[1]
+	require.Equal(t, CallGraphCrossShardCall, crossShardNode.Kind)
+	require.Equal(t, uint64(200), crossShardNode.GasProvided)
+
+	dot := graph.ToDOT()
+	require.True(t, strings.HasPrefix(dot, "digraph CallGraph {"))
+	require.Contains(t, dot, "n0 -> n1")
+	require.Contains(t, dot, "n1 -> n2")
+	require.Contains(t, dot, "n0 -> n3")
+
+	jsonData, err := graph.ToJSON()
+	require.Nil(t, err)
+	require.Contains(t, string(jsonData), `"kind": "crossShardCall"`)
+}
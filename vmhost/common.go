@@ -2,6 +2,8 @@
 package vmhost
 
 import (
+	"io"
+
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 	"github.com/multiversx/mx-chain-vm-go/config"
 	"github.com/multiversx/mx-chain-vm-go/executor"
@@ -209,6 +211,10 @@ type VMHostParameters struct {
 	EnableEpochsHandler                 vmcommon.EnableEpochsHandler
 	Hasher                              HashComputer
 	TimeOutForSCExecutionInMilliseconds uint32
+	RecordCallGraph                     bool
+	EnableDebugHooks                    bool
+	DebugPrintWriter                    io.Writer
+	OpcodeTraceWriter                   io.Writer
 }
 
 // AsyncCallInfo contains the information required to handle the asynchronous call of another SmartContract
@@ -0,0 +1,168 @@
+package contexts
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+)
+
+// AsyncContextStoragePrefix is the storage key prefix under which the async contexts are persisted,
+// when the protocol uses the default protected key prefix
+var AsyncContextStoragePrefix = []byte(core.ProtectedKeyPrefix + VMStoragePrefix + vmhost.AsyncDataPrefix)
+
+// PersistedAsyncContext is an async context found in the storage of a contract
+type PersistedAsyncContext struct {
+	Address       []byte
+	CallID        []byte
+	Context       *SerializableAsyncContext
+	DecodeError   error
+	OrphanReasons []string
+}
+
+// IsOrphaned returns true if the context looks like it will never be deleted
+func (persisted *PersistedAsyncContext) IsOrphaned() bool {
+	return len(persisted.OrphanReasons) > 0
+}
+
+// String renders the async context in a human-readable form
+func (persisted *PersistedAsyncContext) String() string {
+	sb := &strings.Builder{}
+	_, _ = fmt.Fprintf(sb, "async context %s in %s\n", hex.EncodeToString(persisted.CallID), hex.EncodeToString(persisted.Address))
+	if persisted.DecodeError != nil {
+		_, _ = fmt.Fprintf(sb, "  cannot decode: %s\n", persisted.DecodeError.Error())
+	}
+
+	asyncContext := persisted.Context
+	if asyncContext != nil {
+		_, _ = fmt.Fprintf(sb, "  call type: %s, caller: %s, caller call ID: %s\n",
+			asyncContext.CallType.String(), hex.EncodeToString(asyncContext.CallerAddr), hex.EncodeToString(asyncContext.CallerCallID))
+		if len(asyncContext.CallbackAsyncInitiatorCallID) > 0 {
+			_, _ = fmt.Fprintf(sb, "  callback async initiator call ID: %s\n", hex.EncodeToString(asyncContext.CallbackAsyncInitiatorCallID))
+		}
+		if len(asyncContext.Callback) > 0 {
+			_, _ = fmt.Fprintf(sb, "  context callback: %s, data: %s\n", asyncContext.Callback, hex.EncodeToString(asyncContext.CallbackData))
+		}
+		_, _ = fmt.Fprintf(sb, "  pending calls: %d of %d, gas accumulated: %d\n",
+			asyncContext.CallsCounter, asyncContext.TotalCallsCounter, asyncContext.GasAccumulated)
+
+		for _, group := range asyncContext.AsyncCallGroups {
+			_, _ = fmt.Fprintf(sb, "  group %q: callback %q, gas locked %d\n", group.Identifier, group.Callback, group.GasLocked)
+			for _, asyncCall := range group.AsyncCalls {
+				_, _ = fmt.Fprintf(sb, "    call %s to %s: %s, %s, data %q, gas limit %d, gas locked %d, callbacks %q/%q\n",
+					hex.EncodeToString(asyncCall.CallID), hex.EncodeToString(asyncCall.Destination),
+					asyncCall.Status.String(), asyncCall.ExecutionMode.String(), asyncCall.Data,
+					asyncCall.GasLimit, asyncCall.GasLocked, asyncCall.SuccessCallback, asyncCall.ErrorCallback)
+			}
+		}
+
+		if asyncContext.ChildResults != nil {
+			_, _ = fmt.Fprintf(sb, "  child results: return code %d, message %q, gas remaining %d, data %d items\n",
+				asyncContext.ChildResults.ReturnCode, asyncContext.ChildResults.ReturnMessage,
+				asyncContext.ChildResults.GasRemaining, len(asyncContext.ChildResults.ReturnData))
+		}
+	}
+
+	for _, reason := range persisted.OrphanReasons {
+		_, _ = fmt.Fprintf(sb, "  ORPHANED: %s\n", reason)
+	}
+
+	return sb.String()
+}
+
+// DecodeAsyncContext decodes an async context, as persisted by AsyncContext.Save
+func DecodeAsyncContext(data []byte) (*SerializableAsyncContext, error) {
+	asyncContext := &SerializableAsyncContext{}
+	err := (&marshal.GogoProtoMarshalizer{}).Unmarshal(asyncContext, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return asyncContext, nil
+}
+
+// InspectAsyncContexts finds and decodes all the async contexts persisted in the given storages,
+// indexed by address and by storage key, and flags the ones that will never be deleted.
+// The contexts are sorted by address, then by call ID.
+func InspectAsyncContexts(storages map[string]map[string][]byte) []*PersistedAsyncContext {
+	persistedContexts := make([]*PersistedAsyncContext, 0)
+	for address, storage := range storages {
+		for key, value := range storage {
+			if len(value) == 0 || !bytes.HasPrefix([]byte(key), AsyncContextStoragePrefix) {
+				continue
+			}
+
+			persisted := &PersistedAsyncContext{
+				Address: []byte(address),
+				CallID:  []byte(key)[len(AsyncContextStoragePrefix):],
+			}
+			persisted.Context, persisted.DecodeError = DecodeAsyncContext(value)
+			persistedContexts = append(persistedContexts, persisted)
+		}
+	}
+
+	sort.Slice(persistedContexts, func(i, j int) bool {
+		addressComparison := bytes.Compare(persistedContexts[i].Address, persistedContexts[j].Address)
+		if addressComparison != 0 {
+			return addressComparison < 0
+		}
+		return bytes.Compare(persistedContexts[i].CallID, persistedContexts[j].CallID) < 0
+	})
+
+	for _, persisted := range persistedContexts {
+		persisted.OrphanReasons = findOrphanReasons(persisted, storages)
+	}
+
+	return persistedContexts
+}
+
+func findOrphanReasons(persisted *PersistedAsyncContext, storages map[string]map[string][]byte) []string {
+	asyncContext := persisted.Context
+	if asyncContext == nil {
+		return []string{"undecodable contexts are never loaded, so they are never deleted"}
+	}
+
+	reasons := make([]string, 0)
+	if !bytes.Equal(asyncContext.CallID, persisted.CallID) {
+		reasons = append(reasons, fmt.Sprintf("stored under call ID %s, but holds call ID %s",
+			hex.EncodeToString(persisted.CallID), hex.EncodeToString(asyncContext.CallID)))
+	}
+
+	if asyncContext.IsComplete() {
+		reasons = append(reasons, "complete, with no pending calls, but not deleted")
+	}
+
+	if asyncContext.CallType == AsynchronousCall {
+		parentStorage, parentInStorages := storages[string(asyncContext.CallerAddr)]
+		if parentInStorages {
+			parentKey := string(getAsyncContextStorageKey(AsyncContextStoragePrefix, asyncContext.CallerCallID))
+			parentContext, err := DecodeAsyncContext(parentStorage[parentKey])
+			switch {
+			case len(parentStorage[parentKey]) == 0:
+				reasons = append(reasons, fmt.Sprintf("parent context %s not found in caller %s, the results cannot be delivered",
+					hex.EncodeToString(asyncContext.CallerCallID), hex.EncodeToString(asyncContext.CallerAddr)))
+			case err == nil && !hasAsyncCall(parentContext, asyncContext.CallID):
+				reasons = append(reasons, fmt.Sprintf("parent context %s in caller %s does not track this call",
+					hex.EncodeToString(asyncContext.CallerCallID), hex.EncodeToString(asyncContext.CallerAddr)))
+			}
+		}
+	}
+
+	return reasons
+}
+
+func hasAsyncCall(asyncContext *SerializableAsyncContext, callID []byte) bool {
+	for _, group := range asyncContext.AsyncCallGroups {
+		for _, asyncCall := range group.AsyncCalls {
+			if bytes.Equal(asyncCall.CallID, callID) {
+				return true
+			}
+		}
+	}
+	return false
+}
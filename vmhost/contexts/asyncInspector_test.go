@@ -0,0 +1,93 @@
+package contexts
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+func persistAsyncContext(t *testing.T, storages map[string]map[string][]byte, asyncContext *SerializableAsyncContext) {
+	data, err := (&marshal.GogoProtoMarshalizer{}).Marshal(asyncContext)
+	require.Nil(t, err)
+
+	storage, found := storages[string(asyncContext.Address)]
+	if !found {
+		storage = make(map[string][]byte)
+		storages[string(asyncContext.Address)] = storage
+	}
+	storage[string(getAsyncContextStorageKey(AsyncContextStoragePrefix, asyncContext.CallID))] = data
+}
+
+func TestInspectAsyncContexts(t *testing.T) {
+	t.Parallel()
+
+	parent := []byte("parent__________________________")
+	child := []byte("child___________________________")
+	other := []byte("other___________________________")
+
+	storages := make(map[string]map[string][]byte)
+	persistAsyncContext(t, storages, &SerializableAsyncContext{
+		Address:      parent,
+		CallID:       []byte("parentCallID"),
+		CallsCounter: 1,
+		AsyncCallGroups: []*vmhost.SerializableAsyncCallGroup{{
+			Identifier: "group",
+			AsyncCalls: []*vmhost.SerializableAsyncCall{{
+				CallID:          []byte("trackedCallID"),
+				Destination:     child,
+				GasLimit:        1000,
+				GasLocked:       150,
+				SuccessCallback: "callBack",
+			}},
+		}},
+	})
+	persistAsyncContext(t, storages, &SerializableAsyncContext{
+		Address:      child,
+		CallID:       []byte("trackedCallID"),
+		CallType:     AsynchronousCall,
+		CallerAddr:   parent,
+		CallerCallID: []byte("parentCallID"),
+		CallsCounter: 1,
+		AsyncCallGroups: []*vmhost.SerializableAsyncCallGroup{{
+			AsyncCalls: []*vmhost.SerializableAsyncCall{{CallID: []byte("remote")}},
+		}},
+	})
+	persistAsyncContext(t, storages, &SerializableAsyncContext{
+		Address:      child,
+		CallID:       []byte("untrackedCallID"),
+		CallType:     AsynchronousCall,
+		CallerAddr:   parent,
+		CallerCallID: []byte("parentCallID"),
+		CallsCounter: 1,
+	})
+	persistAsyncContext(t, storages, &SerializableAsyncContext{
+		Address:      other,
+		CallID:       []byte("completeCallID"),
+		CallType:     AsynchronousCall,
+		CallerAddr:   parent,
+		CallerCallID: []byte("missingCallID"),
+	})
+	storages[string(other)]["regular key"] = []byte("regular value")
+
+	persistedContexts := InspectAsyncContexts(storages)
+	require.Len(t, persistedContexts, 4)
+
+	require.Equal(t, child, persistedContexts[0].Address)
+	require.Equal(t, []byte("trackedCallID"), persistedContexts[0].CallID)
+	require.False(t, persistedContexts[0].IsOrphaned())
+
+	require.Equal(t, []byte("untrackedCallID"), persistedContexts[1].CallID)
+	require.Len(t, persistedContexts[1].OrphanReasons, 1)
+	require.Contains(t, persistedContexts[1].OrphanReasons[0], "does not track this call")
+
+	require.Equal(t, other, persistedContexts[2].Address)
+	require.Len(t, persistedContexts[2].OrphanReasons, 2)
+	require.Contains(t, persistedContexts[2].OrphanReasons[0], "not deleted")
+	require.Contains(t, persistedContexts[2].OrphanReasons[1], "not found")
+
+	require.Equal(t, parent, persistedContexts[3].Address)
+	require.False(t, persistedContexts[3].IsOrphaned())
+	require.Contains(t, persistedContexts[3].String(), "gas locked 150")
+}
@@ -19,6 +19,7 @@ func (context *asyncContext) sendAsyncCallCrossShard(asyncCall *vmhost.AsyncCall
 	}
 
 	context.incrementCallsCounter()
+	host.CallGraphRecorder().RecordCrossShardCall(runtime.GetContextAddress(), asyncCall.GetDestination(), function, asyncCall.GetGasLimit())
 
 	newCallID := context.generateNewCallID()
 	asyncCall.CallID = newCallID
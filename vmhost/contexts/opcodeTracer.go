@@ -0,0 +1,51 @@
+package contexts
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io"
+
+	"github.com/multiversx/mx-chain-vm-go/executor"
+)
+
+// opcodeTracer writes one JSON record per contract function call, holding the gas points consumed by the call.
+// The first record of each contract code also holds the opcodes the instance was compiled from.
+type opcodeTracer struct {
+	encoder     *json.Encoder
+	tracedCodes map[string]struct{}
+}
+
+func newOpcodeTracer(writer io.Writer) *opcodeTracer {
+	return &opcodeTracer{
+		encoder:     json.NewEncoder(writer),
+		tracedCodes: make(map[string]struct{}),
+	}
+}
+
+func (tracer *opcodeTracer) record(
+	instance executor.Instance,
+	address []byte,
+	codeHash []byte,
+	functionName string,
+	gasPoints uint64,
+) {
+	record := &executor.OpcodeTraceRecord{
+		InstanceID: instance.ID(),
+		Address:    hex.EncodeToString(address),
+		CodeHash:   hex.EncodeToString(codeHash),
+		Function:   functionName,
+		GasPoints:  gasPoints,
+	}
+
+	_, traced := tracer.tracedCodes[record.CodeHash]
+	tracingInstance, ok := instance.(executor.OpcodeTracingInstance)
+	if ok && !traced {
+		record.Opcodes = tracingInstance.CompiledOpcodes()
+		tracer.tracedCodes[record.CodeHash] = struct{}{}
+	}
+
+	err := tracer.encoder.Encode(record)
+	if err != nil {
+		logRuntime.Warn("opcode trace", "error", err)
+	}
+}
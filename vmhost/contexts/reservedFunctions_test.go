@@ -26,4 +26,16 @@ func TestReservedFunctions_IsFunctionReserved(t *testing.T) {
 	require.True(t, reserved.IsReserved("protocolFunctionFoo"))
 	require.True(t, reserved.IsReserved("protocolFunctionBar"))
 	require.True(t, reserved.IsReserved(vmhost.DeleteFunctionName))
+	require.False(t, reserved.IsReserved("debugPrintBigInt"))
+}
+
+func TestReservedFunctions_DebugHooksReservedWhenProvided(t *testing.T) {
+	scAPINames := vmcommon.FunctionNames{
+		"rockets":          {},
+		"debugPrintBigInt": {},
+	}
+
+	reserved := NewReservedFunctions(scAPINames, builtInFunctions.NewBuiltInFunctionContainer())
+
+	require.True(t, reserved.IsReserved("debugPrintBigInt"))
 }
@@ -2,8 +2,10 @@ package contexts
 
 import (
 	"bytes"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	builtinMath "math"
 	"math/big"
 
@@ -15,6 +17,7 @@ import (
 )
 
 var logRuntime = logger.GetOrCreate("vm/runtime")
+var logDebugPrint = logger.GetOrCreate("vm/debugprint")
 
 var _ vmhost.RuntimeContext = (*runtimeContext)(nil)
 
@@ -39,9 +42,15 @@ type runtimeContext struct {
 
 	stateStack []*runtimeContext
 
-	validator *wasmValidator
-	errors    vmhost.WrappableError
-	hasher    vmhost.HashComputer
+	validator    *wasmValidator
+	errors       vmhost.WrappableError
+	errorRecords []*vmhost.ErrorRecord
+	hasher       vmhost.HashComputer
+
+	debugHooksEnabled bool
+	debugPrintWriter  io.Writer
+
+	opcodeTracer *opcodeTracer
 }
 
 // NewRuntimeContext creates a new runtimeContext
@@ -101,6 +110,7 @@ func (context *runtimeContext) InitState() {
 	context.readOnly = false
 	context.iTracker.InitState()
 	context.errors = nil
+	context.errorRecords = nil
 
 	logRuntime.Trace("init state")
 }
@@ -111,6 +121,35 @@ func (context *runtimeContext) ClearWarmInstanceCache() {
 	context.iTracker.UnsetInstance()
 }
 
+// EnableDebugHooks allows the contracts to import and call the debug hooks, which print to the given writer;
+// with a nil writer, the debug messages are only logged
+func (context *runtimeContext) EnableDebugHooks(writer io.Writer) {
+	context.debugHooksEnabled = true
+	context.debugPrintWriter = writer
+}
+
+// EnableOpcodeTrace compiles the instances created from now on with opcode tracing,
+// writing a JSON record to the given writer for each contract function call
+func (context *runtimeContext) EnableOpcodeTrace(writer io.Writer) {
+	context.opcodeTracer = newOpcodeTracer(writer)
+}
+
+// DebugHooksEnabled returns true if the contracts are allowed to call the debug hooks
+func (context *runtimeContext) DebugHooksEnabled() bool {
+	return context.debugHooksEnabled
+}
+
+// DebugPrint outputs a message printed by the running contract through a debug hook
+func (context *runtimeContext) DebugPrint(message string) {
+	address := context.GetContextAddress()
+	logDebugPrint.Debug("contract debug print", "address", address, "function", context.callFunction, "message", message)
+	if context.debugPrintWriter == nil {
+		return
+	}
+
+	_, _ = fmt.Fprintf(context.debugPrintWriter, "[debug] %s %s: %s\n", hex.EncodeToString(address), context.callFunction, message)
+}
+
 // GetVMExecutor yields the configured contract executor.
 func (context *runtimeContext) GetVMExecutor() executor.Executor {
 	return context.vmExecutor
@@ -170,6 +209,10 @@ func (context *runtimeContext) makeInstanceFromCompiledCode(gasLimit uint64, new
 	if newCode || len(codeHash) == 0 {
 		return false
 	}
+	// the compiled code holds no opcodes, so the traced instances are always compiled from the bytecode
+	if context.opcodeTracer != nil {
+		return false
+	}
 
 	blockchain := context.host.Blockchain()
 	found, compiledCode := blockchain.GetCompiledCode(codeHash)
@@ -184,7 +227,7 @@ func (context *runtimeContext) makeInstanceFromCompiledCode(gasLimit uint64, new
 		UnmeteredLocals:    uint64(gasSchedule.WASMOpcodeCost.LocalsUnmetered),
 		MaxMemoryGrow:      uint64(gasSchedule.WASMOpcodeCost.MaxMemoryGrow),
 		MaxMemoryGrowDelta: uint64(gasSchedule.WASMOpcodeCost.MaxMemoryGrowDelta),
-		OpcodeTrace:        false,
+		OpcodeTrace:        context.opcodeTracer != nil,
 		Metering:           true,
 		RuntimeBreakpoints: true,
 	}
@@ -212,7 +255,7 @@ func (context *runtimeContext) makeInstanceFromContractByteCode(contract []byte,
 		UnmeteredLocals:    uint64(gasSchedule.WASMOpcodeCost.LocalsUnmetered),
 		MaxMemoryGrow:      uint64(gasSchedule.WASMOpcodeCost.MaxMemoryGrow),
 		MaxMemoryGrowDelta: uint64(gasSchedule.WASMOpcodeCost.MaxMemoryGrowDelta),
-		OpcodeTrace:        false,
+		OpcodeTrace:        context.opcodeTracer != nil,
 		Metering:           true,
 		RuntimeBreakpoints: true,
 	}
@@ -609,7 +652,7 @@ func (context *runtimeContext) SignalUserError(message string) {
 	context.host.Output().SetReturnCode(vmcommon.UserError)
 	context.host.Output().SetReturnMessage(message)
 	context.SetRuntimeBreakpointValue(vmhost.BreakpointSignalError)
-	context.AddError(errors.New(message))
+	context.AddTypedError(vmhost.ErrorKindUserError, errors.New(message))
 	logRuntime.Trace("user error signalled", "message", message)
 }
 
@@ -652,6 +695,12 @@ func (context *runtimeContext) VerifyContractCode() error {
 		return err
 	}
 
+	err = context.validator.verifyDebugImports(context.iTracker.Instance(), context.debugHooksEnabled)
+	if err != nil {
+		logRuntime.Trace("verify contract code", "error", err)
+		return err
+	}
+
 	enableEpochsHandler := context.host.EnableEpochsHandler()
 	if enableEpochsHandler.IsManagedCryptoAPIsFlagEnabled() {
 		err = context.validator.verifyProtectedFunctions(context.iTracker.Instance())
@@ -791,7 +840,21 @@ func (context *runtimeContext) FunctionNameChecked() (string, error) {
 
 // CallSCFunction will execute the function with given name from the loaded contract.
 func (context *runtimeContext) CallSCFunction(functionName string) error {
-	return context.iTracker.Instance().CallFunction(functionName)
+	instance := context.iTracker.Instance()
+	if context.opcodeTracer == nil {
+		return instance.CallFunction(functionName)
+	}
+
+	pointsBefore := instance.GetPointsUsed()
+	err := instance.CallFunction(functionName)
+	context.opcodeTracer.record(
+		instance,
+		context.codeAddress,
+		context.iTracker.CodeHash(),
+		functionName,
+		instance.GetPointsUsed()-pointsBefore,
+	)
+	return err
 }
 
 // IsFunctionImported returns true if the WASM module imports the specified function.
@@ -799,11 +862,26 @@ func (context *runtimeContext) IsFunctionImported(name string) bool {
 	return context.iTracker.Instance().IsFunctionImported(name)
 }
 
-// AddError adds an error to the global error list on runtime context
+// AddError adds an error to the global error list on runtime context, classified by vmhost.ClassifyError
 func (context *runtimeContext) AddError(err error, otherInfo ...string) {
+	context.AddTypedError(vmhost.ClassifyError(err), err, otherInfo...)
+}
+
+// AddTypedError adds an error of a known kind to the global error list on runtime context,
+// and records it together with the current call and its callers
+func (context *runtimeContext) AddTypedError(kind vmhost.ErrorKind, err error, otherInfo ...string) {
 	if err == nil {
 		return
 	}
+
+	context.errorRecords = append(context.errorRecords, &vmhost.ErrorRecord{
+		Kind:         kind,
+		Err:          err,
+		OtherInfo:    otherInfo,
+		Frame:        context.currentErrorFrame(),
+		ParentFrames: context.parentErrorFrames(),
+	})
+
 	if context.errors == nil {
 		context.errors = vmhost.WrapError(err, otherInfo...)
 		return
@@ -816,6 +894,38 @@ func (context *runtimeContext) GetAllErrors() error {
 	return context.errors
 }
 
+// GetErrorRecords returns the records of all the errors produced since the start of the current execution,
+// in the order in which they were added. They remain available after the execution ends, until the next one starts.
+func (context *runtimeContext) GetErrorRecords() []*vmhost.ErrorRecord {
+	return context.errorRecords
+}
+
+func (context *runtimeContext) currentErrorFrame() vmhost.ErrorFrame {
+	return newErrorFrame(context.vmInput, context.callFunction, len(context.stateStack))
+}
+
+func (context *runtimeContext) parentErrorFrames() []vmhost.ErrorFrame {
+	frames := make([]vmhost.ErrorFrame, 0, len(context.stateStack))
+	for depth := len(context.stateStack) - 1; depth >= 0; depth-- {
+		state := context.stateStack[depth]
+		frames = append(frames, newErrorFrame(state.vmInput, state.callFunction, depth))
+	}
+
+	return frames
+}
+
+func newErrorFrame(vmInput *vmcommon.ContractCallInput, function string, callDepth int) vmhost.ErrorFrame {
+	frame := vmhost.ErrorFrame{
+		Function:  function,
+		CallDepth: callDepth,
+	}
+	if vmInput != nil {
+		frame.Address = vmInput.RecipientAddr
+	}
+
+	return frame
+}
+
 // EndExecution performs final steps after execution ends
 func (context *runtimeContext) EndExecution() {
 	context.iTracker.UnsetInstance()
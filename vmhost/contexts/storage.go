@@ -29,6 +29,7 @@ type storageContext struct {
 	protectedKeyPrefix         []byte
 	vmProtectedKeyPrefix       []byte
 	vmStorageProtectionEnabled bool
+	accessList                 *vmhost.StorageAccessList
 }
 
 // NewStorageContext creates a new storageContext
@@ -58,13 +59,21 @@ func NewStorageContext(
 		protectedKeyPrefix:         protectedKeyPrefix,
 		vmProtectedKeyPrefix:       append(protectedKeyPrefix, []byte(VMStoragePrefix)...),
 		vmStorageProtectionEnabled: true,
+		accessList:                 vmhost.NewStorageAccessList(),
 	}
 
 	return context, nil
 }
 
-// InitState does nothing
+// InitState starts a new storage access list
 func (context *storageContext) InitState() {
+	context.accessList = vmhost.NewStorageAccessList()
+}
+
+// GetStorageAccessList returns the storage keys accessed since the start of the current execution.
+// The list remains available after the execution ends, until the next one starts.
+func (context *storageContext) GetStorageAccessList() *vmhost.StorageAccessList {
+	return context.accessList
 }
 
 // PushState appends the current address to the state stack.
@@ -191,6 +200,17 @@ func (context *storageContext) GetStorageFromAddressNoChecks(address []byte, key
 }
 
 func (context *storageContext) getStorageFromAddressUnmetered(address []byte, key []byte) ([]byte, uint32, bool, error) {
+	value, trieDepth, usedCache, err := context.loadStorageFromAddress(address, key)
+	if err != nil {
+		return value, trieDepth, usedCache, err
+	}
+
+	context.accessList.RecordRead(address, key, trieDepth, usedCache)
+	return value, trieDepth, usedCache, nil
+}
+
+// loadStorageFromAddress reads the value of the key without recording the read in the storage access list
+func (context *storageContext) loadStorageFromAddress(address []byte, key []byte) ([]byte, uint32, bool, error) {
 	var value []byte
 	var err error
 	var trieDepth uint32
@@ -303,6 +323,7 @@ func (context *storageContext) setStorageToAddress(address []byte, key []byte, v
 	context.addDeltaBytes(deltaBytes)
 
 	context.changeStorageUpdate(key, value, storageUpdates)
+	context.accessList.RecordWrite(address, key)
 
 	if len(oldValue) == 0 {
 		return context.storageAdded(length, key, value)
@@ -344,6 +365,7 @@ func (context *storageContext) setStorageToAddressUnmetered(address []byte, key
 
 	storageUpdates := context.GetStorageUpdates(address)
 	context.changeStorageUpdate(key, value, storageUpdates)
+	context.accessList.RecordWrite(address, key)
 
 	logStorage.Trace("storage modified (unmetered)", "key", key, "value", value)
 	return vmhost.StorageModified, nil
@@ -450,8 +472,9 @@ func (context *storageContext) getOldValue(storageUpdates map[string]*vmcommon.S
 	usedCache := true
 	strKey := string(key)
 	if update, ok := storageUpdates[strKey]; !ok {
-		// if it's not in storageUpdates, GetStorageUnmetered() will use blockchain hook for sure
-		oldValue, _, _, err = context.GetStorageUnmetered(key)
+		// if it's not in storageUpdates, loadStorageFromAddress() will use blockchain hook for sure;
+		// looking up the old value is part of the write, so it is not recorded as a read
+		oldValue, _, _, err = context.loadStorageFromAddress(context.address, key)
 		if err != nil {
 			return nil, false, err
 		}
@@ -479,13 +502,26 @@ func (context *storageContext) computeGasForKey(key []byte, usedCache bool) uint
 }
 
 // UseGasForStorageLoad - single spot of gas consumption for storage load
-func (context *storageContext) UseGasForStorageLoad(tracedFunctionName string, trieDepth int64, staticGasCost uint64, usedCache bool) error {
+func (context *storageContext) UseGasForStorageLoad(
+	tracedFunctionName string,
+	address []byte,
+	key []byte,
+	trieDepth int64,
+	staticGasCost uint64,
+	usedCache bool,
+) error {
 	blockchainLoadCost, err := context.getBlockchainLoadCost(trieDepth, staticGasCost, usedCache)
 	if err != nil {
 		return err
 	}
 
-	return context.host.Metering().UseGasBoundedAndAddTracedGas(tracedFunctionName, blockchainLoadCost)
+	err = context.host.Metering().UseGasBoundedAndAddTracedGas(tracedFunctionName, blockchainLoadCost)
+	if err != nil {
+		return err
+	}
+
+	context.accessList.RecordLoadGas(address, key, blockchainLoadCost)
+	return nil
 }
 
 func (context *storageContext) getBlockchainLoadCost(trieDepth int64, staticGasCost uint64, usedCache bool) (uint64, error) {
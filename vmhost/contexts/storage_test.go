@@ -559,6 +559,39 @@ func makeBcHookStub(
 	}
 }
 
+func TestStorageContext_StorageAccessList(t *testing.T) {
+	t.Parallel()
+
+	address := []byte("account")
+	mockOutput := &contextmock.OutputContextMock{}
+	account := mockOutput.NewVMOutputAccount(address)
+	mockOutput.OutputAccountMock = account
+
+	mockMetering := &contextmock.MeteringContextMock{}
+	mockMetering.SetGasSchedule(config.MakeGasMapForTests())
+	mockMetering.BlockGasLimitMock = uint64(15000)
+	mockMetering.GasLeftMock = 20000
+
+	host := &contextmock.VMHostMock{
+		OutputContext:            mockOutput,
+		MeteringContext:          mockMetering,
+		RuntimeContext:           &contextmock.RuntimeContextMock{},
+		EnableEpochsHandlerField: &worldmock.EnableEpochsHandlerStub{},
+	}
+	storageCtx, _ := NewStorageContext(host, &contextmock.BlockchainHookStub{}, reservedTestPrefix)
+	storageCtx.SetAddress(address)
+
+	// looking up the old value of a written key is not a read
+	_, err := storageCtx.SetStorage([]byte("written"), []byte("value"))
+	require.Nil(t, err)
+	_, _, _, err = storageCtx.GetStorage([]byte("read"))
+	require.Nil(t, err)
+
+	accessList := storageCtx.GetStorageAccessList()
+	require.Equal(t, [][]byte{[]byte("written")}, accessList.WrittenKeys(address))
+	require.Equal(t, [][]byte{[]byte("read")}, accessList.ReadKeys(address))
+}
+
 func TestStorageContext_LoadGasStoreGasPerKey(t *testing.T) {
 	// TODO
 }
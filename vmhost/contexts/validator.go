@@ -61,6 +61,20 @@ func (validator *wasmValidator) verifyProtectedFunctions(instance executor.Insta
 	return nil
 }
 
+func (validator *wasmValidator) verifyDebugImports(instance executor.Instance, debugHooksEnabled bool) error {
+	if debugHooksEnabled {
+		return nil
+	}
+
+	for _, functionName := range executor.DebugVMHookNames {
+		if instance.IsFunctionImported(functionName) {
+			return fmt.Errorf("%w: %s imports %s", vmhost.ErrContractInvalid, vmhost.ErrDebugHooksDisabled.Error(), functionName)
+		}
+	}
+
+	return nil
+}
+
 func (validator *wasmValidator) verifyValidFunctionName(functionName string) error {
 	const maxLengthOfFunctionName = 256
 
@@ -8,6 +8,7 @@ import (
 	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
 	contextmock "github.com/multiversx/mx-chain-vm-go/mock/context"
 	worldmock "github.com/multiversx/mx-chain-vm-go/mock/world"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
 	"github.com/multiversx/mx-chain-vm-go/vmhost/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -70,3 +71,17 @@ func TestFunctionsProtected(t *testing.T) {
 	err := validator.verifyProtectedFunctions(instance)
 	require.NotNil(t, err)
 }
+
+func TestFunctionsGuard_DebugImports(t *testing.T) {
+	validator := newWASMValidator(testImportNames(), builtInFunctions.NewBuiltInFunctionContainer())
+
+	instance := contextmock.NewInstanceMock(nil)
+	require.Nil(t, validator.verifyDebugImports(instance, false))
+
+	instance.Exports["debugPrintBigInt"] = nil
+	err := validator.verifyDebugImports(instance, false)
+	require.ErrorIs(t, err, vmhost.ErrContractInvalid)
+	require.Contains(t, err.Error(), "debugPrintBigInt")
+
+	require.Nil(t, validator.verifyDebugImports(instance, true))
+}
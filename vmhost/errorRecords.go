@@ -0,0 +1,202 @@
+package vmhost
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/multiversx/mx-chain-vm-go/executor"
+)
+
+// ErrorKind classifies the errors produced during an execution
+type ErrorKind string
+
+const (
+	// ErrorKindUserError is an error signalled by the contract itself
+	ErrorKindUserError ErrorKind = "userError"
+
+	// ErrorKindOutOfGas is an execution which ran out of gas
+	ErrorKindOutOfGas ErrorKind = "outOfGas"
+
+	// ErrorKindTrap is a WASM trap, e.g. unreachable or an out of bounds memory access
+	ErrorKindTrap ErrorKind = "trap"
+
+	// ErrorKindMemoryLimit is an instance which exceeded the memory limit
+	ErrorKindMemoryLimit ErrorKind = "memoryLimit"
+
+	// ErrorKindInvalidCall is a call which cannot be executed, e.g. a missing function or contract
+	ErrorKindInvalidCall ErrorKind = "invalidCall"
+
+	// ErrorKindAsyncFailure is a failure of the async calls mechanism
+	ErrorKindAsyncFailure ErrorKind = "asyncFailure"
+
+	// ErrorKindExecutionFailed is any other failure, including the failure of a called contract
+	ErrorKindExecutionFailed ErrorKind = "executionFailed"
+)
+
+var invalidCallErrors = []error{
+	ErrInvalidFunctionName,
+	ErrContractNotFound,
+	ErrContractInvalid,
+	ErrInitFuncCalledInRun,
+	ErrCallBackFuncCalledInRun,
+	ErrNonPayableFunctionEgld,
+	ErrNonPayableFunctionEsdt,
+	ErrInvalidCallOnReadOnlyMode,
+	ErrUpgradeNotAllowed,
+	ErrInvalidBuiltInFunctionCall,
+	ErrBuiltinCallOnSameContextDisallowed,
+	ErrSyncExecutionNotInSameShard,
+}
+
+var asyncFailureErrors = []error{
+	ErrAsyncCallGroupExistsAlready,
+	ErrAsyncCallNotFound,
+	ErrAsyncNotAllowed,
+	ErrCannotUseBuiltinAsCallback,
+	ErrOnlyOneLegacyAsyncCallAllowed,
+	ErrLegacyAsyncCallNotFound,
+	ErrLegacyAsyncCallInvalid,
+	ErrNoStoredAsyncContextFound,
+	ErrCannotInterpretCallbackArgs,
+	ErrContextCallbackDisabled,
+	ErrNilCallbackFunction,
+	ErrNoAsyncParentContext,
+	ErrAsyncInit,
+	ErrAsyncNoOutputFromCallback,
+	ErrAsyncNoMultiLevel,
+	ErrAsyncNoCallbackForClosure,
+}
+
+// ClassifyError returns the kind of a VM error; the errors which are not specific to a kind are ErrorKindExecutionFailed
+func ClassifyError(err error) ErrorKind {
+	switch {
+	case errors.Is(err, ErrSignalError):
+		return ErrorKindUserError
+	case errors.Is(err, ErrNotEnoughGas):
+		return ErrorKindOutOfGas
+	case errors.Is(err, ErrMemoryLimit):
+		return ErrorKindMemoryLimit
+	case isAnyOf(err, invalidCallErrors):
+		return ErrorKindInvalidCall
+	case isAnyOf(err, asyncFailureErrors):
+		return ErrorKindAsyncFailure
+	default:
+		return ErrorKindExecutionFailed
+	}
+}
+
+// wasmTrapMessages are the fragments by which the executors describe a WASM trap; the executors
+// do not return typed trap errors, only the message of the last error
+var wasmTrapMessages = []string{
+	"unreachable",
+	"out of bounds",
+	"divide by zero",
+	"division by zero",
+	"integer overflow",
+	"call stack exhausted",
+	"stack overflow",
+	"indirect call",
+	"trap",
+}
+
+// ClassifyExecutionError returns the kind of an error returned by the executor for a call on which
+// no breakpoint was set. The VM errors keep their kind, a missing or invalid function is an invalid call,
+// and the errors described as WASM traps are traps. Any other executor error is ErrorKindExecutionFailed.
+func ClassifyExecutionError(err error) ErrorKind {
+	kind := ClassifyError(err)
+	if kind != ErrorKindExecutionFailed {
+		return kind
+	}
+
+	switch {
+	case errors.Is(err, executor.ErrInvalidFunction):
+		return ErrorKindInvalidCall
+	case errors.Is(err, executor.ErrMemoryBadBounds), errors.Is(err, executor.ErrMemoryNegativeLength):
+		return ErrorKindTrap
+	case containsAnyOf(executionErrorDetails(err), wasmTrapMessages):
+		return ErrorKindTrap
+	default:
+		return ErrorKindExecutionFailed
+	}
+}
+
+// executionErrorDetails returns the lowercase message of the executor error, without the name
+// of the called function, which could otherwise be mistaken for a trap description
+func executionErrorDetails(err error) string {
+	message := strings.ToLower(err.Error())
+	functionEnd := strings.LastIndex(message, "` exported function")
+	if functionEnd >= 0 {
+		message = message[functionEnd:]
+	}
+	return message
+}
+
+func containsAnyOf(message string, fragments []string) bool {
+	for _, fragment := range fragments {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+func isAnyOf(err error, targets []error) bool {
+	for _, target := range targets {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrorFrame identifies a contract call on the call stack
+type ErrorFrame struct {
+	Address   []byte
+	Function  string
+	CallDepth int
+}
+
+// String renders the frame as function@address
+func (frame ErrorFrame) String() string {
+	return fmt.Sprintf("%s@%s", frame.Function, hex.EncodeToString(frame.Address))
+}
+
+// ErrorRecord describes an error produced during an execution, and the contract call in which it happened
+type ErrorRecord struct {
+	Kind      ErrorKind
+	Err       error
+	OtherInfo []string
+
+	// Frame is the call in which the error happened, ParentFrames are its callers, the closest first
+	Frame        ErrorFrame
+	ParentFrames []ErrorFrame
+}
+
+// String renders the record on a single line
+func (record *ErrorRecord) String() string {
+	sb := &strings.Builder{}
+	_, _ = fmt.Fprintf(sb, "[%s] %s at depth %d in %s", record.Kind, record.Err.Error(), record.Frame.CallDepth, record.Frame.String())
+	for _, parentFrame := range record.ParentFrames {
+		_, _ = fmt.Fprintf(sb, " <- %s", parentFrame.String())
+	}
+	if len(record.OtherInfo) > 0 {
+		_, _ = fmt.Fprintf(sb, " [%s]", strings.Join(record.OtherInfo, ","))
+	}
+
+	return sb.String()
+}
+
+// RootCauseErrorRecord returns the first error produced by the deepest failed call, which is the one
+// that caused its callers to fail, or nil if there are no records
+func RootCauseErrorRecord(records []*ErrorRecord) *ErrorRecord {
+	var rootCause *ErrorRecord
+	for _, record := range records {
+		if rootCause == nil || record.Frame.CallDepth > rootCause.Frame.CallDepth {
+			rootCause = record
+		}
+	}
+
+	return rootCause
+}
@@ -0,0 +1,53 @@
+package vmhost
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-go/executor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyError(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, ErrorKindUserError, ClassifyError(ErrSignalError))
+	require.Equal(t, ErrorKindOutOfGas, ClassifyError(fmt.Errorf("%w: storage load", ErrNotEnoughGas)))
+	require.Equal(t, ErrorKindMemoryLimit, ClassifyError(ErrMemoryLimit))
+	require.Equal(t, ErrorKindInvalidCall, ClassifyError(ErrContractNotFound))
+	require.Equal(t, ErrorKindAsyncFailure, ClassifyError(WrapError(ErrAsyncCallNotFound)))
+	require.Equal(t, ErrorKindExecutionFailed, ClassifyError(ErrExecutionFailed))
+	require.Equal(t, ErrorKindExecutionFailed, ClassifyError(errors.New("other")))
+}
+
+func TestClassifyExecutionError(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, ErrorKindTrap, ClassifyExecutionError(fmt.Errorf("%w: RuntimeError: unreachable", errors.New("failed to call the `fail` exported function"))))
+	require.Equal(t, ErrorKindTrap, ClassifyExecutionError(errors.New("RuntimeError: out of bounds memory access")))
+	require.Equal(t, ErrorKindTrap, ClassifyExecutionError(fmt.Errorf("%w: load", executor.ErrMemoryBadBoundsUpper)))
+	require.Equal(t, ErrorKindInvalidCall, ClassifyExecutionError(fmt.Errorf("%w: missing", executor.ErrFuncNotFound)))
+	require.Equal(t, ErrorKindOutOfGas, ClassifyExecutionError(ErrNotEnoughGas))
+	require.Equal(t, ErrorKindExecutionFailed, ClassifyExecutionError(errors.New("failed to call the `fail` exported function: unknown details")))
+	require.Equal(t, ErrorKindExecutionFailed, ClassifyExecutionError(errors.New("failed to call the `trapOnOverflow` exported function: unknown details")))
+}
+
+func TestErrorRecords_RootCause(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, RootCauseErrorRecord(nil))
+
+	caller := ErrorFrame{Address: []byte{0xaa}, Function: "callee_failed", CallDepth: 0}
+	callee := ErrorFrame{Address: []byte{0xbb}, Function: "fail", CallDepth: 1}
+	records := []*ErrorRecord{
+		{Kind: ErrorKindUserError, Err: errors.New("wrong amount"), Frame: callee, ParentFrames: []ErrorFrame{caller}},
+		{Kind: ErrorKindUserError, Err: ErrSignalError, Frame: callee, ParentFrames: []ErrorFrame{caller}},
+		{Kind: ErrorKindExecutionFailed, Err: ErrExecutionFailed, Frame: caller, OtherInfo: []string{"fail"}},
+	}
+
+	rootCause := RootCauseErrorRecord(records)
+	require.Equal(t, records[0], rootCause)
+	require.Equal(t, "[userError] wrong amount at depth 1 in fail@bb <- callee_failed@aa", rootCause.String())
+	require.Equal(t, "[executionFailed] execution failed at depth 0 in callee_failed@aa [fail]", records[2].String())
+}
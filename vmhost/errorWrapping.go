@@ -149,6 +149,9 @@ func (werr *wrappableError) Error() string {
 // Unwrap - standard error function implementation for wrappable errors
 func (werr *wrappableError) Unwrap() error {
 	wrappingErr := werr.unwrapWrapping()
+	if wrappingErr == nil || len(wrappingErr.errsWithLocation) == 0 {
+		return nil
+	}
 	if len(wrappingErr.errsWithLocation) == 1 {
 		return wrappingErr.errsWithLocation[0].err
 	} else {
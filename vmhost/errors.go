@@ -43,6 +43,9 @@ var ErrExecutionPanicked = errors.New("VM execution panicked")
 // ErrExecutionFailedWithTimeout signals that the execution failed with timeout
 var ErrExecutionFailedWithTimeout = errors.New("execution failed with timeout")
 
+// ErrExecutionCancelled signals that the execution was stopped because its context was cancelled or reached its deadline
+var ErrExecutionCancelled = errors.New("execution cancelled")
+
 // ErrMemoryLimit signals that too much memory was allocated by the contract
 var ErrMemoryLimit = errors.New("memory limit reached")
 
@@ -295,3 +298,22 @@ var ErrEmptyProtectedKeyPrefix = errors.New("protectedKeyPrefix is empty or nil"
 
 // ErrInvalidGasProvided signals that an unacceptable GasProvided value was specified
 var ErrInvalidGasProvided = errors.New("invalid gas provided")
+
+// ErrInvalidQueryInput signals that a query transfers value or tokens, which is not allowed in read-only mode
+var ErrInvalidQueryInput = errors.New("queries cannot transfer value or tokens")
+
+// ErrQueryModifiedState signals that a query produced state changes, which must not escape read-only mode
+var ErrQueryModifiedState = errors.New("query modified the state")
+
+// ErrQueryPoolClosed signals that a query was run on a closed query host pool
+var ErrQueryPoolClosed = errors.New("query host pool is closed")
+
+// ErrDebugHooksDisabled signals that a contract called or imported a debug hook, while debug hooks are disabled
+var ErrDebugHooksDisabled = errors.New("debug hooks are disabled")
+
+// ErrBuiltInFunctionOnOverriddenESDT signals that a simulation called a built-in function on an account with
+// overridden ESDT tokens, which the built-in functions cannot see
+var ErrBuiltInFunctionOnOverriddenESDT = errors.New("built-in function called on an account with overridden ESDT tokens")
+
+// ErrGasEstimationFailed signals that the execution does not succeed even with the maximum gas limit
+var ErrGasEstimationFailed = errors.New("gas estimation failed")
@@ -0,0 +1,11 @@
+package vmhost
+
+// ExecutionInfo describes how an execution went, beyond what its VMOutput holds
+type ExecutionInfo struct {
+	// StorageAccessList holds the storage keys read and written by the execution
+	StorageAccessList *StorageAccessList
+
+	// ErrorRecords are the errors produced by the execution, in the order in which they happened,
+	// each with the contract call in which it happened; RootCauseErrorRecord picks the one which caused the failure
+	ErrorRecords []*ErrorRecord
+}
@@ -0,0 +1,33 @@
+package vmhost
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// GasEstimate holds the minimal gas limit for which an execution succeeds,
+// along with the gas figures of the execution with that gas limit.
+type GasEstimate struct {
+	// GasLimit is the minimal gas limit for which the execution succeeds.
+	GasLimit uint64
+
+	// GasUsed is the gas consumed by the execution, including the gas
+	// forwarded and locked for cross-shard async calls.
+	GasUsed uint64
+
+	// GasRefund is the gas refunded by the execution, e.g. for freed storage.
+	GasRefund *big.Int
+
+	// GasLockedForAsync is the minimal gas locked by the async context for the callbacks
+	// of the cross-shard async calls, as computed by the metering context.
+	GasLockedForAsync uint64
+
+	// NumRuns is the number of executions needed to find the estimate.
+	NumRuns int
+}
+
+// String renders the estimate in a human-readable form.
+func (estimate *GasEstimate) String() string {
+	return fmt.Sprintf("%d (used: %d, refund: %d, locked for async: %d)",
+		estimate.GasLimit, estimate.GasUsed, estimate.GasRefund, estimate.GasLockedForAsync)
+}
@@ -18,8 +18,10 @@ func (host *vmHost) handleBreakpointIfAny(executionErr error) error {
 		return err
 	}
 
+	// neither executor exposes the WASM call stack at the trap point, so the error only
+	// names the called function, along with the message of the executor, if any
 	log.Trace("wasmer execution error", "err", executionErr)
-	runtime.AddError(executionErr, runtime.FunctionName())
+	runtime.AddTypedError(vmhost.ClassifyExecutionError(executionErr), executionErr, runtime.FunctionName())
 	return vmhost.ErrExecutionFailed
 }
 
@@ -213,6 +213,9 @@ func (host *vmHost) doRunSmartContractCall(input *vmcommon.ContractCallInput) *v
 	}()
 
 	runtime.InitStateFromContractCallInput(input)
+	if host.readOnlyCalls {
+		runtime.SetReadOnly(true)
+	}
 
 	err := async.InitStateFromInput(input)
 	if err != nil {
@@ -294,6 +297,11 @@ func copyTxHashesFromContext(runtime vmhost.RuntimeContext, input *vmcommon.Cont
 func (host *vmHost) ExecuteOnDestContext(input *vmcommon.ContractCallInput) (vmOutput *vmcommon.VMOutput, isChildComplete bool, err error) {
 	log.Trace("ExecuteOnDestContext", "caller", input.CallerAddr, "dest", input.RecipientAddr, "function", input.Function, "gas", input.GasProvided)
 
+	callGraphNode := host.callGraphRecorder.BeginCall(callGraphNodeKindForCallType(input.CallType), &input.VMInput, input.RecipientAddr, input.Function)
+	defer func() {
+		host.callGraphRecorder.EndCall(callGraphNode, vmOutput, err)
+	}()
+
 	scExecutionInput := input
 
 	blockchain := host.Blockchain()
@@ -418,7 +426,9 @@ func (host *vmHost) handleFunctionCallOnOtherVM(input *vmcommon.ContractCallInpu
 func (host *vmHost) handleBuiltinFunctionCall(input *vmcommon.ContractCallInput) (*vmcommon.ContractCallInput, *vmcommon.VMOutput, error) {
 	output := host.Output()
 
+	callGraphNode := host.callGraphRecorder.BeginCall(vmhost.CallGraphBuiltinCall, &input.VMInput, input.RecipientAddr, input.Function)
 	postBuiltinInput, builtinOutput, err := host.callBuiltinFunction(input)
+	host.callGraphRecorder.EndCall(callGraphNode, builtinOutput, err)
 	if err != nil {
 		log.Trace("ExecuteOnDestContext builtin function", "error", err)
 		return nil, nil, err
@@ -599,6 +609,11 @@ func (host *vmHost) ExecuteOnSameContext(input *vmcommon.ContractCallInput) erro
 
 	defer host.finishExecuteOnSameContext(err)
 
+	callGraphNode := host.callGraphRecorder.BeginCall(vmhost.CallGraphSameContextCall, &input.VMInput, librarySCAddress, input.Function)
+	defer func() {
+		host.endSameContextCallGraphNode(callGraphNode, err)
+	}()
+
 	// Perform a value transfer to the called SC. If the execution fails, this
 	// transfer will not persist.
 	err = output.TransferValueOnly(input.RecipientAddr, input.CallerAddr, input.CallValue, false)
@@ -618,6 +633,33 @@ func (host *vmHost) ExecuteOnSameContext(input *vmcommon.ContractCallInput) erro
 	return err
 }
 
+// endSameContextCallGraphNode must be called before finishExecuteOnSameContext, while the
+// contexts still hold the state of the call
+func (host *vmHost) endSameContextCallGraphNode(callGraphNode *vmhost.CallGraphNode, executeErr error) {
+	if callGraphNode == nil {
+		return
+	}
+
+	output := host.Output()
+	sameContextOutput := &vmcommon.VMOutput{
+		ReturnCode:    output.ReturnCode(),
+		ReturnMessage: output.ReturnMessage(),
+		GasRemaining:  host.Metering().GasLeft(),
+	}
+	host.callGraphRecorder.EndCall(callGraphNode, sameContextOutput, executeErr)
+}
+
+func callGraphNodeKindForCallType(callType vm.CallType) vmhost.CallGraphNodeKind {
+	switch callType {
+	case vm.AsynchronousCall:
+		return vmhost.CallGraphAsyncCall
+	case vm.AsynchronousCallBack:
+		return vmhost.CallGraphCallback
+	default:
+		return vmhost.CallGraphSyncCall
+	}
+}
+
 func (host *vmHost) finishExecuteOnSameContext(executeErr error) {
 	managedTypes, blockchain, metering, output, runtime, _, _ := host.GetContexts()
 
@@ -0,0 +1,172 @@
+package hostCore
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-go/config"
+	"github.com/multiversx/mx-chain-vm-go/math"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+)
+
+// EstimateGasForCall finds the minimal gas limit for which the given call succeeds, by bisection
+// below input.GasProvided. Each execution is reverted through the snapshots of the BlockchainHook,
+// which must support them, so that the BlockchainHook is left untouched.
+func (host *vmHost) EstimateGasForCall(input *vmcommon.ContractCallInput) (*vmhost.GasEstimate, error) {
+	return host.estimateGas(input.GasProvided, func(gasLimit uint64) (*vmcommon.VMOutput, error) {
+		inputCopy := *input
+		inputCopy.GasProvided = gasLimit
+		return host.runAndRevert(func() (*vmcommon.VMOutput, error) {
+			return host.RunSmartContractCall(&inputCopy)
+		})
+	})
+}
+
+// EstimateGasForCreate finds the minimal gas limit for which the given deployment succeeds, by bisection
+// below input.GasProvided. Each execution is reverted through the snapshots of the BlockchainHook,
+// which must support them, so that the BlockchainHook is left untouched.
+func (host *vmHost) EstimateGasForCreate(input *vmcommon.ContractCreateInput) (*vmhost.GasEstimate, error) {
+	return host.estimateGas(input.GasProvided, func(gasLimit uint64) (*vmcommon.VMOutput, error) {
+		inputCopy := *input
+		inputCopy.GasProvided = gasLimit
+		return host.runAndRevert(func() (*vmcommon.VMOutput, error) {
+			return host.RunSmartContractCreate(&inputCopy)
+		})
+	})
+}
+
+func (host *vmHost) estimateGas(
+	maxGasLimit uint64,
+	run func(gasLimit uint64) (*vmcommon.VMOutput, error),
+) (*vmhost.GasEstimate, error) {
+	bisection, err := bisectGasLimit(maxGasLimit, run)
+	if err != nil {
+		return nil, err
+	}
+
+	output := bisection.output
+	gasRefund := big.NewInt(0)
+	if output.GasRefund != nil {
+		gasRefund.Set(output.GasRefund)
+	}
+
+	return &vmhost.GasEstimate{
+		GasLimit:          bisection.gasLimit,
+		GasUsed:           bisection.gasLimit - output.GasRemaining,
+		GasRefund:         gasRefund,
+		GasLockedForAsync: host.gasLockedForAsync(output),
+		NumRuns:           bisection.numRuns,
+	}, nil
+}
+
+// gasBisection is the minimal gas limit found by bisectGasLimit, with the output of the execution with it
+type gasBisection struct {
+	gasLimit uint64
+	output   *vmcommon.VMOutput
+	numRuns  int
+}
+
+// bisectGasLimit finds, by bisection, the minimal gas limit not above maxGasLimit for which run succeeds.
+// The run function executes with the given gas limit and must leave the state as it found it.
+// The bisection assumes that the execution keeps succeeding once the gas limit is high enough, which does
+// not hold for the contracts branching on the gas left, so the gas limit found is run once more to check it.
+func bisectGasLimit(
+	maxGasLimit uint64,
+	run func(gasLimit uint64) (*vmcommon.VMOutput, error),
+) (*gasBisection, error) {
+	numRuns := 0
+	runSuccessfully := func(gasLimit uint64) (*vmcommon.VMOutput, error) {
+		numRuns++
+		output, err := run(gasLimit)
+		if err != nil {
+			return nil, err
+		}
+		if output.ReturnCode != vmcommon.Ok {
+			return nil, fmt.Errorf("%s: %s", output.ReturnCode.String(), output.ReturnMessage)
+		}
+
+		return output, nil
+	}
+
+	_, err := runSuccessfully(maxGasLimit)
+	if err != nil {
+		return nil, fmt.Errorf("%w with gas limit %d: %s", vmhost.ErrGasEstimationFailed, maxGasLimit, err.Error())
+	}
+
+	// the execution fails with gasLimit lower and succeeds with gasLimit upper
+	lower := uint64(0)
+	upper := maxGasLimit
+	for upper-lower > 1 {
+		middle := lower + (upper-lower)/2
+		_, errRun := runSuccessfully(middle)
+		if errRun != nil {
+			lower = middle
+			continue
+		}
+
+		upper = middle
+	}
+
+	output, err := runSuccessfully(upper)
+	if err != nil {
+		return nil, fmt.Errorf("%w: the execution with the estimated gas limit %d failed when run again, "+
+			"its outcome does not only grow with the gas limit: %s", vmhost.ErrGasEstimationFailed, upper, err.Error())
+	}
+
+	return &gasBisection{
+		gasLimit: upper,
+		output:   output,
+		numRuns:  numRuns,
+	}, nil
+}
+
+func (host *vmHost) runAndRevert(run func() (*vmcommon.VMOutput, error)) (*vmcommon.VMOutput, error) {
+	blockchain := host.Blockchain()
+	snapshot := blockchain.GetSnapshot()
+	defer blockchain.RevertToSnapshot(snapshot)
+
+	return run()
+}
+
+// gasLockedForAsync computes the gas locked by the async context for the callbacks of the cross-shard
+// async calls found in the output, from the code size of the contracts which made the calls
+func (host *vmHost) gasLockedForAsync(output *vmcommon.VMOutput) uint64 {
+	gasLocked := uint64(0)
+	for _, outputAccount := range output.OutputAccounts {
+		for _, transfer := range outputAccount.OutputTransfers {
+			if transfer.CallType != vm.AsynchronousCall || transfer.GasLocked == 0 {
+				continue
+			}
+
+			codeSize := host.codeSizeAfterExecution(output, transfer.SenderAddress)
+			gasLocked = math.AddUint64(gasLocked, extraGasLockedForAsync(host.Metering().GasSchedule(), codeSize))
+		}
+	}
+
+	return gasLocked
+}
+
+// extraGasLockedForAsync computes the gas locked for the async callbacks of a contract with the given code size,
+// as the metering context does in ComputeExtraGasLockedForAsync for the running contract
+func extraGasLockedForAsync(gasSchedule *config.GasCost, codeSize uint64) uint64 {
+	compilationGasLock := math.MulUint64(codeSize, gasSchedule.BaseOperationCost.AoTPreparePerByte)
+	executionGasLock := math.AddUint64(gasSchedule.BaseOpsAPICost.AsyncCallStep, gasSchedule.BaseOpsAPICost.AsyncCallbackGasLock)
+	return math.AddUint64(compilationGasLock, executionGasLock)
+}
+
+// codeSizeAfterExecution returns the size of the code deployed by the output, or else of the code
+// found in the BlockchainHook, since the changes of the estimated executions are reverted
+func (host *vmHost) codeSizeAfterExecution(output *vmcommon.VMOutput, address []byte) uint64 {
+	outputAccount, found := output.OutputAccounts[string(address)]
+	if found && len(outputAccount.Code) > 0 {
+		return uint64(len(outputAccount.Code))
+	}
+
+	codeSize, err := host.Blockchain().GetCodeSize(address)
+	if err != nil {
+		return 0
+	}
+	return uint64(codeSize)
+}
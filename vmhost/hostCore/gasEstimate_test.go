@@ -0,0 +1,67 @@
+package hostCore
+
+import (
+	"testing"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-go/config"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBisectGasLimit(t *testing.T) {
+	t.Parallel()
+
+	triedGasLimits := make([]uint64, 0)
+	bisection, err := bisectGasLimit(100, func(gasLimit uint64) (*vmcommon.VMOutput, error) {
+		triedGasLimits = append(triedGasLimits, gasLimit)
+		if gasLimit < 37 {
+			return &vmcommon.VMOutput{ReturnCode: vmcommon.OutOfGas}, nil
+		}
+		return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: gasLimit - 30}, nil
+	})
+	require.Nil(t, err)
+	require.Equal(t, uint64(37), bisection.gasLimit)
+	require.Equal(t, uint64(7), bisection.output.GasRemaining)
+	require.Equal(t, len(triedGasLimits), bisection.numRuns)
+	require.Equal(t, []uint64{100, 50, 25, 37, 31, 34, 35, 36, 37}, triedGasLimits)
+}
+
+func TestBisectGasLimit_FailsWithMaxGasLimit(t *testing.T) {
+	t.Parallel()
+
+	_, err := bisectGasLimit(100, func(gasLimit uint64) (*vmcommon.VMOutput, error) {
+		return &vmcommon.VMOutput{ReturnCode: vmcommon.UserError, ReturnMessage: "fail"}, nil
+	})
+	require.ErrorIs(t, err, vmhost.ErrGasEstimationFailed)
+	require.Contains(t, err.Error(), "with gas limit 100")
+}
+
+func TestBisectGasLimit_ReportsUnstableEstimate(t *testing.T) {
+	t.Parallel()
+
+	// the execution only succeeds on the first run with a gas limit, like a contract branching on state it changes
+	triedGasLimits := make(map[uint64]bool)
+	_, err := bisectGasLimit(100, func(gasLimit uint64) (*vmcommon.VMOutput, error) {
+		if triedGasLimits[gasLimit] {
+			return &vmcommon.VMOutput{ReturnCode: vmcommon.UserError, ReturnMessage: "already run"}, nil
+		}
+		triedGasLimits[gasLimit] = true
+		return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}, nil
+	})
+	require.ErrorIs(t, err, vmhost.ErrGasEstimationFailed)
+	require.Contains(t, err.Error(), "the execution with the estimated gas limit 1 failed when run again")
+}
+
+func TestExtraGasLockedForAsync(t *testing.T) {
+	t.Parallel()
+
+	gasSchedule, err := config.CreateGasConfig(config.MakeGasMapForTests())
+	require.Nil(t, err)
+
+	apiCost := gasSchedule.BaseOpsAPICost
+	require.Equal(t, apiCost.AsyncCallStep+apiCost.AsyncCallbackGasLock, extraGasLockedForAsync(gasSchedule, 0))
+	require.Equal(t,
+		1000*gasSchedule.BaseOperationCost.AoTPreparePerByte+apiCost.AsyncCallStep+apiCost.AsyncCallbackGasLock,
+		extraGasLockedForAsync(gasSchedule, 1000))
+}
@@ -59,6 +59,11 @@ type vmHost struct {
 	activationEpochMap   map[uint32]struct{}
 
 	transferLogIdentifiers map[string]bool
+
+	callGraphRecorder *vmhost.CallGraphRecorder
+
+	// readOnlyCalls makes all the top-level calls run in read-only mode, used by the query host pool
+	readOnlyCalls bool
 }
 
 // NewVMHost creates a new VM vmHost
@@ -107,6 +112,9 @@ func NewVMHost(
 		executionTimeout:     minExecutionTimeout,
 		enableEpochsHandler:  hostParameters.EnableEpochsHandler,
 	}
+	if hostParameters.RecordCallGraph {
+		host.callGraphRecorder = vmhost.NewCallGraphRecorder()
+	}
 	newExecutionTimeout := time.Duration(hostParameters.TimeOutForSCExecutionInMilliseconds) * time.Millisecond
 	if newExecutionTimeout > minExecutionTimeout {
 		host.executionTimeout = newExecutionTimeout
@@ -123,7 +131,7 @@ func NewVMHost(
 		return nil, err
 	}
 
-	host.runtimeContext, err = contexts.NewRuntimeContext(
+	runtimeContext, err := contexts.NewRuntimeContext(
 		host,
 		hostParameters.VMType,
 		host.builtInFuncContainer,
@@ -133,6 +141,13 @@ func NewVMHost(
 	if err != nil {
 		return nil, err
 	}
+	if hostParameters.EnableDebugHooks {
+		runtimeContext.EnableDebugHooks(hostParameters.DebugPrintWriter)
+	}
+	if hostParameters.OpcodeTraceWriter != nil {
+		runtimeContext.EnableOpcodeTrace(hostParameters.OpcodeTraceWriter)
+	}
+	host.runtimeContext = runtimeContext
 
 	host.meteringContext, err = contexts.NewMeteringContext(host, hostParameters.GasSchedule, hostParameters.BlockGasLimit)
 	if err != nil {
@@ -197,6 +212,8 @@ func (host *vmHost) createExecutor(hostParameters *vmhost.VMHostParameters) (exe
 		OpcodeCosts:              gasCostConfig.WASMOpcodeCost,
 		RkyvSerializationEnabled: true,
 		WasmerSIGSEGVPassthrough: hostParameters.WasmerSIGSEGVPassthrough,
+		DebugHooksEnabled:        hostParameters.EnableDebugHooks,
+		OpcodeTraceEnabled:       hostParameters.OpcodeTraceWriter != nil,
 	}
 	return vmExecutorFactory.CreateExecutor(vmExecutorFactoryArgs)
 }
@@ -241,6 +258,11 @@ func (host *vmHost) Storage() vmhost.StorageContext {
 	return host.storageContext
 }
 
+// CallGraphRecorder returns the recorder of the execution call graphs, or nil if recording is disabled
+func (host *vmHost) CallGraphRecorder() *vmhost.CallGraphRecorder {
+	return host.callGraphRecorder
+}
+
 // EnableEpochsHandler returns the enableEpochsHandler instance of the host
 func (host *vmHost) EnableEpochsHandler() vmcommon.EnableEpochsHandler {
 	return host.enableEpochsHandler
@@ -345,6 +367,13 @@ func (host *vmHost) GetGasScheduleMap() config.GasScheduleMap {
 
 // RunSmartContractCreate executes the deployment of a new contract
 func (host *vmHost) RunSmartContractCreate(input *vmcommon.ContractCreateInput) (vmOutput *vmcommon.VMOutput, err error) {
+	return host.RunSmartContractCreateWithContext(context.Background(), input)
+}
+
+// RunSmartContractCreateWithContext deploys a new contract, stopping the execution when ctx is
+// cancelled or reaches its deadline, in which case it returns ErrExecutionCancelled, and the VMOutput
+// has the ExecutionFailed return code with the same error as message
+func (host *vmHost) RunSmartContractCreateWithContext(ctx context.Context, input *vmcommon.ContractCreateInput) (vmOutput *vmcommon.VMOutput, err error) {
 	err = validateVMInput(&input.VMInput)
 	if err != nil {
 		return nil, err
@@ -356,9 +385,12 @@ func (host *vmHost) RunSmartContractCreate(input *vmcommon.ContractCreateInput)
 	if host.closingInstance {
 		return nil, vmhost.ErrVMIsClosing
 	}
+	if ctx.Err() != nil {
+		return nil, vmhost.ErrExecutionCancelled
+	}
 
 	host.setGasTracerEnabledIfLogIsTrace()
-	ctx, cancel := context.WithTimeout(context.Background(), host.executionTimeout)
+	executionCtx, cancel := context.WithTimeout(ctx, host.executionTimeout)
 	defer cancel()
 
 	log.Trace("RunSmartContractCreate begin",
@@ -382,7 +414,9 @@ func (host *vmHost) RunSmartContractCreate(input *vmcommon.ContractCreateInput)
 			close(done)
 		}()
 
+		callGraphNode := host.callGraphRecorder.BeginTransaction(vmhost.CallGraphDeploy, &input.VMInput, nil, vmhost.InitFunctionName)
 		vmOutput = host.doRunSmartContractCreate(input)
+		host.callGraphRecorder.EndCall(callGraphNode, vmOutput, nil)
 		host.CompleteLogEntriesWithCallType(vmOutput, vmhost.DeploySmartContractString)
 
 		logsFromErrors := host.createLogEntryFromErrors(input.CallerAddr, input.CallerAddr, "_init")
@@ -400,10 +434,12 @@ func (host *vmHost) RunSmartContractCreate(input *vmcommon.ContractCreateInput)
 	select {
 	case <-done:
 		return
-	case <-ctx.Done():
-		host.Runtime().FailExecution(vmhost.ErrExecutionFailedWithTimeout)
-		<-done
-		err = vmhost.ErrExecutionFailedWithTimeout
+	case <-executionCtx.Done():
+		err = host.stopExecution(ctx, done)
+		if err == vmhost.ErrExecutionCancelled && vmOutput != nil {
+			vmOutput.ReturnCode = vmcommon.ExecutionFailed
+			vmOutput.ReturnMessage = err.Error()
+		}
 	}
 
 	return
@@ -411,6 +447,13 @@ func (host *vmHost) RunSmartContractCreate(input *vmcommon.ContractCreateInput)
 
 // RunSmartContractCall executes the call of an existing contract
 func (host *vmHost) RunSmartContractCall(input *vmcommon.ContractCallInput) (vmOutput *vmcommon.VMOutput, err error) {
+	return host.RunSmartContractCallWithContext(context.Background(), input)
+}
+
+// RunSmartContractCallWithContext executes the call of an existing contract, stopping the execution when ctx
+// is cancelled or reaches its deadline, in which case it returns ErrExecutionCancelled, and the VMOutput
+// has the ExecutionFailed return code with the same error as message
+func (host *vmHost) RunSmartContractCallWithContext(ctx context.Context, input *vmcommon.ContractCallInput) (vmOutput *vmcommon.VMOutput, err error) {
 	err = validateVMInput(&input.VMInput)
 	if err != nil {
 		return nil, err
@@ -422,9 +465,12 @@ func (host *vmHost) RunSmartContractCall(input *vmcommon.ContractCallInput) (vmO
 	if host.closingInstance {
 		return nil, vmhost.ErrVMIsClosing
 	}
+	if ctx.Err() != nil {
+		return nil, vmhost.ErrExecutionCancelled
+	}
 
 	host.setGasTracerEnabledIfLogIsTrace()
-	ctx, cancel := context.WithTimeout(context.Background(), host.executionTimeout)
+	executionCtx, cancel := context.WithTimeout(ctx, host.executionTimeout)
 	defer cancel()
 
 	log.Trace("RunSmartContractCall begin",
@@ -447,6 +493,8 @@ func (host *vmHost) RunSmartContractCall(input *vmcommon.ContractCallInput) (vmO
 			close(done)
 		}()
 
+		callGraphNode := host.callGraphRecorder.BeginTransaction(vmhost.CallGraphTransaction, &input.VMInput, input.RecipientAddr, input.Function)
+
 		switch input.Function {
 		case vmhost.UpgradeFunctionName:
 			vmOutput = host.doRunSmartContractUpgrade(input)
@@ -455,6 +503,7 @@ func (host *vmHost) RunSmartContractCall(input *vmcommon.ContractCallInput) (vmO
 		default:
 			vmOutput = host.doRunSmartContractCall(input)
 		}
+		host.callGraphRecorder.EndCall(callGraphNode, vmOutput, nil)
 
 		logsFromErrors := host.createLogEntryFromErrors(input.CallerAddr, input.RecipientAddr, input.Function)
 		if logsFromErrors != nil {
@@ -473,20 +522,62 @@ func (host *vmHost) RunSmartContractCall(input *vmcommon.ContractCallInput) (vmO
 	case <-done:
 		// Normal termination.
 		return
-	case <-ctx.Done():
-		// Terminated due to timeout. The VM sets the `ExecutionFailed` breakpoint
-		// in Wasmer. Also, the VM must wait for Wasmer to reach the end of a WASM
-		// basic block in order to close the WASM instance cleanly. This is done by
-		// reading the `done` channel once more, awaiting the call to `close(done)`
-		// from above.
-		host.Runtime().FailExecution(vmhost.ErrExecutionFailedWithTimeout)
-		<-done
-		err = vmhost.ErrExecutionFailedWithTimeout
+	case <-executionCtx.Done():
+		err = host.stopExecution(ctx, done)
+		if err == vmhost.ErrExecutionCancelled && vmOutput != nil {
+			vmOutput.ReturnCode = vmcommon.ExecutionFailed
+			vmOutput.ReturnMessage = err.Error()
+		}
 	}
 
 	return
 }
 
+// RunSmartContractCreateWithInfo deploys a new contract like RunSmartContractCreateWithContext,
+// also returning the storage keys read and written by the deployment and the errors it produced
+func (host *vmHost) RunSmartContractCreateWithInfo(ctx context.Context, input *vmcommon.ContractCreateInput) (*vmcommon.VMOutput, *vmhost.ExecutionInfo, error) {
+	vmOutput, err := host.RunSmartContractCreateWithContext(ctx, input)
+	if vmOutput == nil {
+		return nil, nil, err
+	}
+
+	return vmOutput, host.lastExecutionInfo(), err
+}
+
+// RunSmartContractCallWithInfo executes the call of an existing contract like RunSmartContractCallWithContext,
+// also returning the storage keys read and written by the call and the errors it produced
+func (host *vmHost) RunSmartContractCallWithInfo(ctx context.Context, input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, *vmhost.ExecutionInfo, error) {
+	vmOutput, err := host.RunSmartContractCallWithContext(ctx, input)
+	if vmOutput == nil {
+		return nil, nil, err
+	}
+
+	return vmOutput, host.lastExecutionInfo(), err
+}
+
+func (host *vmHost) lastExecutionInfo() *vmhost.ExecutionInfo {
+	return &vmhost.ExecutionInfo{
+		StorageAccessList: host.Storage().GetStorageAccessList(),
+		ErrorRecords:      host.Runtime().GetErrorRecords(),
+	}
+}
+
+// stopExecution stops an execution which timed out or whose context was cancelled. The VM sets
+// the `ExecutionFailed` breakpoint in Wasmer. Also, the VM must wait for Wasmer to reach the end
+// of a WASM basic block in order to close the WASM instance cleanly. This is done by reading the
+// `done` channel once more, awaiting the call to `close(done)` from the execution goroutine.
+func (host *vmHost) stopExecution(ctx context.Context, done chan struct{}) error {
+	stopErr := vmhost.ErrExecutionFailedWithTimeout
+	if ctx.Err() != nil {
+		stopErr = vmhost.ErrExecutionCancelled
+	}
+
+	host.Runtime().FailExecution(stopErr)
+	<-done
+
+	return stopErr
+}
+
 func (host *vmHost) createLogEntryFromErrors(sndAddress, rcvAddress []byte, function string) *vmcommon.LogEntry {
 	formattedErrors := host.runtimeContext.GetAllErrors()
 	if formattedErrors == nil {
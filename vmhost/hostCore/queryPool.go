@@ -0,0 +1,229 @@
+package hostCore
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+)
+
+// QueryResult holds the outcome of a query run by the QueryHostPool
+type QueryResult struct {
+	VMOutput          *vmcommon.VMOutput
+	GasUsed           uint64
+	Duration          time.Duration
+	StorageAccessList *vmhost.StorageAccessList
+	ErrorRecords      []*vmhost.ErrorRecord
+}
+
+// QueryHostPool runs read-only contract calls concurrently, on a fixed number of hosts.
+// Each host keeps its own warm instance cache. All hosts read from the same BlockchainHook,
+// which must be an immutable snapshot, safe for concurrent reads. The hosts never write to it:
+// the compiled codes are kept by the pool, and state snapshots are not needed by read-only calls.
+type QueryHostPool struct {
+	allHosts       []*vmHost
+	availableHosts chan *vmHost
+
+	mutClosed sync.RWMutex
+	closed    bool
+}
+
+// NewQueryHostPool creates numHosts hosts, all reading from the given BlockchainHook
+func NewQueryHostPool(
+	numHosts int,
+	blockChainHook vmcommon.BlockchainHook,
+	hostParameters *vmhost.VMHostParameters,
+) (*QueryHostPool, error) {
+	if numHosts < 1 {
+		return nil, fmt.Errorf("%w: the query host pool needs at least one host", vmhost.ErrInvalidArgument)
+	}
+
+	pool := &QueryHostPool{
+		allHosts:       make([]*vmHost, 0, numHosts),
+		availableHosts: make(chan *vmHost, numHosts),
+	}
+	queryHook := newQueryBlockchainHook(blockChainHook)
+	for i := 0; i < numHosts; i++ {
+		newHost, err := NewVMHost(queryHook, hostParameters)
+		if err != nil {
+			_ = pool.Close()
+			return nil, err
+		}
+
+		host := newHost.(*vmHost)
+		host.readOnlyCalls = true
+		pool.allHosts = append(pool.allHosts, host)
+		pool.availableHosts <- host
+	}
+
+	return pool, nil
+}
+
+// RunQuery runs the call in read-only mode on the first available host, waiting for one if
+// all are busy. Calls attempting to write storage or to transfer value fail, as with ExecuteReadOnly.
+// Upgrading or deleting contracts is not a query, and is rejected.
+func (pool *QueryHostPool) RunQuery(input *vmcommon.ContractCallInput) (*QueryResult, error) {
+	return pool.RunQueryWithContext(context.Background(), input)
+}
+
+// RunQueryWithContext runs the query like RunQuery, abandoning it when ctx is cancelled or reaches
+// its deadline, including while waiting for an available host
+func (pool *QueryHostPool) RunQueryWithContext(ctx context.Context, input *vmcommon.ContractCallInput) (*QueryResult, error) {
+	if input.CallValue != nil && input.CallValue.Sign() != 0 || len(input.ESDTTransfers) > 0 {
+		return nil, vmhost.ErrInvalidQueryInput
+	}
+	if input.Function == vmhost.UpgradeFunctionName || input.Function == vmhost.DeleteFunctionName {
+		return nil, vmhost.ErrInvalidQueryInput
+	}
+
+	pool.mutClosed.RLock()
+	defer pool.mutClosed.RUnlock()
+	if pool.closed {
+		return nil, vmhost.ErrQueryPoolClosed
+	}
+
+	var host *vmHost
+	select {
+	case host = <-pool.availableHosts:
+	case <-ctx.Done():
+		return nil, vmhost.ErrExecutionCancelled
+	}
+	defer func() {
+		pool.availableHosts <- host
+	}()
+
+	start := time.Now()
+	vmOutput, info, err := host.RunSmartContractCallWithInfo(ctx, input)
+	duration := time.Since(start)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkQueryOutputHasNoStateChanges(vmOutput)
+	if err != nil {
+		return nil, err
+	}
+
+	gasUsed := uint64(0)
+	if input.GasProvided > vmOutput.GasRemaining {
+		gasUsed = input.GasProvided - vmOutput.GasRemaining
+	}
+
+	return &QueryResult{
+		VMOutput:          vmOutput,
+		GasUsed:           gasUsed,
+		Duration:          duration,
+		StorageAccessList: info.StorageAccessList,
+		ErrorRecords:      info.ErrorRecords,
+	}, nil
+}
+
+// NumHosts returns the number of hosts in the pool
+func (pool *QueryHostPool) NumHosts() int {
+	return len(pool.allHosts)
+}
+
+// Close waits for the running queries to finish, then closes all the hosts
+func (pool *QueryHostPool) Close() error {
+	pool.mutClosed.Lock()
+	defer pool.mutClosed.Unlock()
+
+	if pool.closed {
+		return nil
+	}
+	pool.closed = true
+
+	for _, host := range pool.allHosts {
+		_ = host.Close()
+	}
+
+	return nil
+}
+
+// checkQueryOutputHasNoStateChanges is a safety net: the read-only mode should already
+// prevent all the state changes reported here
+func checkQueryOutputHasNoStateChanges(vmOutput *vmcommon.VMOutput) error {
+	if len(vmOutput.DeletedAccounts) > 0 {
+		return fmt.Errorf("%w: %d accounts deleted", vmhost.ErrQueryModifiedState, len(vmOutput.DeletedAccounts))
+	}
+
+	for _, outputAccount := range vmOutput.OutputAccounts {
+		for _, storageUpdate := range outputAccount.StorageUpdates {
+			if storageUpdate.Written {
+				return fmt.Errorf("%w: storage written for address %x", vmhost.ErrQueryModifiedState, outputAccount.Address)
+			}
+		}
+		if len(outputAccount.OutputTransfers) > 0 {
+			return fmt.Errorf("%w: transfers from address %x", vmhost.ErrQueryModifiedState, outputAccount.Address)
+		}
+		if outputAccount.BalanceDelta != nil && outputAccount.BalanceDelta.Cmp(big.NewInt(0)) != 0 {
+			return fmt.Errorf("%w: balance changed for address %x", vmhost.ErrQueryModifiedState, outputAccount.Address)
+		}
+		if len(outputAccount.Code) > 0 {
+			return fmt.Errorf("%w: code changed for address %x", vmhost.ErrQueryModifiedState, outputAccount.Address)
+		}
+	}
+
+	return nil
+}
+
+// queryBlockchainHook shields the BlockchainHook shared by the hosts of a QueryHostPool from
+// the writes the hosts would otherwise make to it
+type queryBlockchainHook struct {
+	vmcommon.BlockchainHook
+
+	mutCompiledCodes sync.RWMutex
+	compiledCodes    map[string][]byte
+}
+
+func newQueryBlockchainHook(blockChainHook vmcommon.BlockchainHook) *queryBlockchainHook {
+	return &queryBlockchainHook{
+		BlockchainHook: blockChainHook,
+		compiledCodes:  make(map[string][]byte),
+	}
+}
+
+// SaveCompiledCode keeps the compiled code in the pool, instead of passing it to the underlying hook
+func (hook *queryBlockchainHook) SaveCompiledCode(codeHash []byte, code []byte) {
+	hook.mutCompiledCodes.Lock()
+	hook.compiledCodes[string(codeHash)] = code
+	hook.mutCompiledCodes.Unlock()
+}
+
+// GetCompiledCode returns the code compiled by the pool, falling back to the underlying hook
+func (hook *queryBlockchainHook) GetCompiledCode(codeHash []byte) (bool, []byte) {
+	hook.mutCompiledCodes.RLock()
+	compiledCode, found := hook.compiledCodes[string(codeHash)]
+	hook.mutCompiledCodes.RUnlock()
+	if found {
+		return true, compiledCode
+	}
+
+	return hook.BlockchainHook.GetCompiledCode(codeHash)
+}
+
+// ClearCompiledCodes clears the codes compiled by the pool, leaving the underlying hook unchanged
+func (hook *queryBlockchainHook) ClearCompiledCodes() {
+	hook.mutCompiledCodes.Lock()
+	hook.compiledCodes = make(map[string][]byte)
+	hook.mutCompiledCodes.Unlock()
+}
+
+// GetSnapshot returns a constant, since the read-only calls have no changes to revert
+func (hook *queryBlockchainHook) GetSnapshot() int {
+	return 0
+}
+
+// RevertToSnapshot does nothing, since the read-only calls have no changes to revert
+func (hook *queryBlockchainHook) RevertToSnapshot(_ int) error {
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (hook *queryBlockchainHook) IsInterfaceNil() bool {
+	return hook == nil
+}
@@ -0,0 +1,113 @@
+package hostCore
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	contextmock "github.com/multiversx/mx-chain-vm-go/mock/context"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryHostPool_RejectsValueTransfers(t *testing.T) {
+	t.Parallel()
+
+	pool := &QueryHostPool{}
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{CallValue: big.NewInt(1)},
+	}
+	_, err := pool.RunQuery(input)
+	require.Equal(t, vmhost.ErrInvalidQueryInput, err)
+
+	input.CallValue = big.NewInt(0)
+	input.ESDTTransfers = []*vmcommon.ESDTTransfer{{ESDTTokenName: []byte("TOKEN")}}
+	_, err = pool.RunQuery(input)
+	require.Equal(t, vmhost.ErrInvalidQueryInput, err)
+
+	input.ESDTTransfers = nil
+	input.Function = vmhost.UpgradeFunctionName
+	_, err = pool.RunQuery(input)
+	require.Equal(t, vmhost.ErrInvalidQueryInput, err)
+
+	input.Function = vmhost.DeleteFunctionName
+	_, err = pool.RunQuery(input)
+	require.Equal(t, vmhost.ErrInvalidQueryInput, err)
+
+	pool.closed = true
+	_, err = pool.RunQuery(&vmcommon.ContractCallInput{})
+	require.Equal(t, vmhost.ErrQueryPoolClosed, err)
+}
+
+func TestCheckQueryOutputHasNoStateChanges(t *testing.T) {
+	t.Parallel()
+
+	readOnlyOutput := &vmcommon.VMOutput{
+		OutputAccounts: map[string]*vmcommon.OutputAccount{
+			"sc": {
+				Address:      []byte("sc"),
+				BalanceDelta: big.NewInt(0),
+				StorageUpdates: map[string]*vmcommon.StorageUpdate{
+					"key": {Offset: []byte("key"), Data: []byte("value"), Written: false},
+				},
+			},
+		},
+	}
+	require.Nil(t, checkQueryOutputHasNoStateChanges(readOnlyOutput))
+
+	readOnlyOutput.OutputAccounts["sc"].StorageUpdates["key"].Written = true
+	err := checkQueryOutputHasNoStateChanges(readOnlyOutput)
+	require.True(t, errors.Is(err, vmhost.ErrQueryModifiedState))
+
+	transferOutput := &vmcommon.VMOutput{
+		OutputAccounts: map[string]*vmcommon.OutputAccount{
+			"sc": {
+				Address:         []byte("sc"),
+				OutputTransfers: []vmcommon.OutputTransfer{{Value: big.NewInt(1)}},
+			},
+		},
+	}
+	err = checkQueryOutputHasNoStateChanges(transferOutput)
+	require.True(t, errors.Is(err, vmhost.ErrQueryModifiedState))
+}
+
+func TestQueryBlockchainHook_DoesNotWriteToTheUnderlyingHook(t *testing.T) {
+	t.Parallel()
+
+	underlyingHook := &contextmock.BlockchainHookStub{
+		SaveCompiledCodeCalled: func(_ []byte, _ []byte) {
+			require.Fail(t, "compiled code saved to the shared hook")
+		},
+		GetCompiledCodeCalled: func(codeHash []byte) (bool, []byte) {
+			if string(codeHash) == "underlying" {
+				return true, []byte("underlying code")
+			}
+			return false, nil
+		},
+		GetSnapshotCalled: func() int {
+			require.Fail(t, "snapshot taken from the shared hook")
+			return 0
+		},
+		RevertToSnapshotCalled: func(_ int) error {
+			require.Fail(t, "shared hook reverted")
+			return nil
+		},
+	}
+	hook := newQueryBlockchainHook(underlyingHook)
+
+	hook.SaveCompiledCode([]byte("hash"), []byte("code"))
+	found, code := hook.GetCompiledCode([]byte("hash"))
+	require.True(t, found)
+	require.Equal(t, []byte("code"), code)
+
+	found, code = hook.GetCompiledCode([]byte("underlying"))
+	require.True(t, found)
+	require.Equal(t, []byte("underlying code"), code)
+
+	require.Nil(t, hook.RevertToSnapshot(hook.GetSnapshot()))
+
+	hook.ClearCompiledCodes()
+	found, _ = hook.GetCompiledCode([]byte("hash"))
+	require.False(t, found)
+}
@@ -0,0 +1,91 @@
+package hostCore
+
+import (
+	"context"
+	"sync"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+)
+
+// SimulationResult holds the outcome of a simulation and all the state it has read
+type SimulationResult struct {
+	VMOutput          *vmcommon.VMOutput
+	StateReads        *StateReads
+	StorageAccessList *vmhost.StorageAccessList
+	ErrorRecords      []*vmhost.ErrorRecord
+}
+
+// Simulator runs calls and deployments as if some accounts had a different state, without
+// changing the underlying BlockchainHook. The VMOutput of a simulation is never committed.
+//
+// The overrides are only seen by the VM: the built-in functions, which the protocol executes
+// on its own accounts, see the state of the underlying BlockchainHook. The built-in functions
+// called on the accounts with overridden ESDT tokens fail with ErrBuiltInFunctionOnOverriddenESDT.
+type Simulator struct {
+	mutSimulation sync.Mutex
+	host          *vmHost
+	hook          *stateOverrideHook
+}
+
+// NewSimulator creates a Simulator with its own host, reading from the given BlockchainHook
+func NewSimulator(blockChainHook vmcommon.BlockchainHook, hostParameters *vmhost.VMHostParameters) (*Simulator, error) {
+	hook := newStateOverrideHook(blockChainHook)
+	host, err := NewVMHost(hook, hostParameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Simulator{
+		host: host.(*vmHost),
+		hook: hook,
+	}, nil
+}
+
+// SimulateCall runs the call with the given state overrides
+func (simulator *Simulator) SimulateCall(input *vmcommon.ContractCallInput, overrides StateOverrides) (*SimulationResult, error) {
+	return simulator.simulate(overrides, func() (*vmcommon.VMOutput, *vmhost.ExecutionInfo, error) {
+		return simulator.host.RunSmartContractCallWithInfo(context.Background(), input)
+	})
+}
+
+// SimulateCreate runs the deployment with the given state overrides
+func (simulator *Simulator) SimulateCreate(input *vmcommon.ContractCreateInput, overrides StateOverrides) (*SimulationResult, error) {
+	return simulator.simulate(overrides, func() (*vmcommon.VMOutput, *vmhost.ExecutionInfo, error) {
+		return simulator.host.RunSmartContractCreateWithInfo(context.Background(), input)
+	})
+}
+
+// Close closes the host of the simulator
+func (simulator *Simulator) Close() error {
+	return simulator.host.Close()
+}
+
+func (simulator *Simulator) simulate(
+	overrides StateOverrides,
+	run func() (*vmcommon.VMOutput, *vmhost.ExecutionInfo, error),
+) (*SimulationResult, error) {
+	simulator.mutSimulation.Lock()
+	defer simulator.mutSimulation.Unlock()
+
+	simulator.hook.reset(overrides)
+	defer simulator.hook.reset(nil)
+
+	// the built-in functions may change the state of the underlying hook
+	snapshot := simulator.hook.GetSnapshot()
+	defer func() {
+		_ = simulator.hook.RevertToSnapshot(snapshot)
+	}()
+
+	vmOutput, info, err := run()
+	if err != nil {
+		return nil, err
+	}
+
+	return &SimulationResult{
+		VMOutput:          vmOutput,
+		StateReads:        simulator.hook.reads,
+		StorageAccessList: info.StorageAccessList,
+		ErrorRecords:      info.ErrorRecords,
+	}, nil
+}
@@ -0,0 +1,438 @@
+package hostCore
+
+import (
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+)
+
+// AccountOverride replaces parts of the state of an account during a simulation.
+// The nil fields keep the state provided by the underlying BlockchainHook.
+type AccountOverride struct {
+	Balance      *big.Int
+	Nonce        *uint64
+	Code         []byte
+	CodeMetadata []byte
+	Storage      map[string][]byte
+	ESDTTokens   []*ESDTTokenOverride
+}
+
+// ESDTTokenOverride replaces the data of an ESDT token held by an account, during a simulation
+type ESDTTokenOverride struct {
+	TokenIdentifier []byte
+	Nonce           uint64
+	Token           *esdt.ESDigitalToken
+}
+
+// StateOverrides holds the account overrides of a simulation, indexed by address
+type StateOverrides map[string]*AccountOverride
+
+// AccountRead is an account read from the state during a simulation, with the values seen by the VM
+type AccountRead struct {
+	Address  []byte
+	Exists   bool
+	Balance  *big.Int
+	Nonce    uint64
+	CodeHash []byte
+}
+
+// StorageRead is a storage value read from the state during a simulation
+type StorageRead struct {
+	Address []byte
+	Key     []byte
+	Value   []byte
+}
+
+// ESDTTokenRead is an ESDT token read from the state during a simulation
+type ESDTTokenRead struct {
+	Address         []byte
+	TokenIdentifier []byte
+	Nonce           uint64
+	Value           *big.Int
+}
+
+// StateReads holds all the state read by a simulation, in the order of the first read
+type StateReads struct {
+	Accounts   []*AccountRead
+	Storage    []*StorageRead
+	ESDTTokens []*ESDTTokenRead
+}
+
+// stateOverrideHook layers the overrides of a simulation over a BlockchainHook, leaving it
+// unchanged, and records the state read through it
+type stateOverrideHook struct {
+	vmcommon.BlockchainHook
+
+	overrides     StateOverrides
+	compiledCodes map[string][]byte
+
+	reads        *StateReads
+	readAccounts map[string]struct{}
+	readStorage  map[string]struct{}
+	readTokens   map[string]struct{}
+}
+
+func newStateOverrideHook(blockChainHook vmcommon.BlockchainHook) *stateOverrideHook {
+	hook := &stateOverrideHook{
+		BlockchainHook: blockChainHook,
+	}
+	hook.reset(nil)
+
+	return hook
+}
+
+// reset replaces the overrides and forgets the state read so far
+func (hook *stateOverrideHook) reset(overrides StateOverrides) {
+	hook.overrides = overrides
+	hook.compiledCodes = make(map[string][]byte)
+	hook.reads = &StateReads{
+		Accounts:   make([]*AccountRead, 0),
+		Storage:    make([]*StorageRead, 0),
+		ESDTTokens: make([]*ESDTTokenRead, 0),
+	}
+	hook.readAccounts = make(map[string]struct{})
+	hook.readStorage = make(map[string]struct{})
+	hook.readTokens = make(map[string]struct{})
+}
+
+// GetUserAccount returns the account from the underlying hook, with the overrides applied
+func (hook *stateOverrideHook) GetUserAccount(address []byte) (vmcommon.UserAccountHandler, error) {
+	account, err := hook.BlockchainHook.GetUserAccount(address)
+	override, hasOverride := hook.overrides[string(address)]
+	if hasOverride {
+		account, err = newOverriddenAccount(address, account, override), nil
+	}
+
+	hook.recordAccountRead(address, account, err)
+	return account, err
+}
+
+// GetCode returns the overridden code of the account, if any
+func (hook *stateOverrideHook) GetCode(account vmcommon.UserAccountHandler) []byte {
+	overridden, isOverridden := account.(*overriddenAccount)
+	if !isOverridden {
+		return hook.BlockchainHook.GetCode(account)
+	}
+	if overridden.override.Code != nil {
+		return overridden.override.Code
+	}
+	if overridden.UserAccountHandler == nil {
+		return nil
+	}
+
+	return hook.BlockchainHook.GetCode(overridden.UserAccountHandler)
+}
+
+// IsSmartContract returns true for the accounts with overridden code
+func (hook *stateOverrideHook) IsSmartContract(address []byte) bool {
+	override, hasOverride := hook.overrides[string(address)]
+	if hasOverride && override.Code != nil {
+		return len(override.Code) > 0
+	}
+
+	return hook.BlockchainHook.IsSmartContract(address)
+}
+
+// GetStorageData returns the overridden storage value, if any
+func (hook *stateOverrideHook) GetStorageData(accountAddress []byte, index []byte) ([]byte, uint32, error) {
+	override, hasOverride := hook.overrides[string(accountAddress)]
+	if hasOverride {
+		value, isValueOverridden := override.Storage[string(index)]
+		if isValueOverridden {
+			hook.recordStorageRead(accountAddress, index, value)
+			return value, 0, nil
+		}
+	}
+
+	value, trieDepth, err := hook.BlockchainHook.GetStorageData(accountAddress, index)
+	if err == nil {
+		hook.recordStorageRead(accountAddress, index, value)
+	}
+	return value, trieDepth, err
+}
+
+// GetAllState returns the storage of the account from the underlying hook, with the overridden values
+func (hook *stateOverrideHook) GetAllState(address []byte) (map[string][]byte, error) {
+	override, hasOverride := hook.overrides[string(address)]
+	state, err := hook.BlockchainHook.GetAllState(address)
+	if err != nil || !hasOverride || len(override.Storage) == 0 {
+		return state, err
+	}
+
+	mergedState := make(map[string][]byte, len(state)+len(override.Storage))
+	for key, value := range state {
+		mergedState[key] = value
+	}
+	for key, value := range override.Storage {
+		mergedState[key] = value
+	}
+
+	return mergedState, nil
+}
+
+// GetESDTToken returns the overridden token data, if any
+func (hook *stateOverrideHook) GetESDTToken(address []byte, tokenID []byte, nonce uint64) (*esdt.ESDigitalToken, error) {
+	tokenOverride := hook.findESDTTokenOverride(address, tokenID, nonce)
+	if tokenOverride != nil {
+		hook.recordESDTTokenRead(address, tokenID, nonce, tokenOverride.Token)
+		return tokenOverride.Token, nil
+	}
+
+	token, err := hook.BlockchainHook.GetESDTToken(address, tokenID, nonce)
+	if err == nil {
+		hook.recordESDTTokenRead(address, tokenID, nonce, token)
+	}
+	return token, err
+}
+
+// ProcessBuiltInFunction rejects the built-in functions involving the accounts with overridden ESDT tokens,
+// since they run on the underlying hook and would not see the overridden tokens
+func (hook *stateOverrideHook) ProcessBuiltInFunction(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	if hook.involvesOverriddenESDT(input) {
+		return nil, vmhost.ErrBuiltInFunctionOnOverriddenESDT
+	}
+
+	return hook.BlockchainHook.ProcessBuiltInFunction(input)
+}
+
+// SaveCompiledCode keeps the compiled overridden code in the hook, instead of passing it to the underlying hook
+func (hook *stateOverrideHook) SaveCompiledCode(codeHash []byte, code []byte) {
+	if hook.isOverriddenCodeHash(codeHash) {
+		hook.compiledCodes[string(codeHash)] = code
+		return
+	}
+
+	hook.BlockchainHook.SaveCompiledCode(codeHash, code)
+}
+
+// GetCompiledCode returns the compiled overridden code, if any
+func (hook *stateOverrideHook) GetCompiledCode(codeHash []byte) (bool, []byte) {
+	if hook.isOverriddenCodeHash(codeHash) {
+		compiledCode, found := hook.compiledCodes[string(codeHash)]
+		return found, compiledCode
+	}
+
+	return hook.BlockchainHook.GetCompiledCode(codeHash)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (hook *stateOverrideHook) IsInterfaceNil() bool {
+	return hook == nil
+}
+
+func (hook *stateOverrideHook) isOverriddenCodeHash(codeHash []byte) bool {
+	for _, override := range hook.overrides {
+		if override.Code != nil && string(overriddenCodeHash(override.Code)) == string(codeHash) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// involvesOverriddenESDT returns true if the caller, the recipient or any address among the arguments
+// of the call, such as the destination of a token transfer, has overridden ESDT tokens
+func (hook *stateOverrideHook) involvesOverriddenESDT(input *vmcommon.ContractCallInput) bool {
+	addresses := append([][]byte{input.CallerAddr, input.RecipientAddr}, input.Arguments...)
+	for _, address := range addresses {
+		override, hasOverride := hook.overrides[string(address)]
+		if hasOverride && len(override.ESDTTokens) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (hook *stateOverrideHook) findESDTTokenOverride(address []byte, tokenID []byte, nonce uint64) *ESDTTokenOverride {
+	override, hasOverride := hook.overrides[string(address)]
+	if !hasOverride {
+		return nil
+	}
+
+	for _, tokenOverride := range override.ESDTTokens {
+		if string(tokenOverride.TokenIdentifier) == string(tokenID) && tokenOverride.Nonce == nonce {
+			return tokenOverride
+		}
+	}
+
+	return nil
+}
+
+func (hook *stateOverrideHook) recordAccountRead(address []byte, account vmcommon.UserAccountHandler, err error) {
+	_, alreadyRead := hook.readAccounts[string(address)]
+	if alreadyRead {
+		return
+	}
+	hook.readAccounts[string(address)] = struct{}{}
+
+	accountRead := &AccountRead{
+		Address: address,
+		Balance: big.NewInt(0),
+	}
+	if err == nil && account != nil && !account.IsInterfaceNil() {
+		accountRead.Exists = true
+		accountRead.Balance = account.GetBalance()
+		accountRead.Nonce = account.GetNonce()
+		accountRead.CodeHash = account.GetCodeHash()
+	}
+
+	hook.reads.Accounts = append(hook.reads.Accounts, accountRead)
+}
+
+func (hook *stateOverrideHook) recordStorageRead(address []byte, key []byte, value []byte) {
+	readKey := string(address) + string(key)
+	_, alreadyRead := hook.readStorage[readKey]
+	if alreadyRead {
+		return
+	}
+	hook.readStorage[readKey] = struct{}{}
+
+	hook.reads.Storage = append(hook.reads.Storage, &StorageRead{
+		Address: address,
+		Key:     key,
+		Value:   value,
+	})
+}
+
+func (hook *stateOverrideHook) recordESDTTokenRead(address []byte, tokenID []byte, nonce uint64, token *esdt.ESDigitalToken) {
+	readKey := string(address) + string(tokenID) + string(big.NewInt(0).SetUint64(nonce).Bytes())
+	_, alreadyRead := hook.readTokens[readKey]
+	if alreadyRead {
+		return
+	}
+	hook.readTokens[readKey] = struct{}{}
+
+	value := big.NewInt(0)
+	if token != nil && token.Value != nil {
+		value = token.Value
+	}
+	hook.reads.ESDTTokens = append(hook.reads.ESDTTokens, &ESDTTokenRead{
+		Address:         address,
+		TokenIdentifier: tokenID,
+		Nonce:           nonce,
+		Value:           value,
+	})
+}
+
+func overriddenCodeHash(code []byte) []byte {
+	codeHash := sha256.Sum256(code)
+	return codeHash[:]
+}
+
+// overriddenAccount applies an AccountOverride over an account of the underlying hook,
+// which is nil if the account does not exist
+type overriddenAccount struct {
+	vmcommon.UserAccountHandler
+	address  []byte
+	override *AccountOverride
+}
+
+func newOverriddenAccount(address []byte, account vmcommon.UserAccountHandler, override *AccountOverride) *overriddenAccount {
+	if account != nil && account.IsInterfaceNil() {
+		account = nil
+	}
+
+	return &overriddenAccount{
+		UserAccountHandler: account,
+		address:            address,
+		override:           override,
+	}
+}
+
+// AddressBytes returns the address of the account
+func (account *overriddenAccount) AddressBytes() []byte {
+	return account.address
+}
+
+// GetBalance returns the overridden balance, if any
+func (account *overriddenAccount) GetBalance() *big.Int {
+	if account.override.Balance != nil {
+		return big.NewInt(0).Set(account.override.Balance)
+	}
+	if account.UserAccountHandler == nil {
+		return big.NewInt(0)
+	}
+
+	return account.UserAccountHandler.GetBalance()
+}
+
+// GetNonce returns the overridden nonce, if any
+func (account *overriddenAccount) GetNonce() uint64 {
+	if account.override.Nonce != nil {
+		return *account.override.Nonce
+	}
+	if account.UserAccountHandler == nil {
+		return 0
+	}
+
+	return account.UserAccountHandler.GetNonce()
+}
+
+// GetCodeHash returns the hash of the overridden code, if any
+func (account *overriddenAccount) GetCodeHash() []byte {
+	if account.override.Code != nil {
+		return overriddenCodeHash(account.override.Code)
+	}
+	if account.UserAccountHandler == nil {
+		return nil
+	}
+
+	return account.UserAccountHandler.GetCodeHash()
+}
+
+// GetCodeMetadata returns the overridden code metadata, if any
+func (account *overriddenAccount) GetCodeMetadata() []byte {
+	if account.override.CodeMetadata != nil {
+		return account.override.CodeMetadata
+	}
+	if account.UserAccountHandler == nil {
+		return nil
+	}
+
+	return account.UserAccountHandler.GetCodeMetadata()
+}
+
+// GetRootHash returns the root hash of the underlying account
+func (account *overriddenAccount) GetRootHash() []byte {
+	if account.UserAccountHandler == nil {
+		return nil
+	}
+
+	return account.UserAccountHandler.GetRootHash()
+}
+
+// GetDeveloperReward returns the developer reward of the underlying account
+func (account *overriddenAccount) GetDeveloperReward() *big.Int {
+	if account.UserAccountHandler == nil {
+		return big.NewInt(0)
+	}
+
+	return account.UserAccountHandler.GetDeveloperReward()
+}
+
+// GetOwnerAddress returns the owner of the underlying account
+func (account *overriddenAccount) GetOwnerAddress() []byte {
+	if account.UserAccountHandler == nil {
+		return nil
+	}
+
+	return account.UserAccountHandler.GetOwnerAddress()
+}
+
+// GetUserName returns the user name of the underlying account
+func (account *overriddenAccount) GetUserName() []byte {
+	if account.UserAccountHandler == nil {
+		return nil
+	}
+
+	return account.UserAccountHandler.GetUserName()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (account *overriddenAccount) IsInterfaceNil() bool {
+	return account == nil
+}
@@ -0,0 +1,125 @@
+package hostCore
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	worldmock "github.com/multiversx/mx-chain-vm-go/mock/world"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateOverrideHook_OverridesAndRecordsReads(t *testing.T) {
+	t.Parallel()
+
+	world := worldmock.NewMockWorld()
+	existing := world.AcctMap.CreateAccount([]byte("existing________________________"), world)
+	existing.Balance = big.NewInt(100)
+	existing.Nonce = 3
+	existing.Storage["key"] = []byte("real")
+
+	nonce := uint64(7)
+	hook := newStateOverrideHook(world)
+	hook.reset(StateOverrides{
+		string(existing.Address): {
+			Balance: big.NewInt(500),
+			Storage: map[string][]byte{"overridden": []byte("fake")},
+		},
+		"missing_________________________": {
+			Nonce: &nonce,
+			Code:  []byte("code"),
+			ESDTTokens: []*ESDTTokenOverride{{
+				TokenIdentifier: []byte("TOKEN-123456"),
+				Token:           &esdt.ESDigitalToken{Value: big.NewInt(42)},
+			}},
+		},
+	})
+
+	account, err := hook.GetUserAccount(existing.Address)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(500), account.GetBalance())
+	require.Equal(t, uint64(3), account.GetNonce())
+
+	missing, err := hook.GetUserAccount([]byte("missing_________________________"))
+	require.Nil(t, err)
+	require.Equal(t, uint64(7), missing.GetNonce())
+	require.Equal(t, []byte("code"), hook.GetCode(missing))
+	require.Equal(t, overriddenCodeHash([]byte("code")), missing.GetCodeHash())
+	require.True(t, hook.IsSmartContract([]byte("missing_________________________")))
+
+	value, _, err := hook.GetStorageData(existing.Address, []byte("key"))
+	require.Nil(t, err)
+	require.Equal(t, []byte("real"), value)
+	value, _, err = hook.GetStorageData(existing.Address, []byte("overridden"))
+	require.Nil(t, err)
+	require.Equal(t, []byte("fake"), value)
+	_, _, _ = hook.GetStorageData(existing.Address, []byte("key"))
+
+	token, err := hook.GetESDTToken([]byte("missing_________________________"), []byte("TOKEN-123456"), 0)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(42), token.Value)
+
+	require.Len(t, hook.reads.Accounts, 2)
+	require.Len(t, hook.reads.Storage, 2)
+	require.Len(t, hook.reads.ESDTTokens, 1)
+	require.Equal(t, big.NewInt(42), hook.reads.ESDTTokens[0].Value)
+
+	require.Equal(t, big.NewInt(100), world.AcctMap.GetAccount(existing.Address).Balance)
+	require.Nil(t, world.AcctMap.GetAccount([]byte("missing_________________________")))
+}
+
+func TestStateOverrideHook_GetAllState(t *testing.T) {
+	t.Parallel()
+
+	world := worldmock.NewMockWorld()
+	existing := world.AcctMap.CreateAccount([]byte("existing________________________"), world)
+	existing.Storage["key"] = []byte("real")
+
+	hook := newStateOverrideHook(world)
+	hook.reset(StateOverrides{
+		string(existing.Address):           {Storage: map[string][]byte{"overridden": []byte("fake")}},
+		"missing_________________________": {Storage: map[string][]byte{"overridden": []byte("fake")}},
+	})
+
+	state, err := hook.GetAllState(existing.Address)
+	require.Nil(t, err)
+	require.Equal(t, map[string][]byte{"key": []byte("real"), "overridden": []byte("fake")}, state)
+
+	_, err = hook.GetAllState([]byte("missing_________________________"))
+	require.NotNil(t, err)
+}
+
+func TestStateOverrideHook_ProcessBuiltInFunctionOnOverriddenESDT(t *testing.T) {
+	t.Parallel()
+
+	holder := []byte("holder__________________________")
+	hook := newStateOverrideHook(worldmock.NewMockWorld())
+	hook.reset(StateOverrides{
+		string(holder): {ESDTTokens: []*ESDTTokenOverride{{
+			TokenIdentifier: []byte("TOKEN-123456"),
+			Token:           &esdt.ESDigitalToken{Value: big.NewInt(42)},
+		}}},
+	})
+
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: holder,
+			Arguments:  [][]byte{[]byte("TOKEN-123456"), big.NewInt(1).Bytes()},
+		},
+		RecipientAddr: []byte("receiver________________________"),
+		Function:      core.BuiltInFunctionESDTTransfer,
+	}
+	_, err := hook.ProcessBuiltInFunction(input)
+	require.Equal(t, vmhost.ErrBuiltInFunctionOnOverriddenESDT, err)
+
+	// the destination of an NFT transfer is among the arguments
+	input.CallerAddr = []byte("sender__________________________")
+	input.RecipientAddr = input.CallerAddr
+	input.Function = core.BuiltInFunctionESDTNFTTransfer
+	input.Arguments = [][]byte{[]byte("NFT-123456"), {1}, {1}, holder}
+	_, err = hook.ProcessBuiltInFunction(input)
+	require.Equal(t, vmhost.ErrBuiltInFunctionOnOverriddenESDT, err)
+}
@@ -0,0 +1,122 @@
+package hostCoretest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	test "github.com/multiversx/mx-chain-vm-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecution_CallWithContext_Cancelled(t *testing.T) {
+	code := test.GetTestSCCode("counter", "../../")
+	host := test.NewTestHostBuilder(t).
+		WithBlockchainHook(test.BlockchainHookStubForCall(code, nil)).
+		Build()
+	defer func() {
+		host.Reset()
+	}()
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 1_000_000
+	input.Function = get
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	vmOutput, err := host.RunSmartContractCallWithContext(ctx, input)
+	require.Equal(t, vmhost.ErrExecutionCancelled, err)
+	require.Nil(t, vmOutput)
+
+	vmOutput, err = host.RunSmartContractCallWithContext(context.Background(), input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+}
+
+func TestExecution_CallWithContext_DeadlineExpired(t *testing.T) {
+	code := test.GetTestSCCode("counter", "../../")
+	host := test.NewTestHostBuilder(t).
+		WithBlockchainHook(test.BlockchainHookStubForCall(code, nil)).
+		Build()
+	defer func() {
+		host.Reset()
+	}()
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 1_000_000
+	input.Function = get
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	vmOutput, err := host.RunSmartContractCallWithContext(ctx, input)
+	require.Equal(t, vmhost.ErrExecutionCancelled, err)
+	require.Nil(t, vmOutput)
+}
+
+func TestExecution_CallWithContext_CancelledDuringExecution(t *testing.T) {
+	code := test.GetTestSCCode("bad-extra", "../../")
+	host := test.NewTestHostBuilder(t).
+		WithBlockchainHook(test.BlockchainHookStubForCall(code, nil)).
+		Build()
+	defer func() {
+		host.Reset()
+	}()
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 1_000_000_000_000
+	input.Function = "bigLoop"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	vmOutput, err := host.RunSmartContractCallWithContext(ctx, input)
+	require.Equal(t, vmhost.ErrExecutionCancelled, err)
+	require.NotNil(t, vmOutput)
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, vmhost.ErrExecutionCancelled.Error(), vmOutput.ReturnMessage)
+}
+
+func TestExecution_CreateWithContext_Cancelled(t *testing.T) {
+	host := test.NewTestHostBuilder(t).
+		WithBlockchainHook(test.BlockchainHookStubForCall(nil, nil)).
+		Build()
+	defer func() {
+		host.Reset()
+	}()
+
+	input := test.CreateTestContractCreateInputBuilder().
+		WithGasProvided(1_000_000).
+		WithContractCode(test.GetTestSCCode("counter", "../../")).
+		Build()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	vmOutput, err := host.RunSmartContractCreateWithContext(ctx, input)
+	require.Equal(t, vmhost.ErrExecutionCancelled, err)
+	require.Nil(t, vmOutput)
+
+	vmOutput, err = host.RunSmartContractCreateWithContext(context.Background(), input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+}
+
+func TestExecution_CreateWithContext_DeadlineExpired(t *testing.T) {
+	host := test.NewTestHostBuilder(t).
+		WithBlockchainHook(test.BlockchainHookStubForCall(nil, nil)).
+		Build()
+	defer func() {
+		host.Reset()
+	}()
+
+	input := test.CreateTestContractCreateInputBuilder().
+		WithGasProvided(1_000_000).
+		WithContractCode(test.GetTestSCCode("counter", "../../")).
+		Build()
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	vmOutput, err := host.RunSmartContractCreateWithContext(ctx, input)
+	require.Equal(t, vmhost.ErrExecutionCancelled, err)
+	require.Nil(t, vmOutput)
+}
@@ -0,0 +1,116 @@
+package hostCoretest
+
+import (
+	"bytes"
+	"testing"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-go/executor"
+	executorwrapper "github.com/multiversx/mx-chain-vm-go/executor/wrapper"
+	test "github.com/multiversx/mx-chain-vm-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-go/wasmer"
+	"github.com/multiversx/mx-chain-vm-go/wasmer2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecution_DebugHooks_Enabled(t *testing.T) {
+	code := test.GetTestSCCode("debug-print", "../../")
+	debugOutput := &bytes.Buffer{}
+	host := test.NewTestHostBuilder(t).
+		WithBlockchainHook(test.BlockchainHookStubForCall(code, nil)).
+		WithExecutorFactory(wasmer.ExecutorFactory()).
+		WithDebugHooks(debugOutput).
+		Build()
+	defer func() {
+		host.Reset()
+	}()
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 1_000_000
+	input.Function = "print"
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+
+	printed := debugOutput.String()
+	require.Contains(t, printed, "print: big int")
+	require.Contains(t, printed, ": 42\n")
+	require.Contains(t, printed, `0x68656c6c6f "hello"`)
+	require.Contains(t, printed, "print: gas left: ")
+}
+
+func TestExecution_DebugHooks_ExecutorLogs(t *testing.T) {
+	code := test.GetTestSCCode("debug-print", "../../")
+	executorLogger := executorwrapper.NewStringLogger()
+	host := test.NewTestHostBuilder(t).
+		WithBlockchainHook(test.BlockchainHookStubForCall(code, nil)).
+		WithExecutorFactory(wasmer.ExecutorFactory()).
+		WithExecutorLogs(executorLogger).
+		WithDebugHooks(nil).
+		Build()
+	defer func() {
+		host.Reset()
+	}()
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 1_000_000
+	input.Function = "print"
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+
+	executorLogs := executorLogger.String()
+	require.Contains(t, executorLogs, "VM hook begin: DebugPrintBigInt(")
+	require.Contains(t, executorLogs, "print: big int")
+	require.Contains(t, executorLogs, "VM hook end:   DebugPrintGasLeft()")
+}
+
+func TestExecution_DebugHooks_Disabled_Deploy(t *testing.T) {
+	code := test.GetTestSCCode("debug-print", "../../")
+	host := test.NewTestHostBuilder(t).
+		WithBlockchainHook(test.BlockchainHookStubForCall(nil, nil)).
+		WithExecutorFactory(wasmer.ExecutorFactory()).
+		Build()
+	defer func() {
+		host.Reset()
+	}()
+
+	input := test.CreateTestContractCreateInputBuilder().
+		WithGasProvided(1_000_000).
+		WithContractCode(code).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCreate(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.ContractInvalid, vmOutput.ReturnCode)
+}
+
+func TestExecution_DebugHooks_Disabled_Call(t *testing.T) {
+	code := test.GetTestSCCode("debug-print", "../../")
+	host := test.NewTestHostBuilder(t).
+		WithBlockchainHook(test.BlockchainHookStubForCall(code, nil)).
+		WithExecutorFactory(wasmer.ExecutorFactory()).
+		Build()
+	defer func() {
+		host.Reset()
+	}()
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 1_000_000
+	input.Function = "print"
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.ExecutionFailed().
+		ReturnMessage(vmhost.ErrDebugHooksDisabled.Error())
+}
+
+func TestExecution_DebugHooks_NotSupportedByWasmer2(t *testing.T) {
+	_, err := wasmer2.ExecutorFactory().CreateExecutor(executor.ExecutorFactoryArgs{
+		DebugHooksEnabled: true,
+	})
+	require.Equal(t, wasmer2.ErrDebugHooksNotSupported, err)
+}
@@ -0,0 +1,75 @@
+package hostCoretest
+
+import (
+	"testing"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	test "github.com/multiversx/mx-chain-vm-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecution_EstimateGasForCall(t *testing.T) {
+	code := test.GetTestSCCode("counter", "../../")
+	host := test.NewTestHostBuilder(t).
+		WithBlockchainHook(test.BlockchainHookStubForCall(code, nil)).
+		Build()
+	defer func() {
+		host.Reset()
+	}()
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 1_000_000
+	input.Function = increment
+
+	estimate, err := host.EstimateGasForCall(input)
+	require.Nil(t, err)
+	require.Greater(t, estimate.GasLimit, uint64(0))
+	require.Less(t, estimate.GasLimit, input.GasProvided)
+	require.Greater(t, estimate.NumRuns, 1)
+	require.Zero(t, estimate.GasLockedForAsync)
+	require.Equal(t, uint64(1_000_000), input.GasProvided)
+
+	input.GasProvided = estimate.GasLimit
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+	require.Equal(t, estimate.GasUsed, estimate.GasLimit-vmOutput.GasRemaining)
+
+	input.GasProvided = estimate.GasLimit - 1
+	vmOutput, err = host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotEqual(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	_, err = host.EstimateGasForCall(input)
+	require.ErrorIs(t, err, vmhost.ErrGasEstimationFailed)
+}
+
+func TestExecution_EstimateGasForCreate(t *testing.T) {
+	code := test.GetTestSCCode("counter", "../../")
+	host := test.NewTestHostBuilder(t).
+		WithBlockchainHook(test.BlockchainHookStubForCall(nil, nil)).
+		Build()
+	defer func() {
+		host.Reset()
+	}()
+
+	input := test.CreateTestContractCreateInputBuilder().
+		WithGasProvided(1_000_000).
+		WithContractCode(code).
+		Build()
+
+	estimate, err := host.EstimateGasForCreate(input)
+	require.Nil(t, err)
+	require.Less(t, estimate.GasLimit, input.GasProvided)
+
+	input.GasProvided = estimate.GasLimit
+	vmOutput, err := host.RunSmartContractCreate(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+
+	input.GasProvided = estimate.GasLimit - 1
+	vmOutput, err = host.RunSmartContractCreate(input)
+	require.Nil(t, err)
+	require.NotEqual(t, vmcommon.Ok, vmOutput.ReturnCode)
+}
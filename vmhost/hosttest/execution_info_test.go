@@ -0,0 +1,88 @@
+package hostCoretest
+
+import (
+	"context"
+	"testing"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	test "github.com/multiversx/mx-chain-vm-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecution_CallWithInfo_StorageAccessList(t *testing.T) {
+	code := test.GetTestSCCode("counter", "../../")
+	host := test.NewTestHostBuilder(t).
+		WithBlockchainHook(test.BlockchainHookStubForCall(code, nil)).
+		Build()
+	defer func() {
+		host.Reset()
+	}()
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 1_000_000
+	input.Function = increment
+
+	vmOutput, info, err := host.RunSmartContractCallWithInfo(context.Background(), input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+	require.NotNil(t, info)
+	require.Empty(t, info.ErrorRecords)
+
+	accounts := info.StorageAccessList.Accounts()
+	require.Len(t, accounts, 1)
+	require.Equal(t, test.ParentAddress, accounts[0].Address)
+	require.Len(t, accounts[0].Keys, 1)
+
+	access := accounts[0].Keys[0]
+	require.Equal(t, counterKey, access.Key)
+	require.Equal(t, 1, access.Reads)
+	require.NotZero(t, access.LoadGas)
+	require.True(t, access.Written)
+}
+
+func TestExecution_CallWithInfo_TrapErrorRecord(t *testing.T) {
+	code := test.GetTestSCCode("bad-misc", "../../")
+	host := test.NewTestHostBuilder(t).
+		WithBlockchainHook(test.BlockchainHookStubForCall(code, nil)).
+		WithWasmerSIGSEGVPassthrough(false).
+		Build()
+	defer func() {
+		host.Reset()
+	}()
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = test.GasProvided
+	input.Function = "memoryFault"
+
+	vmOutput, info, err := host.RunSmartContractCallWithInfo(context.Background(), input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+
+	rootCause := vmhost.RootCauseErrorRecord(info.ErrorRecords)
+	require.NotNil(t, rootCause)
+	require.Equal(t, vmhost.ErrorKindTrap, rootCause.Kind)
+	require.Equal(t, "memoryFault", rootCause.Frame.Function)
+	require.Equal(t, test.ParentAddress, rootCause.Frame.Address)
+}
+
+func TestExecution_CallWithInfo_Cancelled(t *testing.T) {
+	code := test.GetTestSCCode("counter", "../../")
+	host := test.NewTestHostBuilder(t).
+		WithBlockchainHook(test.BlockchainHookStubForCall(code, nil)).
+		Build()
+	defer func() {
+		host.Reset()
+	}()
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 1_000_000
+	input.Function = increment
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	vmOutput, info, err := host.RunSmartContractCallWithInfo(ctx, input)
+	require.NotNil(t, err)
+	require.Nil(t, vmOutput)
+	require.Nil(t, info)
+}
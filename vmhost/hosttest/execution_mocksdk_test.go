@@ -0,0 +1,219 @@
+package hostCoretest
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	mocksdk "github.com/multiversx/mx-chain-vm-go/mock/sdk"
+	worldmock "github.com/multiversx/mx-chain-vm-go/mock/world"
+	test "github.com/multiversx/mx-chain-vm-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	sdkLastSum      = mocksdk.NewStorageField[*big.Int]("lastSum")
+	sdkLastError    = mocksdk.NewStorageField[string]("lastError")
+	sdkIssuedToken  = mocksdk.NewStorageField[string]("issuedToken")
+	sdkIssuedAmount = mocksdk.NewStorageField[*big.Int]("issuedAmount")
+)
+
+type sdkAddedEvent struct {
+	Caller mocksdk.Address `event:"caller,indexed"`
+	Sum    *big.Int
+}
+
+func makeSDKChildContract() *mocksdk.Contract {
+	child := mocksdk.NewContract("child")
+	added := mocksdk.NewEvent[sdkAddedEvent](child, "added")
+	child.Endpoint("add", func(ctx *mocksdk.Context, first *big.Int, others ...uint64) (*big.Int, error) {
+		sum := big.NewInt(0).Set(first)
+		for _, other := range others {
+			sum.Add(sum, big.NewInt(0).SetUint64(other))
+		}
+		if sum.Cmp(big.NewInt(1000)) > 0 {
+			return nil, errors.New("sum too large")
+		}
+
+		added.Emit(ctx, sdkAddedEvent{Caller: ctx.Caller(), Sum: sum})
+		return sum, nil
+	}, "first", "others")
+
+	return child
+}
+
+func makeSDKParentContract() *mocksdk.Contract {
+	parent := mocksdk.NewContract("parent")
+	parent.Endpoint("callAdd", func(ctx *mocksdk.Context, tag string, first *big.Int, second uint64) {
+		ctx.AsyncCall(test.ChildAddress, "add", first, second).
+			WithGas(100_000, 10_000).
+			WithCallback("addCallback", tag).
+			Register()
+	}, "tag", "first", "second")
+	parent.Callback("addCallback", func(ctx *mocksdk.Context, result *mocksdk.AsyncResult, tag string) {
+		if !result.IsOk() {
+			sdkLastError.Set(ctx, result.Message, tag)
+			return
+		}
+		sdkLastSum.Set(ctx, big.NewInt(0).SetBytes(result.Data[0]), tag)
+	})
+
+	return parent
+}
+
+func runSDKContractsTest(t *testing.T, function string, arguments [][]byte, assertResults func(world *worldmock.MockWorld, verify *test.VMOutputVerifier)) {
+	_, err := test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(1000).
+				WithMethods(makeSDKParentContract().InitMethod()),
+			test.CreateMockContract(test.ChildAddress).
+				WithBalance(1000).
+				WithMethods(makeSDKChildContract().InitMethod()),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(1_000_000).
+			WithFunction(function).
+			WithArguments(arguments...).
+			Build()).
+		WithSetup(func(host vmhost.VMHost, world *worldmock.MockWorld) {
+			setZeroCodeCosts(host)
+			setAsyncCosts(host, 0)
+		}).
+		AndAssertResults(assertResults)
+	assert.Nil(t, err)
+}
+
+func TestExecution_MockSDK_AsyncCallWithCallback(t *testing.T) {
+	runSDKContractsTest(t, "callAdd", [][]byte{[]byte("tag"), {3}, {4}},
+		func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok().
+				Storage(
+					test.CreateStoreEntry(test.ParentAddress).WithKey(sdkLastSum.Key("tag")).WithValue([]byte{7}),
+				)
+
+			var addedLogs int
+			for _, logEntry := range verify.VmOutput.Logs {
+				if string(logEntry.Identifier) != "added" {
+					continue
+				}
+				addedLogs++
+				require.Equal(t, test.ChildAddress, logEntry.Address)
+				require.Equal(t, [][]byte{test.ParentAddress}, logEntry.Topics)
+				require.Equal(t, [][]byte{{7}}, logEntry.Data)
+			}
+			require.Equal(t, 1, addedLogs)
+		})
+}
+
+func TestExecution_MockSDK_AsyncCallError(t *testing.T) {
+	runSDKContractsTest(t, "callAdd", [][]byte{[]byte("tag"), {0x03, 0xe8}, {1}},
+		func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok().
+				HasRuntimeErrors("sum too large").
+				Storage(
+					test.CreateStoreEntry(test.ParentAddress).WithKey(sdkLastError.Key("tag")).WithValue([]byte("sum too large")),
+				)
+		})
+}
+
+func TestExecution_MockSDK_ArgumentErrors(t *testing.T) {
+	runSDKContractsTest(t, "callAdd", [][]byte{[]byte("tag"), {3}},
+		func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.UserError().
+				ReturnMessage("wrong number of arguments")
+		})
+
+	runSDKContractsTest(t, "callAdd", [][]byte{[]byte("tag"), {3}, {1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.UserError().
+				ReturnMessage("argument decode error (second): input too long")
+		})
+}
+
+func makeSDKIssuerContract() *mocksdk.Contract {
+	issuer := mocksdk.NewContract("issuer")
+	issuer.Endpoint("issueToken", func(ctx *mocksdk.Context, ticker string, supply *big.Int) {
+		ctx.AsyncCall(core.ESDTSCAddress, "issue", "IssuedToken", ticker, supply, uint64(18)).
+			WithGas(100_000, 10_000).
+			WithCallback("issueCallback").
+			Register()
+	}, "ticker", "supply")
+	issuer.Callback("issueCallback", func(ctx *mocksdk.Context, result *mocksdk.AsyncResult) {
+		if !result.IsOk() {
+			sdkLastError.Set(ctx, result.Message)
+			return
+		}
+		payments := ctx.ESDTTransfers()
+		ctx.Require(len(payments) == 1, "expected the issued tokens as payment")
+		sdkIssuedToken.Set(ctx, string(payments[0].ESDTTokenName))
+		sdkIssuedAmount.Set(ctx, payments[0].ESDTValue)
+	})
+
+	return issuer
+}
+
+func runSDKIssueTest(t *testing.T, ticker string, assertResults func(world *worldmock.MockWorld, verify *test.VMOutputVerifier)) {
+	world := worldmock.NewMockWorld()
+	world.RegisterESDTSystemSC()
+
+	_, err := test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(1000).
+				WithMethods(makeSDKIssuerContract().InitMethod()),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(1_000_000).
+			WithFunction("issueToken").
+			WithArguments([]byte(ticker), big.NewInt(5000).Bytes()).
+			Build()).
+		WithSetup(func(host vmhost.VMHost, world *worldmock.MockWorld) {
+			setZeroCodeCosts(host)
+			setAsyncCosts(host, 0)
+		}).
+		AndAssertResultsWithWorld(world, true, nil, nil, func(startNode *test.TestCallNode, world *worldmock.MockWorld, verify *test.VMOutputVerifier, expectedErrorsForRound []string) {
+			assertResults(world, verify)
+		})
+	assert.Nil(t, err)
+}
+
+func TestExecution_MockSDK_IssueTokenThroughSystemSC(t *testing.T) {
+	runSDKIssueTest(t, "TKN", func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+		verify.Ok()
+
+		parentOutput := verify.VmOutput.OutputAccounts[string(test.ParentAddress)]
+		require.NotNil(t, parentOutput)
+		issuedToken := parentOutput.StorageUpdates[string(sdkIssuedToken.Key())]
+		require.NotNil(t, issuedToken)
+		require.Regexp(t, "^TKN-[0-9a-f]{6}$", string(issuedToken.Data))
+		require.Equal(t, big.NewInt(5000).Bytes(), parentOutput.StorageUpdates[string(sdkIssuedAmount.Key())].Data)
+
+		// the system SC changes nothing before the output is committed
+		require.Nil(t, world.ESDTSystemSC.GetTokenInfo(issuedToken.Data))
+
+		err := world.UpdateAccounts(verify.VmOutput.OutputAccounts, nil)
+		require.Nil(t, err)
+		token := world.ESDTSystemSC.GetTokenInfo(issuedToken.Data)
+		require.NotNil(t, token)
+		require.Equal(t, test.ParentAddress, token.Owner)
+		balance, err := world.AcctMap.GetAccount(test.ParentAddress).GetTokenBalance(issuedToken.Data, 0)
+		require.Nil(t, err)
+		require.Equal(t, big.NewInt(5000), balance)
+	})
+}
+
+func TestExecution_MockSDK_IssueTokenThroughSystemSCError(t *testing.T) {
+	runSDKIssueTest(t, "tkn", func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+		// no other storage updates, so the failed issue left no state in the system SC
+		verify.Ok().
+			Storage(
+				test.CreateStoreEntry(test.ParentAddress).WithKey(sdkLastError.Key()).WithValue([]byte("ticker name is not valid")),
+			)
+	})
+}
@@ -0,0 +1,61 @@
+package hostCoretest
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	test "github.com/multiversx/mx-chain-vm-go/testcommon"
+	"github.com/stretchr/testify/require"
+)
+
+// run with -race, to check that the hosts of the pool do not share mutable state
+func TestExecution_QueryHostPool_ConcurrentQueries(t *testing.T) {
+	code := test.GetTestSCCode("counter", "../../")
+	blockchainHook := test.BlockchainHookStubForCall(code, nil)
+	numCompiledCodeWrites := int32(0)
+	blockchainHook.SaveCompiledCodeCalled = func(_ []byte, _ []byte) {
+		atomic.AddInt32(&numCompiledCodeWrites, 1)
+	}
+	blockchainHook.GetSnapshotCalled = func() int {
+		require.Fail(t, "snapshot taken from the shared hook")
+		return 0
+	}
+
+	pool := test.NewTestHostBuilder(t).
+		WithBlockchainHook(blockchainHook).
+		BuildQueryHostPool(4)
+	defer func() {
+		_ = pool.Close()
+	}()
+
+	numQueries := 40
+	var wg sync.WaitGroup
+	wg.Add(numQueries)
+	for i := 0; i < numQueries; i++ {
+		go func() {
+			defer wg.Done()
+
+			input := test.DefaultTestContractCallInput()
+			input.GasProvided = 1000000
+			input.Function = get
+
+			result, err := pool.RunQuery(input)
+			require.Nil(t, err)
+			verify := test.NewVMOutputVerifier(t, result.VMOutput, err)
+			verify.Ok()
+			require.Greater(t, result.GasUsed, uint64(0))
+		}()
+	}
+	wg.Wait()
+
+	require.Zero(t, atomic.LoadInt32(&numCompiledCodeWrites))
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = increment
+	result, err := pool.RunQuery(input)
+	require.Nil(t, err)
+	require.NotEqual(t, vmcommon.Ok, result.VMOutput.ReturnCode)
+}
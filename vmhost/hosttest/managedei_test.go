@@ -15,6 +15,7 @@ import (
 	"github.com/multiversx/mx-chain-core-go/data/vm"
 	"github.com/multiversx/mx-chain-scenario-go/esdtconvert"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
 	"github.com/multiversx/mx-chain-vm-go/crypto/hashing"
 	"github.com/multiversx/mx-chain-vm-go/crypto/signing/secp256k1"
 	mock "github.com/multiversx/mx-chain-vm-go/mock/context"
@@ -216,6 +217,49 @@ func testManagedIsESDTFrozenIsLimitedTransfer(t *testing.T, isLimitedTransfer bo
 	assert.Nil(t, err)
 }
 
+func Test_ManagedIsESDTPaused_PerToken(t *testing.T) {
+	testManagedIsESDTPausedPerToken(t, test.ESDTTestTokenName, 1)
+	testManagedIsESDTPausedPerToken(t, []byte("OTHER-abcdef"), 0)
+}
+
+func testManagedIsESDTPausedPerToken(t *testing.T, pausedToken []byte, expectedPaused int64) {
+	testConfig := baseTestConfig
+
+	_, err := test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(testConfig.ParentBalance).
+				WithConfig(testConfig).
+				WithMethods(func(parentInstance *mock.InstanceMock, config interface{}) {
+					parentInstance.AddMockMethod("testFunction", func() *mock.InstanceMock {
+						host := parentInstance.Host
+
+						tokenIDHandle := host.ManagedTypes().NewManagedBufferFromBytes(test.ESDTTestTokenName)
+						isPaused := vmhooks.ManagedIsESDTPausedWithHost(host, tokenIDHandle)
+						isLimitedTransfer := vmhooks.ManagedIsESDTLimitedTransferWithHost(host, tokenIDHandle)
+
+						host.Output().Finish(big.NewInt(int64(isPaused)).Bytes())
+						host.Output().Finish(big.NewInt(int64(isLimitedTransfer)).Bytes())
+						return parentInstance
+					})
+				}),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction("testFunction").
+			Build()).
+		WithSetup(func(host vmhost.VMHost, world *worldmock.MockWorld) {
+			world.SetESDTGlobalSettings(pausedToken, builtInFunctions.ESDTGlobalMetadata{Paused: true})
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.
+				Ok().
+				ReturnData(big.NewInt(expectedPaused).Bytes(), big.NewInt(0).Bytes())
+		})
+	assert.Nil(t, err)
+}
+
 func Test_ManagedBufferToHex(t *testing.T) {
 	testConfig := baseTestConfig
 
@@ -1,6 +1,7 @@
 package vmhost
 
 import (
+	"context"
 	"crypto/elliptic"
 	"io"
 	"math/big"
@@ -56,7 +57,16 @@ type VMHost interface {
 	SetBuiltInFunctionsContainer(builtInFuncs vmcommon.BuiltInFunctionContainer)
 	InitState()
 
+	RunSmartContractCreateWithContext(ctx context.Context, input *vmcommon.ContractCreateInput) (*vmcommon.VMOutput, error)
+	RunSmartContractCallWithContext(ctx context.Context, input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error)
+	RunSmartContractCreateWithInfo(ctx context.Context, input *vmcommon.ContractCreateInput) (*vmcommon.VMOutput, *ExecutionInfo, error)
+	RunSmartContractCallWithInfo(ctx context.Context, input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, *ExecutionInfo, error)
+
+	EstimateGasForCall(input *vmcommon.ContractCallInput) (*GasEstimate, error)
+	EstimateGasForCreate(input *vmcommon.ContractCreateInput) (*GasEstimate, error)
+
 	CompleteLogEntriesWithCallType(vmOutput *vmcommon.VMOutput, callType string)
+	CallGraphRecorder() *CallGraphRecorder
 
 	Reset()
 }
@@ -154,7 +164,11 @@ type RuntimeContext interface {
 	CleanInstance()
 
 	AddError(err error, otherInfo ...string)
+	AddTypedError(kind ErrorKind, err error, otherInfo ...string)
 	GetAllErrors() error
+	GetErrorRecords() []*ErrorRecord
+	DebugHooksEnabled() bool
+	DebugPrint(message string)
 
 	ValidateCallbackName(callbackName string) error
 	HasFunction(functionName string) bool
@@ -328,9 +342,10 @@ type StorageContext interface {
 	SetProtectedStorage(key []byte, value []byte) (StorageStatus, error)
 	SetProtectedStorageToAddress(address []byte, key []byte, value []byte) (StorageStatus, error)
 	SetProtectedStorageToAddressUnmetered(address []byte, key []byte, value []byte) (StorageStatus, error)
-	UseGasForStorageLoad(tracedFunctionName string, trieDepth int64, blockchainLoadCost uint64, usedCache bool) error
+	UseGasForStorageLoad(tracedFunctionName string, address []byte, key []byte, trieDepth int64, blockchainLoadCost uint64, usedCache bool) error
 	IsUseDifferentGasCostFlagSet() bool
 	GetVmProtectedPrefix(prefix string) []byte
+	GetStorageAccessList() *StorageAccessList
 }
 
 // AsyncCallInfoHandler defines the functionality for working with AsyncCallInfo
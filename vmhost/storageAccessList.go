@@ -0,0 +1,163 @@
+package vmhost
+
+import (
+	"bytes"
+	"sort"
+)
+
+// StorageKeyAccess describes how a storage key was accessed during an execution
+type StorageKeyAccess struct {
+	Key []byte
+
+	// Reads counts all the reads of the key, CachedReads only those served from the storage updates
+	Reads       int
+	CachedReads int
+
+	// TrieDepth is the depth at which the value was found in the data trie, when it was loaded from the blockchain
+	TrieDepth uint32
+
+	// LoadGas is the gas charged for all the reads of the key
+	LoadGas uint64
+
+	Written bool
+}
+
+// AccountStorageAccess holds the storage keys accessed in the storage of an account
+type AccountStorageAccess struct {
+	Address []byte
+	Keys    []*StorageKeyAccess
+}
+
+// StorageAccessList records every storage key read and written during an execution, per account.
+// The accesses of the calls which failed and were reverted are kept, because they still happened.
+type StorageAccessList struct {
+	accounts map[string]map[string]*StorageKeyAccess
+}
+
+// NewStorageAccessList creates an empty StorageAccessList
+func NewStorageAccessList() *StorageAccessList {
+	return &StorageAccessList{
+		accounts: make(map[string]map[string]*StorageKeyAccess),
+	}
+}
+
+// RecordRead records a read of the key; the gas charged for it is added by RecordLoadGas
+func (list *StorageAccessList) RecordRead(address []byte, key []byte, trieDepth uint32, usedCache bool) {
+	access := list.getOrCreateAccess(address, key)
+	access.Reads++
+	if usedCache {
+		access.CachedReads++
+	} else {
+		access.TrieDepth = trieDepth
+	}
+}
+
+// RecordLoadGas adds the gas charged for loading a key, once its read was recorded
+func (list *StorageAccessList) RecordLoadGas(address []byte, key []byte, gas uint64) {
+	access, found := list.accounts[string(address)][string(key)]
+	if !found || access.Reads == 0 {
+		return
+	}
+
+	access.LoadGas += gas
+}
+
+// RecordWrite records a write of the key
+func (list *StorageAccessList) RecordWrite(address []byte, key []byte) {
+	access := list.getOrCreateAccess(address, key)
+	access.Written = true
+}
+
+// Accounts returns the accesses grouped by account, sorted by address, then by key
+func (list *StorageAccessList) Accounts() []*AccountStorageAccess {
+	accounts := make([]*AccountStorageAccess, 0, len(list.accounts))
+	for address, accesses := range list.accounts {
+		account := &AccountStorageAccess{
+			Address: []byte(address),
+			Keys:    make([]*StorageKeyAccess, 0, len(accesses)),
+		}
+		for _, access := range accesses {
+			account.Keys = append(account.Keys, access)
+		}
+		sort.Slice(account.Keys, func(i, j int) bool {
+			return bytes.Compare(account.Keys[i].Key, account.Keys[j].Key) < 0
+		})
+		accounts = append(accounts, account)
+	}
+
+	sort.Slice(accounts, func(i, j int) bool {
+		return bytes.Compare(accounts[i].Address, accounts[j].Address) < 0
+	})
+
+	return accounts
+}
+
+// ReadKeys returns the keys read from the storage of the given account
+func (list *StorageAccessList) ReadKeys(address []byte) [][]byte {
+	return list.filterKeys(address, func(access *StorageKeyAccess) bool {
+		return access.Reads > 0
+	})
+}
+
+// WrittenKeys returns the keys written in the storage of the given account
+func (list *StorageAccessList) WrittenKeys(address []byte) [][]byte {
+	return list.filterKeys(address, func(access *StorageKeyAccess) bool {
+		return access.Written
+	})
+}
+
+// ConflictsWith returns true if either list writes a key which the other one reads or writes,
+// meaning that the two executions cannot run in parallel
+func (list *StorageAccessList) ConflictsWith(other *StorageAccessList) bool {
+	return list.writesKeysAccessedBy(other) || other.writesKeysAccessedBy(list)
+}
+
+func (list *StorageAccessList) writesKeysAccessedBy(other *StorageAccessList) bool {
+	for address, accesses := range list.accounts {
+		otherAccesses := other.accounts[address]
+		for key, access := range accesses {
+			if !access.Written {
+				continue
+			}
+			_, accessedByOther := otherAccesses[key]
+			if accessedByOther {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (list *StorageAccessList) filterKeys(address []byte, include func(access *StorageKeyAccess) bool) [][]byte {
+	keys := make([][]byte, 0)
+	for _, access := range list.accounts[string(address)] {
+		if include(access) {
+			keys = append(keys, access.Key)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i], keys[j]) < 0
+	})
+
+	return keys
+}
+
+func (list *StorageAccessList) getOrCreateAccess(address []byte, key []byte) *StorageKeyAccess {
+	accesses, found := list.accounts[string(address)]
+	if !found {
+		accesses = make(map[string]*StorageKeyAccess)
+		list.accounts[string(address)] = accesses
+	}
+
+	access, found := accesses[string(key)]
+	if !found {
+		access = &StorageKeyAccess{
+			Key: append([]byte(nil), key...),
+		}
+		accesses[string(key)] = access
+	}
+
+	return access
+}
@@ -0,0 +1,53 @@
+package vmhost
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageAccessList_RecordsAccesses(t *testing.T) {
+	t.Parallel()
+
+	list := NewStorageAccessList()
+	list.RecordRead([]byte("sc"), []byte("b"), 3, false)
+	list.RecordRead([]byte("other"), []byte("c"), 1, false)
+	list.RecordLoadGas([]byte("sc"), []byte("b"), 100)
+	list.RecordRead([]byte("sc"), []byte("b"), 0, true)
+	list.RecordLoadGas([]byte("sc"), []byte("b"), 10)
+	list.RecordWrite([]byte("sc"), []byte("a"))
+	// the gas of a key which was not read is not recorded
+	list.RecordLoadGas([]byte("sc"), []byte("a"), 50)
+
+	accounts := list.Accounts()
+	require.Len(t, accounts, 2)
+	require.Equal(t, []byte("other"), accounts[0].Address)
+
+	keys := accounts[1].Keys
+	require.Len(t, keys, 2)
+	require.Equal(t, &StorageKeyAccess{Key: []byte("a"), Written: true}, keys[0])
+	require.Equal(t, &StorageKeyAccess{Key: []byte("b"), Reads: 2, CachedReads: 1, TrieDepth: 3, LoadGas: 110}, keys[1])
+
+	require.Equal(t, [][]byte{[]byte("b")}, list.ReadKeys([]byte("sc")))
+	require.Equal(t, [][]byte{[]byte("a")}, list.WrittenKeys([]byte("sc")))
+}
+
+func TestStorageAccessList_ConflictsWith(t *testing.T) {
+	t.Parallel()
+
+	reader := NewStorageAccessList()
+	reader.RecordRead([]byte("sc"), []byte("key"), 0, false)
+
+	otherReader := NewStorageAccessList()
+	otherReader.RecordRead([]byte("sc"), []byte("key"), 0, false)
+	require.False(t, reader.ConflictsWith(otherReader))
+
+	writer := NewStorageAccessList()
+	writer.RecordWrite([]byte("sc"), []byte("key"))
+	require.True(t, reader.ConflictsWith(writer))
+	require.True(t, writer.ConflictsWith(reader))
+
+	otherWriter := NewStorageAccessList()
+	otherWriter.RecordWrite([]byte("other"), []byte("key"))
+	require.False(t, writer.ConflictsWith(otherWriter))
+}
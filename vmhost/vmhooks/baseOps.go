@@ -503,6 +503,8 @@ func (context *VMHooksImpl) GetESDTLocalRoles(tokenIdHandle int32) int64 {
 
 	err = storage.UseGasForStorageLoad(
 		storageLoadName,
+		runtime.GetContextAddress(),
+		key,
 		int64(trieDepth),
 		metering.GasSchedule().BaseOpsAPICost.StorageLoad,
 		usedCache)
@@ -1720,6 +1722,8 @@ func (context *VMHooksImpl) StorageLoadLength(keyOffset executor.MemPtr, keyLeng
 
 	err = storage.UseGasForStorageLoad(
 		storageLoadLengthName,
+		runtime.GetContextAddress(),
+		key,
 		int64(trieDepth),
 		metering.GasSchedule().BaseOpsAPICost.StorageLoad,
 		usedCache)
@@ -1791,6 +1795,8 @@ func StorageLoadFromAddressWithTypedArgs(host vmhost.VMHost, address []byte, key
 	}
 	err = storage.UseGasForStorageLoad(
 		storageLoadFromAddressName,
+		address,
+		key,
 		int64(trieDepth),
 		metering.GasSchedule().BaseOpsAPICost.StorageLoad,
 		usedCache)
@@ -1846,6 +1852,8 @@ func StorageLoadWithWithTypedArgs(host vmhost.VMHost, key []byte) ([]byte, error
 
 	err = storage.UseGasForStorageLoad(
 		storageLoadName,
+		host.Runtime().GetContextAddress(),
+		key,
 		int64(trieDepth),
 		metering.GasSchedule().BaseOpsAPICost.StorageLoad,
 		usedCache)
@@ -1923,6 +1931,8 @@ func (context *VMHooksImpl) GetStorageLock(keyOffset executor.MemPtr, keyLength
 
 	err = storage.UseGasForStorageLoad(
 		getStorageLockName,
+		runtime.GetContextAddress(),
+		timeLockKey,
 		int64(trieDepth),
 		metering.GasSchedule().BaseOpsAPICost.StorageLoad,
 		usedCache)
@@ -2139,6 +2149,8 @@ func (context *VMHooksImpl) GetCurrentESDTNFTNonce(
 
 	err = storage.UseGasForStorageLoad(
 		getCurrentESDTNFTNonceName,
+		destination,
+		key,
 		int64(trieDepth),
 		metering.GasSchedule().BaseOpsAPICost.StorageLoad,
 		false)
@@ -140,6 +140,8 @@ func (context *VMHooksImpl) BigIntStorageLoadUnsigned(keyOffset executor.MemPtr,
 	}
 
 	err = storage.UseGasForStorageLoad(bigIntStorageLoadUnsignedName,
+		runtime.GetContextAddress(),
+		key,
 		int64(trieDepth),
 		metering.GasSchedule().BigIntAPICost.BigIntStorageLoadUnsigned,
 		usedCache)
@@ -0,0 +1,85 @@
+package vmhooks
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/multiversx/mx-chain-vm-go/executor"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+)
+
+var _ executor.DebugVMHooks = (*VMHooksImpl)(nil)
+
+// The debug hooks use no gas, so that the contracts consume the same gas with and without them.
+
+// DebugPrintManagedBuffer prints the managed buffer, as hex and as text
+func (context *VMHooksImpl) DebugPrintManagedBuffer(mBufferHandle int32) {
+	if !context.checkDebugHooksEnabled() {
+		return
+	}
+
+	bytes, err := context.GetManagedTypesContext().GetBytes(mBufferHandle)
+	if err != nil {
+		context.debugPrint(fmt.Sprintf("managed buffer %d: %s", mBufferHandle, err.Error()))
+		return
+	}
+
+	context.debugPrint(fmt.Sprintf("managed buffer %d: 0x%s %q", mBufferHandle, hex.EncodeToString(bytes), bytes))
+}
+
+// DebugPrintBigInt prints the big int, in decimal
+func (context *VMHooksImpl) DebugPrintBigInt(bigIntHandle int32) {
+	if !context.checkDebugHooksEnabled() {
+		return
+	}
+
+	value, err := context.GetManagedTypesContext().GetBigInt(bigIntHandle)
+	if err != nil {
+		context.debugPrint(fmt.Sprintf("big int %d: %s", bigIntHandle, err.Error()))
+		return
+	}
+
+	context.debugPrint(fmt.Sprintf("big int %d: %s", bigIntHandle, value.String()))
+}
+
+// DebugDumpManagedVector prints the managed buffers held by the managed vector, as hex
+func (context *VMHooksImpl) DebugDumpManagedVector(managedVecHandle int32) {
+	if !context.checkDebugHooksEnabled() {
+		return
+	}
+
+	buffers, _, err := context.GetManagedTypesContext().ReadManagedVecOfManagedBuffers(managedVecHandle)
+	if err != nil {
+		context.debugPrint(fmt.Sprintf("managed vector %d: %s", managedVecHandle, err.Error()))
+		return
+	}
+
+	items := make([]string, len(buffers))
+	for i, buffer := range buffers {
+		items[i] = "0x" + hex.EncodeToString(buffer)
+	}
+	context.debugPrint(fmt.Sprintf("managed vector %d, %d items: [%s]", managedVecHandle, len(buffers), strings.Join(items, ", ")))
+}
+
+// DebugPrintGasLeft prints the gas left for the current call
+func (context *VMHooksImpl) DebugPrintGasLeft() {
+	if !context.checkDebugHooksEnabled() {
+		return
+	}
+
+	context.debugPrint(fmt.Sprintf("gas left: %d", context.GetMeteringContext().GasLeft()))
+}
+
+func (context *VMHooksImpl) checkDebugHooksEnabled() bool {
+	if context.GetRuntimeContext().DebugHooksEnabled() {
+		return true
+	}
+
+	_ = context.WithFault(vmhost.ErrDebugHooksDisabled, true)
+	return false
+}
+
+func (context *VMHooksImpl) debugPrint(message string) {
+	context.GetRuntimeContext().DebugPrint(message)
+}
@@ -549,6 +549,8 @@ func (context *VMHooksImpl) MBufferStorageLoad(keyHandle int32, destinationHandl
 
 	err = storage.UseGasForStorageLoad(
 		mBufferStorageLoadName,
+		runtime.GetContextAddress(),
+		key,
 		int64(trieDepth),
 		metering.GasSchedule().ManagedBufferAPICost.MBufferStorageLoad,
 		usedCache)
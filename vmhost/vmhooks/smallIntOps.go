@@ -164,6 +164,8 @@ func (context *VMHooksImpl) SmallIntStorageLoadUnsigned(keyOffset executor.MemPt
 
 	err = storage.UseGasForStorageLoad(
 		smallIntStorageLoadUnsignedName,
+		runtime.GetContextAddress(),
+		key,
 		int64(trieDepth),
 		metering.GasSchedule().BaseOpsAPICost.Int64StorageLoad,
 		usedCache)
@@ -199,6 +201,8 @@ func (context *VMHooksImpl) SmallIntStorageLoadSigned(keyOffset executor.MemPtr,
 
 	err = storage.UseGasForStorageLoad(
 		smallIntStorageLoadSignedName,
+		runtime.GetContextAddress(),
+		key,
 		int64(trieDepth),
 		metering.GasSchedule().BaseOpsAPICost.Int64StorageLoad,
 		usedCache)
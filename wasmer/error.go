@@ -17,6 +17,12 @@ var ErrInvalidBytecode = errors.New("invalid bytecode")
 // ErrCachingFailed indicates that creating the precompilation cache of an instance has failed
 var ErrCachingFailed = errors.New("instance caching failed")
 
+// ErrOpcodeTraceNotProduced indicates that Wasmer did not write the opcodes of an instance compiled with OpcodeTrace
+var ErrOpcodeTraceNotProduced = errors.New("the opcode trace was not produced")
+
+// ErrInvalidOpcodeTrace indicates that the opcodes written by Wasmer could not be read
+var ErrInvalidOpcodeTrace = errors.New("invalid opcode trace")
+
 // GetLastError returns the last error message if any, otherwise returns an error.
 func GetLastError() (string, error) {
 	var errorLength = cWasmerLastErrorLength()
@@ -0,0 +1,75 @@
+package wasmer
+
+// // Declare the function signatures (see [cgo](https://golang.org/cmd/cgo/)).
+//
+// #include <stdlib.h>
+// typedef int int32_t;
+//
+// extern void v1_5_debugPrintManagedBuffer(void* context, int32_t mBufferHandle);
+// extern void v1_5_debugPrintBigInt(void* context, int32_t bigIntHandle);
+// extern void v1_5_debugDumpManagedVector(void* context, int32_t managedVecHandle);
+// extern void v1_5_debugPrintGasLeft(void* context);
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/multiversx/mx-chain-vm-go/executor"
+)
+
+// populateDebugImports populates imports with the DebugVMHooks methods, which are not generated with the VMHooks
+func populateDebugImports(imports *wasmerImports) error {
+	err := imports.append("debugPrintManagedBuffer", v1_5_debugPrintManagedBuffer, C.v1_5_debugPrintManagedBuffer)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("debugPrintBigInt", v1_5_debugPrintBigInt, C.v1_5_debugPrintBigInt)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("debugDumpManagedVector", v1_5_debugDumpManagedVector, C.v1_5_debugDumpManagedVector)
+	if err != nil {
+		return err
+	}
+
+	return imports.append("debugPrintGasLeft", v1_5_debugPrintGasLeft, C.v1_5_debugPrintGasLeft)
+}
+
+func getDebugVMHooksFromContextRawPtr(context unsafe.Pointer) (executor.DebugVMHooks, bool) {
+	debugVMHooks, ok := getVMHooksFromContextRawPtr(context).(executor.DebugVMHooks)
+	return debugVMHooks, ok
+}
+
+//export v1_5_debugPrintManagedBuffer
+func v1_5_debugPrintManagedBuffer(context unsafe.Pointer, mBufferHandle int32) {
+	debugVMHooks, ok := getDebugVMHooksFromContextRawPtr(context)
+	if ok {
+		debugVMHooks.DebugPrintManagedBuffer(mBufferHandle)
+	}
+}
+
+//export v1_5_debugPrintBigInt
+func v1_5_debugPrintBigInt(context unsafe.Pointer, bigIntHandle int32) {
+	debugVMHooks, ok := getDebugVMHooksFromContextRawPtr(context)
+	if ok {
+		debugVMHooks.DebugPrintBigInt(bigIntHandle)
+	}
+}
+
+//export v1_5_debugDumpManagedVector
+func v1_5_debugDumpManagedVector(context unsafe.Pointer, managedVecHandle int32) {
+	debugVMHooks, ok := getDebugVMHooksFromContextRawPtr(context)
+	if ok {
+		debugVMHooks.DebugDumpManagedVector(managedVecHandle)
+	}
+}
+
+//export v1_5_debugPrintGasLeft
+func v1_5_debugPrintGasLeft(context unsafe.Pointer) {
+	debugVMHooks, ok := getDebugVMHooksFromContextRawPtr(context)
+	if ok {
+		debugVMHooks.DebugPrintGasLeft()
+	}
+}
@@ -14,14 +14,25 @@ type WasmerExecutor struct {
 	eiFunctionNames vmcommon.FunctionNames
 	vmHooks         executor.VMHooks
 	vmHooksPtr      uintptr
+	opcodeCosts     *executor.WASMOpcodeCost
 }
 
 // CreateExecutor creates a new wasmer executor.
 func CreateExecutor() (*WasmerExecutor, error) {
+	return createExecutor(false)
+}
+
+// createExecutor creates a new wasmer executor, whose function names include the debug hooks if they are enabled.
+func createExecutor(debugHooksEnabled bool) (*WasmerExecutor, error) {
 	functionNames, err := injectCgoFunctionPointers()
 	if err != nil {
 		return nil, err
 	}
+	if !debugHooksEnabled {
+		for _, debugHookName := range executor.DebugVMHookNames {
+			delete(functionNames, debugHookName)
+		}
+	}
 
 	ForceInstallSighandlers()
 
@@ -32,6 +43,7 @@ func CreateExecutor() (*WasmerExecutor, error) {
 
 // SetOpcodeCosts sets gas costs globally inside the Wasmer executor.
 func (wasmerExecutor *WasmerExecutor) SetOpcodeCosts(opcodeCosts *executor.WASMOpcodeCost) {
+	wasmerExecutor.opcodeCosts = opcodeCosts
 	SetOpcodeCosts(opcodeCosts)
 }
 
@@ -41,12 +53,18 @@ func (wasmerExecutor *WasmerExecutor) FunctionNames() vmcommon.FunctionNames {
 }
 
 // NewInstanceWithOptions creates a new Wasmer instance from WASM bytecode,
-// respecting the provided options
+// respecting the provided options. With OpcodeTrace, the instance also holds the opcodes it was compiled from.
 func (wasmerExecutor *WasmerExecutor) NewInstanceWithOptions(
 	contractCode []byte,
 	options executor.CompilationOptions,
 ) (executor.Instance, error) {
-	instance, err := NewInstanceWithOptions(contractCode, options)
+	var instance *WasmerInstance
+	var err error
+	if options.OpcodeTrace {
+		instance, err = newTracedInstance(contractCode, options, wasmerExecutor.opcodeCosts)
+	} else {
+		instance, err = NewInstanceWithOptions(contractCode, options)
+	}
 	if err == nil {
 		instance.SetVMHooksPtr(wasmerExecutor.vmHooksPtr)
 	}
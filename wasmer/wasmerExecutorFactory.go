@@ -18,7 +18,7 @@ func (wef *WasmerExecutorFactory) CreateExecutor(args executor.ExecutorFactoryAr
 		SetSIGSEGVPassthrough()
 	}
 
-	exec, err := CreateExecutor()
+	exec, err := createExecutor(args.DebugHooksEnabled)
 	if err != nil {
 		return nil, err
 	}
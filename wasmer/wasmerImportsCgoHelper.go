@@ -14,6 +14,9 @@ func getVMHooksFromContextRawPtr(contextPtr unsafe.Pointer) executor.VMHooks {
 	return *(*executor.VMHooks)(unsafe.Pointer(vmHooksPtr))
 }
 
+// injectCgoFunctionPointers caches the import object shared by all the instances. It always holds the debug
+// imports, so that executors with and without debug hooks can coexist; the hosts without debug hooks reject
+// the contracts importing them when validating the code.
 func injectCgoFunctionPointers() (vmcommon.FunctionNames, error) {
 	importsInfo := newWasmerImports()
 	defer importsInfo.Close()
@@ -23,6 +26,11 @@ func injectCgoFunctionPointers() (vmcommon.FunctionNames, error) {
 		return nil, err
 	}
 
+	err = populateDebugImports(importsInfo)
+	if err != nil {
+		return nil, err
+	}
+
 	wasmImportsCPointer, numberOfImports := generateWasmerImports(importsInfo)
 
 	var result = cWasmerCacheImportObjectFromImports(
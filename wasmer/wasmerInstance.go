@@ -101,6 +101,9 @@ type WasmerInstance struct {
 	InstanceCtx InstanceContext
 
 	vmHooksPtr unsafe.Pointer
+
+	// The opcodes of the contract functions, when compiled with OpcodeTrace.
+	compiledOpcodes []executor.OpcodeTraceEntry
 }
 
 func newWrappedError(target error) error {
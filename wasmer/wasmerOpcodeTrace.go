@@ -0,0 +1,99 @@
+package wasmer
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/multiversx/mx-chain-vm-go/executor"
+)
+
+// opcodeTraceFileName is the file in the working directory where the opcode tracing middleware of
+// Wasmer writes the opcodes of the functions it compiles, one "[fn: <index>, operator: <opcode>]" line each.
+const opcodeTraceFileName = "opcode.trace"
+
+// the trace file is shared by the whole process, so the traced compilations run one at a time
+var opcodeTraceMutex sync.Mutex
+
+var opcodeTraceLine = regexp.MustCompile(`^\[fn: (\d+), operator: (.*)\]$`)
+
+// newTracedInstance compiles the contract with opcode tracing and collects the opcodes written by Wasmer.
+func newTracedInstance(
+	contractCode []byte,
+	options executor.CompilationOptions,
+	opcodeCosts *executor.WASMOpcodeCost,
+) (*WasmerInstance, error) {
+	opcodeTraceMutex.Lock()
+	defer opcodeTraceMutex.Unlock()
+
+	_ = os.Remove(opcodeTraceFileName)
+	defer func() {
+		_ = os.Remove(opcodeTraceFileName)
+	}()
+
+	instance, err := NewInstanceWithOptions(contractCode, options)
+	if err != nil {
+		return instance, err
+	}
+
+	traceFile, err := os.Open(opcodeTraceFileName)
+	if err != nil {
+		instance.Clean()
+		return nil, newWrappedError(ErrOpcodeTraceNotProduced)
+	}
+	defer func() {
+		_ = traceFile.Close()
+	}()
+
+	instance.compiledOpcodes, err = parseOpcodeTrace(traceFile, opcodeCosts)
+	if err != nil {
+		instance.Clean()
+		return nil, err
+	}
+
+	return instance, nil
+}
+
+// parseOpcodeTrace reads the opcodes written by the opcode tracing middleware,
+// keeping only the name of each opcode, without its immediates.
+func parseOpcodeTrace(reader io.Reader, opcodeCosts *executor.WASMOpcodeCost) ([]executor.OpcodeTraceEntry, error) {
+	entries := make([]executor.OpcodeTraceEntry, 0)
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 {
+			continue
+		}
+
+		match := opcodeTraceLine.FindStringSubmatch(line)
+		if match == nil {
+			return nil, ErrInvalidOpcodeTrace
+		}
+		functionIndex, err := strconv.ParseUint(match[1], 10, 32)
+		if err != nil {
+			return nil, ErrInvalidOpcodeTrace
+		}
+
+		opcode := match[2]
+		if end := strings.IndexAny(opcode, " {("); end >= 0 {
+			opcode = opcode[:end]
+		}
+		entries = append(entries, executor.OpcodeTraceEntry{
+			FunctionIndex: uint32(functionIndex),
+			Opcode:        opcode,
+			GasPoints:     executor.OpcodeGasPoints(opcodeCosts, opcode),
+		})
+	}
+
+	return entries, scanner.Err()
+}
+
+// CompiledOpcodes returns the opcodes of the contract functions, if the instance was compiled with OpcodeTrace.
+func (instance *WasmerInstance) CompiledOpcodes() []executor.OpcodeTraceEntry {
+	return instance.compiledOpcodes
+}
@@ -0,0 +1,43 @@
+package wasmer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-go/executor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOpcodeTrace(t *testing.T) {
+	trace := `[fn: 0, operator: LocalGet { local_index: 0 }]
+[fn: 0, operator: I32Const { value: 1 }]
+[fn: 0, operator: I32Add]
+
+[fn: 1, operator: Call { function_index: 0 }]
+[fn: 1, operator: End]
+`
+	opcodeCosts := &executor.WASMOpcodeCost{
+		LocalGet: 3,
+		I32Const: 1,
+		I32Add:   2,
+		Call:     5,
+	}
+
+	entries, err := parseOpcodeTrace(strings.NewReader(trace), opcodeCosts)
+	require.Nil(t, err)
+	require.Equal(t, []executor.OpcodeTraceEntry{
+		{FunctionIndex: 0, Opcode: "LocalGet", GasPoints: 3},
+		{FunctionIndex: 0, Opcode: "I32Const", GasPoints: 1},
+		{FunctionIndex: 0, Opcode: "I32Add", GasPoints: 2},
+		{FunctionIndex: 1, Opcode: "Call", GasPoints: 5},
+		{FunctionIndex: 1, Opcode: "End", GasPoints: 0},
+	}, entries)
+}
+
+func TestParseOpcodeTrace_InvalidLine(t *testing.T) {
+	_, err := parseOpcodeTrace(strings.NewReader("[fn: x, operator: I32Add]\n"), nil)
+	require.Equal(t, ErrInvalidOpcodeTrace, err)
+
+	_, err = parseOpcodeTrace(strings.NewReader("I32Add\n"), nil)
+	require.Equal(t, ErrInvalidOpcodeTrace, err)
+}
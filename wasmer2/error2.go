@@ -14,6 +14,14 @@ var ErrInvalidBytecode = errors.New("invalid bytecode")
 
 var ErrCachingFailed = errors.New("instance caching failed")
 
+// ErrDebugHooksNotSupported signals that the debug hooks were requested, but the hooks table of the wasmer2
+// library is fixed and does not contain them; the wasmer executor provides them
+var ErrDebugHooksNotSupported = errors.New("the debug hooks are not supported by the wasmer2 executor, the default one; use the wasmer executor")
+
+// ErrOpcodeTraceNotSupported signals that opcode tracing was requested, but the wasmer2 library has no
+// opcode tracing middleware; the wasmer executor provides it
+var ErrOpcodeTraceNotSupported = errors.New("opcode tracing is not supported by the wasmer2 executor")
+
 // GetLastError returns the last error message if any, otherwise returns an error.
 func GetLastError() (string, error) {
 	var errorLength = cWasmerLastErrorLength()
@@ -18,6 +18,13 @@ func ExecutorFactory() *Wasmer2ExecutorFactory {
 
 // CreateExecutor creates a new Executor instance.
 func (wef *Wasmer2ExecutorFactory) CreateExecutor(args executor.ExecutorFactoryArgs) (executor.Executor, error) {
+	if args.DebugHooksEnabled {
+		return nil, ErrDebugHooksNotSupported
+	}
+	if args.OpcodeTraceEnabled {
+		return nil, ErrOpcodeTraceNotSupported
+	}
+
 	signal.Reset()
 
 	executor, err := CreateExecutor()